@@ -0,0 +1 @@
+ALTER TABLE "records" ADD COLUMN "tags" text[];
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "records" ADD COLUMN "notes" text;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "records" ADD COLUMN "rating" integer;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "records" ADD COLUMN "play_count" integer DEFAULT 0 NOT NULL;--> statement-breakpoint
+ALTER TABLE "records" ADD COLUMN "last_played_at" timestamp;
\ No newline at end of file
@@ -39,6 +39,17 @@ export const recordsTable = pgTable("records", {
   vinylColor: text("vinyl_color"), // e.g., "Black", "Clear", "Blue Marble"
   isShapedVinyl: boolean("is_shaped_vinyl").default(false), // true if not round (picture disc, shaped, etc.)
 
+  // Personal tags (user-defined, never from Discogs), e.g. "to-sell", "gift"
+  tags: text("tags").array(),
+
+  // Personal notes (condition, where it was bought, pressing details)
+  notes: text("notes"),
+  rating: integer("rating"), // 1-5 stars; null when unrated
+
+  // Listening log
+  playCount: integer("play_count").notNull().default(0),
+  lastPlayedAt: timestamp("last_played_at"),
+
   // Data source tracking
   dataSource: text("data_source").notNull().default("discogs"), // 'discogs' or 'manual'
 
@@ -1,10 +1,18 @@
 package config
 
 import (
-	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
 )
 
 const (
@@ -17,8 +25,76 @@ type Config struct {
 	DiscogsUsername  string
 	DiscogsToken     string
 	DiscogsUserAgent string
+	AuditLog         string
+	ReverseList      bool
+	LineNumbers      bool
+	ListThumbnails   bool
+	ListStripes      bool
+	Theme            string
+	ImageProtocol    string
+	SortColumn       string
+
+	// IgnoreArticles sorts artists without a leading "The", "A" or "An".
+	IgnoreArticles bool
+
+	// PageSize <= 0 pages by the visible list height.
+	PageSize int
+
+	// ListColumns names the list's columns in order; empty keeps the
+	// default set.
+	ListColumns []string
+
+	// SearchDebounce is how long a database search waits after the last
+	// keystroke; 0 searches immediately.
+	SearchDebounce time.Duration
+
+	// ReadOnly disables every editing key and opens database sessions
+	// that refuse writes.
+	ReadOnly bool
+
+	// DBConnectAttempts is how many times startup tries to reach the
+	// database; the wait between tries starts at DBConnectRetryDelay and
+	// doubles.
+	DBConnectAttempts   int
+	DBConnectRetryDelay time.Duration
+
+	// DBMaxConns caps the pool, idle connections are closed after
+	// DBMaxConnIdleTime, and each connect or ping gives up after
+	// DBConnectTimeout.
+	DBMaxConns        int
+	DBMaxConnIdleTime time.Duration
+	DBConnectTimeout  time.Duration
+
+	// DBQueryTimeout bounds each query the TUI runs; 0 disables it.
+	DBQueryTimeout time.Duration
+
+	// ImageCacheMaxMB <= 0 disables the on-disk cover cache;
+	// ImageCacheTTLDays <= 0 keeps entries until evicted by size.
+	ImageCacheDir     string
+	ImageCacheMaxMB   int
+	ImageCacheTTLDays int
+
+	// ImageFetchTimeout bounds each cover download.
+	ImageFetchTimeout time.Duration
+
+	// FileErr reports a config file that exists but isn't valid TOML.
+	FileErr error
 }
 
+const (
+	DefaultImageCacheMaxMB   = 200
+	DefaultImageCacheTTLDays = 30
+	DefaultImageFetchTimeout = 10 * time.Second
+	DefaultSearchDebounce    = 250 * time.Millisecond
+
+	DefaultDBConnectAttempts   = 5
+	DefaultDBConnectRetryDelay = 500 * time.Millisecond
+	DefaultDBMaxConns          = 4
+	DefaultDBMaxConnIdleTime   = 5 * time.Minute
+	DefaultDBConnectTimeout    = 5 * time.Second
+	DefaultDBQueryTimeout      = 30 * time.Second
+)
+
 func configPath() string {
 	candidates := configPaths()
 	for _, p := range candidates {
@@ -35,76 +111,221 @@ func configPath() string {
 
 func configPaths() []string {
 	var paths []string
-	if dir, err := os.UserConfigDir(); err == nil {
-		paths = append(paths, filepath.Join(dir, ConfigDir, ConfigFile))
-	}
-	home := os.Getenv("HOME")
-	if home != "" {
-		xdg := filepath.Join(home, ".config", ConfigDir, ConfigFile)
-		// Avoid duplicates when UserConfigDir already returns ~/.config.
-		if len(paths) == 0 || paths[0] != xdg {
-			paths = append(paths, xdg)
+	add := func(dir string) {
+		p := filepath.Join(dir, ConfigDir, ConfigFile)
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
 		}
 	}
+	// XDG_CONFIG_HOME is honoured on every platform, not just where
+	// UserConfigDir consults it. Relative values are ignored per the spec.
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdg) {
+		add(xdg)
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		add(dir)
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		add(filepath.Join(home, ".config"))
+	}
 	return paths
 }
 
+// fileConfig mirrors config.toml. Every setting may be written either as
+// a top-level key (database_url = ...) or inside its section
+// ([database] url = ...); the section form wins when both are present.
+type fileConfig struct {
+	DatabaseURL       string   `toml:"database_url"`
+	DiscogsUsername   string   `toml:"discogs_username"`
+	DiscogsToken      string   `toml:"discogs_token"`
+	DiscogsUserAgent  string   `toml:"discogs_user_agent"`
+	AuditLog          string   `toml:"audit_log"`
+	ReadOnly          bool     `toml:"read_only"`
+	ReverseList       bool     `toml:"reverse_list"`
+	LineNumbers       bool     `toml:"line_numbers"`
+	ListThumbnails    bool     `toml:"list_thumbnails"`
+	ListStripes       bool     `toml:"list_stripes"`
+	Theme             string   `toml:"theme"`
+	ImageCacheDir     string   `toml:"image_cache_dir"`
+	ImageCacheMaxMB   *int     `toml:"image_cache_max_mb"`
+	ImageCacheTTLDays *int     `toml:"image_cache_ttl_days"`
+	ImageFetchTimeout string   `toml:"image_fetch_timeout"`
+	ImageProtocol     string   `toml:"image_protocol"`
+	SortColumn        string   `toml:"sort_column"`
+	IgnoreArticles    bool     `toml:"ignore_articles"`
+	PageSize          int      `toml:"page_size"`
+	ListColumns       []string `toml:"list_columns"`
+	SearchDebounce    string   `toml:"search_debounce"`
+
+	DBConnectAttempts   *int   `toml:"db_connect_attempts"`
+	DBConnectRetryDelay string `toml:"db_connect_retry_delay"`
+	DBMaxConns          *int   `toml:"db_max_conns"`
+	DBMaxConnIdleTime   string `toml:"db_max_conn_idle_time"`
+	DBConnectTimeout    string `toml:"db_connect_timeout"`
+	DBQueryTimeout      string `toml:"db_query_timeout"`
+
+	Database struct {
+		URL               string `toml:"url"`
+		ReadOnly          bool   `toml:"read_only"`
+		ConnectAttempts   *int   `toml:"connect_attempts"`
+		ConnectRetryDelay string `toml:"connect_retry_delay"`
+		MaxConns          *int   `toml:"max_conns"`
+		MaxConnIdleTime   string `toml:"max_conn_idle_time"`
+		ConnectTimeout    string `toml:"connect_timeout"`
+		QueryTimeout      string `toml:"query_timeout"`
+	} `toml:"database"`
+	Discogs struct {
+		Username  string `toml:"username"`
+		Token     string `toml:"token"`
+		UserAgent string `toml:"user_agent"`
+	} `toml:"discogs"`
+	UI struct {
+		Theme          string   `toml:"theme"`
+		ReverseList    bool     `toml:"reverse_list"`
+		LineNumbers    bool     `toml:"line_numbers"`
+		Thumbnails     bool     `toml:"thumbnails"`
+		Stripes        bool     `toml:"stripes"`
+		ImageProtocol  string   `toml:"image_protocol"`
+		SortColumn     string   `toml:"sort_column"`
+		IgnoreArticles bool     `toml:"ignore_articles"`
+		PageSize       int      `toml:"page_size"`
+		Columns        []string `toml:"columns"`
+		SearchDebounce string   `toml:"search_debounce"`
+	} `toml:"ui"`
+	ImageCache struct {
+		Dir          string `toml:"dir"`
+		MaxMB        *int   `toml:"max_mb"`
+		TTLDays      *int   `toml:"ttl_days"`
+		FetchTimeout string `toml:"fetch_timeout"`
+	} `toml:"image_cache"`
+}
+
+// readFile decodes the config file at path. A missing file is not an error.
+func readFile(path string) (fileConfig, error) {
+	var f fileConfig
+	if path == "" {
+		return f, nil
+	}
+	if _, err := toml.DecodeFile(path, &f); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fileConfig{}, nil
+		}
+		return fileConfig{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Load reads the config file, then lets any non-empty environment variable
+// override the matching setting. If the file can't be parsed, FileErr is
+// set and only the environment is used.
 func Load() Config {
-	var cfg Config
+	f, err := readFile(configPath())
+	return Config{
+		DatabaseURL:         envString("DATABASE_URL", cmp.Or(f.Database.URL, f.DatabaseURL)),
+		DiscogsUsername:     envString("DISCOGS_USERNAME", cmp.Or(f.Discogs.Username, f.DiscogsUsername)),
+		DiscogsToken:        envString("DISCOGS_TOKEN", cmp.Or(f.Discogs.Token, f.DiscogsToken)),
+		DiscogsUserAgent:    envString("DISCOGS_USER_AGENT", cmp.Or(f.Discogs.UserAgent, f.DiscogsUserAgent)),
+		AuditLog:            envString("AUDIT_LOG", f.AuditLog),
+		ReadOnly:            envBool("READ_ONLY", f.Database.ReadOnly || f.ReadOnly),
+		ReverseList:         envBool("REVERSE_LIST", f.UI.ReverseList || f.ReverseList),
+		LineNumbers:         envBool("LINE_NUMBERS", f.UI.LineNumbers || f.LineNumbers),
+		ListThumbnails:      envBool("LIST_THUMBNAILS", f.UI.Thumbnails || f.ListThumbnails),
+		ListStripes:         envBool("LIST_STRIPES", f.UI.Stripes || f.ListStripes),
+		Theme:               envString("THEME", cmp.Or(f.UI.Theme, f.Theme)),
+		ImageProtocol:       envString("IMAGE_PROTOCOL", cmp.Or(f.UI.ImageProtocol, f.ImageProtocol)),
+		SortColumn:          envString("SORT_COLUMN", cmp.Or(f.UI.SortColumn, f.SortColumn)),
+		IgnoreArticles:      envBool("IGNORE_ARTICLES", f.UI.IgnoreArticles || f.IgnoreArticles),
+		PageSize:            envInt("PAGE_SIZE", new(cmp.Or(f.UI.PageSize, f.PageSize)), 0),
+		ListColumns:         envList("LIST_COLUMNS", orList(f.UI.Columns, f.ListColumns)),
+		SearchDebounce:      envDuration("SEARCH_DEBOUNCE", cmp.Or(f.UI.SearchDebounce, f.SearchDebounce), DefaultSearchDebounce),
+		ImageCacheDir:       envString("IMAGE_CACHE_DIR", cmp.Or(f.ImageCache.Dir, f.ImageCacheDir)),
+		ImageCacheMaxMB:     envInt("IMAGE_CACHE_MAX_MB", cmp.Or(f.ImageCache.MaxMB, f.ImageCacheMaxMB), DefaultImageCacheMaxMB),
+		ImageCacheTTLDays:   envInt("IMAGE_CACHE_TTL_DAYS", cmp.Or(f.ImageCache.TTLDays, f.ImageCacheTTLDays), DefaultImageCacheTTLDays),
+		ImageFetchTimeout:   envDuration("IMAGE_FETCH_TIMEOUT", cmp.Or(f.ImageCache.FetchTimeout, f.ImageFetchTimeout), DefaultImageFetchTimeout),
+		DBConnectAttempts:   envInt("DB_CONNECT_ATTEMPTS", cmp.Or(f.Database.ConnectAttempts, f.DBConnectAttempts), DefaultDBConnectAttempts),
+		DBConnectRetryDelay: envDuration("DB_CONNECT_RETRY_DELAY", cmp.Or(f.Database.ConnectRetryDelay, f.DBConnectRetryDelay), DefaultDBConnectRetryDelay),
+		DBMaxConns:          envInt("DB_MAX_CONNS", cmp.Or(f.Database.MaxConns, f.DBMaxConns), DefaultDBMaxConns),
+		DBMaxConnIdleTime:   envDuration("DB_MAX_CONN_IDLE_TIME", cmp.Or(f.Database.MaxConnIdleTime, f.DBMaxConnIdleTime), DefaultDBMaxConnIdleTime),
+		DBConnectTimeout:    envDuration("DB_CONNECT_TIMEOUT", cmp.Or(f.Database.ConnectTimeout, f.DBConnectTimeout), DefaultDBConnectTimeout),
+		DBQueryTimeout:      envDuration("DB_QUERY_TIMEOUT", cmp.Or(f.Database.QueryTimeout, f.DBQueryTimeout), DefaultDBQueryTimeout),
+		FileErr:             err,
+	}
+}
 
-	if v := os.Getenv("DATABASE_URL"); v != "" {
-		cfg.DatabaseURL = v
-	} else {
-		cfg.DatabaseURL = readKey(configPath(), "database_url")
+func envString(key, fileValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
+	return fileValue
+}
 
-	if v := os.Getenv("DISCOGS_USERNAME"); v != "" {
-		cfg.DiscogsUsername = v
-	} else {
-		cfg.DiscogsUsername = readKey(configPath(), "discogs_username")
+func envBool(key string, fileValue bool) bool {
+	if v := os.Getenv(key); v != "" {
+		return parseBool(v)
 	}
+	return fileValue
+}
 
-	if v := os.Getenv("DISCOGS_TOKEN"); v != "" {
-		cfg.DiscogsToken = v
-	} else {
-		cfg.DiscogsToken = readKey(configPath(), "discogs_token")
+// envList splits a comma-separated environment value; the file's list is
+// used when the variable is unset.
+func envList(key string, fileValue []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fileValue
+	}
+	var out []string
+	for s := range strings.SplitSeq(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
 	}
+	return out
+}
 
-	if v := os.Getenv("DISCOGS_USER_AGENT"); v != "" {
-		cfg.DiscogsUserAgent = v
-	} else {
-		cfg.DiscogsUserAgent = readKey(configPath(), "discogs_user_agent")
+// orList is cmp.Or for slices: the first non-empty one.
+func orList(lists ...[]string) []string {
+	for _, l := range lists {
+		if len(l) > 0 {
+			return l
+		}
 	}
+	return nil
+}
 
-	return cfg
+func envInt(key string, fileValue *int, def int) int {
+	if v := os.Getenv(key); v != "" {
+		return parseInt(v, def)
+	}
+	if fileValue != nil {
+		return *fileValue
+	}
+	return def
+}
+
+func envDuration(key, fileValue string, def time.Duration) time.Duration {
+	return parseDuration(envString(key, fileValue), def)
+}
+
+func parseBool(v string) bool {
+	b, err := strconv.ParseBool(strings.TrimSpace(v))
+	return err == nil && b
 }
 
-func readKey(path, key string) string {
-	f, err := os.Open(path)
+// parseInt returns def for empty or malformed values.
+func parseInt(v string, def int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
 	if err != nil {
-		return ""
+		return def
 	}
-	defer func() { _ = f.Close() }()
+	return n
+}
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
-			continue
-		}
-		k, v, ok := strings.Cut(line, "=")
-		if !ok {
-			continue
-		}
-		k = strings.TrimSpace(k)
-		v = strings.TrimSpace(v)
-		v = strings.Trim(v, `"'`)
-		if k == key {
-			return v
-		}
-	}
-	if scanner.Err() != nil {
-		return ""
+// parseDuration accepts Go durations like "500ms" or "2s"; empty, malformed
+// or negative values return def.
+func parseDuration(v string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(v))
+	if err != nil || d < 0 {
+		return def
 	}
-	return ""
+	return d
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"slices"
 	"testing"
+	"time"
 )
 
 func writeFile(t *testing.T, path string, content string) {
@@ -14,96 +15,158 @@ func writeFile(t *testing.T, path string, content string) {
 	}
 }
 
-func TestReadKeyBasic(t *testing.T) {
+func TestReadFileFlatKeys(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "config.toml")
-	writeFile(t, path, "database_url = \"postgres://localhost/test\"\nother_key = \"value\"\n")
+	writeFile(t, path, "# comment\n\ndatabase_url = \"postgres://localhost/test\"\ndiscogs_token = 'literal'\nimage_cache_max_mb = 50\n")
 
-	got := readKey(path, "database_url")
-	if got != "postgres://localhost/test" {
-		t.Errorf("readKey(database_url) = %q, want %q", got, "postgres://localhost/test")
+	f, err := readFile(path)
+	if err != nil {
+		t.Fatalf("readFile: %v", err)
 	}
-
-	got = readKey(path, "other_key")
-	if got != "value" {
-		t.Errorf("readKey(other_key) = %q, want %q", got, "value")
+	if f.DatabaseURL != "postgres://localhost/test" {
+		t.Errorf("DatabaseURL = %q, want %q", f.DatabaseURL, "postgres://localhost/test")
+	}
+	if f.DiscogsToken != "literal" {
+		t.Errorf("DiscogsToken = %q, want %q", f.DiscogsToken, "literal")
+	}
+	if f.ImageCacheMaxMB == nil || *f.ImageCacheMaxMB != 50 {
+		t.Errorf("ImageCacheMaxMB = %v, want 50", f.ImageCacheMaxMB)
 	}
 }
 
-func TestReadKeyMissing(t *testing.T) {
+func TestReadFileSections(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "config.toml")
-	writeFile(t, path, `database_url = "test"`)
+	writeFile(t, path, `
+audit_log = "/tmp/audit.log"
+
+[database]
+url = "postgres://section/db"
+
+[discogs]
+username = "me"
+token = "tok"
+user_agent = "UA/1.0"
 
-	got := readKey(path, "nonexistent")
-	if got != "" {
-		t.Errorf("readKey(nonexistent) = %q, want empty", got)
+[ui]
+theme = "nord"
+reverse_list = true
+line_numbers = true
+
+[image_cache]
+dir = "/tmp/img"
+max_mb = 10
+ttl_days = 0
+
+[future]
+tags = ["a", "b"]
+`)
+
+	f, err := readFile(path)
+	if err != nil {
+		t.Fatalf("readFile: %v", err)
+	}
+	if f.Database.URL != "postgres://section/db" || f.AuditLog != "/tmp/audit.log" {
+		t.Errorf("database/audit = %q/%q", f.Database.URL, f.AuditLog)
+	}
+	if f.Discogs.Username != "me" || f.Discogs.Token != "tok" || f.Discogs.UserAgent != "UA/1.0" {
+		t.Errorf("discogs = %+v", f.Discogs)
+	}
+	if f.UI.Theme != "nord" || !f.UI.ReverseList || !f.UI.LineNumbers {
+		t.Errorf("ui = %+v", f.UI)
+	}
+	if f.ImageCache.Dir != "/tmp/img" || *f.ImageCache.MaxMB != 10 || *f.ImageCache.TTLDays != 0 {
+		t.Errorf("image_cache = %+v", f.ImageCache)
 	}
 }
 
-func TestReadKeyFileNotFound(t *testing.T) {
-	got := readKey("/nonexistent/path/config.toml", "database_url")
-	if got != "" {
-		t.Errorf("readKey on missing file = %q, want empty", got)
+func TestReadFileNotFound(t *testing.T) {
+	f, err := readFile("/nonexistent/path/config.toml")
+	if err != nil {
+		t.Errorf("readFile on missing file: %v", err)
+	}
+	if f.DatabaseURL != "" {
+		t.Errorf("DatabaseURL = %q, want empty", f.DatabaseURL)
 	}
 }
 
-func TestReadKeySkipsComments(t *testing.T) {
+func TestReadFileInvalid(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "config.toml")
-	writeFile(t, path, "# this is a comment\n[section]\ndatabase_url = \"test_value\"\n")
+	writeFile(t, path, "database_url = unquoted\n")
 
-	got := readKey(path, "database_url")
-	if got != "test_value" {
-		t.Errorf("readKey with comments = %q, want %q", got, "test_value")
+	if _, err := readFile(path); err == nil {
+		t.Error("readFile should reject invalid TOML")
 	}
 }
 
-func TestReadKeyTrimsQuotes(t *testing.T) {
-	dir := t.TempDir()
-	path := filepath.Join(dir, "config.toml")
-
-	tests := []struct {
-		name    string
-		content string
-		want    string
-	}{
-		{"double quotes", `key = "value"`, "value"},
-		{"single quotes", `key = 'value'`, "value"},
-		{"no quotes", `key = value`, "value"},
-		{"spaces around equals", `key  =  value  `, "value"},
+func TestLoadSectionKeys(t *testing.T) {
+	for _, k := range []string{"DATABASE_URL", "DISCOGS_USERNAME", "THEME", "LINE_NUMBERS", "IMAGE_CACHE_MAX_MB"} {
+		t.Setenv(k, "")
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			writeFile(t, path, tt.content)
-			got := readKey(path, "key")
-			if got != tt.want {
-				t.Errorf("readKey = %q, want %q", got, tt.want)
-			}
-		})
+	tmp := t.TempDir()
+	xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+	if err := os.MkdirAll(xdgDir, 0755); err != nil {
+		t.Fatal(err)
 	}
-}
+	writeFile(t, filepath.Join(xdgDir, ConfigFile), `
+database_url = "postgres://flat/db"
+theme = "latte"
 
-func TestReadKeySkipsBlankLines(t *testing.T) {
-	dir := t.TempDir()
-	path := filepath.Join(dir, "config.toml")
-	writeFile(t, path, "\n\n\ndatabase_url = found\n")
+[database]
+url = "postgres://section/db"
+
+[discogs]
+username = "me"
+
+[ui]
+theme = "nord"
+line_numbers = true
+
+[image_cache]
+max_mb = 0
+`)
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_CONFIG_HOME", "")
 
-	got := readKey(path, "database_url")
-	if got != "found" {
-		t.Errorf("readKey with blanks = %q, want %q", got, "found")
+	cfg := Load()
+	if cfg.FileErr != nil {
+		t.Fatalf("FileErr = %v", cfg.FileErr)
+	}
+	if cfg.DatabaseURL != "postgres://section/db" {
+		t.Errorf("DatabaseURL = %q, want section value", cfg.DatabaseURL)
+	}
+	if cfg.DiscogsUsername != "me" || cfg.Theme != "nord" || !cfg.LineNumbers {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.ImageCacheMaxMB != 0 {
+		t.Errorf("ImageCacheMaxMB = %d, want explicit 0", cfg.ImageCacheMaxMB)
+	}
+
+	t.Setenv("DATABASE_URL", "postgres://env/db")
+	if got := Load().DatabaseURL; got != "postgres://env/db" {
+		t.Errorf("DatabaseURL = %q, env should win over [database]", got)
 	}
 }
 
-func TestReadKeySkipsLinesWithoutEquals(t *testing.T) {
-	dir := t.TempDir()
-	path := filepath.Join(dir, "config.toml")
-	writeFile(t, path, "malformed line\ndatabase_url = ok\n")
+func TestLoadInvalidFileSetsFileErr(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://env/db")
+	tmp := t.TempDir()
+	xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+	if err := os.MkdirAll(xdgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(xdgDir, ConfigFile), "[ui\n")
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_CONFIG_HOME", "")
 
-	got := readKey(path, "database_url")
-	if got != "ok" {
-		t.Errorf("readKey with malformed = %q, want %q", got, "ok")
+	cfg := Load()
+	if cfg.FileErr == nil {
+		t.Error("FileErr should be set for an unparsable file")
+	}
+	if cfg.DatabaseURL != "postgres://env/db" {
+		t.Errorf("DatabaseURL = %q, env should still apply", cfg.DatabaseURL)
 	}
 }
 
@@ -304,3 +367,579 @@ func TestLoadDiscogsUserAgentEnvOverridesFile(t *testing.T) {
 		t.Errorf("Load().DiscogsUserAgent = %q, want %q", cfg.DiscogsUserAgent, "EnvAgent/3.0")
 	}
 }
+
+func TestLoadAuditLogFromFile(t *testing.T) {
+	t.Setenv("AUDIT_LOG", "")
+	t.Setenv("DATABASE_URL", "postgres://x/y")
+
+	tmp := t.TempDir()
+	xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+	if err := os.MkdirAll(xdgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(xdgDir, ConfigFile), "audit_log = \"/tmp/records-audit.log\"\n")
+
+	t.Setenv("HOME", tmp)
+
+	cfg := Load()
+	if cfg.AuditLog != "/tmp/records-audit.log" {
+		t.Errorf("Load().AuditLog = %q, want %q", cfg.AuditLog, "/tmp/records-audit.log")
+	}
+}
+
+func TestLoadReverseList(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		file string
+		want bool
+	}{
+		{"file true", "", "reverse_list = true\n", true},
+		{"file absent", "", "", false},
+		{"env overrides file", "false", "reverse_list = true\n", false},
+		{"env true", "1", "", true},
+		{"garbage is false", "maybe", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REVERSE_LIST", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().ReverseList; got != tt.want {
+				t.Errorf("Load().ReverseList = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadReadOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		file string
+		want bool
+	}{
+		{"flat key", "", "read_only = true\n", true},
+		{"database section", "", "[database]\nread_only = true\n", true},
+		{"file absent", "", "", false},
+		{"env overrides file", "false", "read_only = true\n", false},
+		{"env true", "1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("READ_ONLY", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().ReadOnly; got != tt.want {
+				t.Errorf("Load().ReadOnly = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLineNumbersFromFile(t *testing.T) {
+	t.Setenv("LINE_NUMBERS", "")
+	t.Setenv("DATABASE_URL", "postgres://x/y")
+
+	tmp := t.TempDir()
+	xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+	if err := os.MkdirAll(xdgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(xdgDir, ConfigFile), "line_numbers = true\n")
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	if !Load().LineNumbers {
+		t.Error("Load().LineNumbers = false, want true")
+	}
+}
+
+func TestLoadIgnoreArticles(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want bool
+	}{
+		{"default", "", "", false},
+		{"from file", "ignore_articles = true\n", "", true},
+		{"from ui section", "[ui]\nignore_articles = true\n", "", true},
+		{"env overrides file", "ignore_articles = true\n", "false", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("IGNORE_ARTICLES", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().IgnoreArticles; got != tt.want {
+				t.Errorf("IgnoreArticles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadListThumbnails(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want bool
+	}{
+		{"default", "", "", false},
+		{"from file", "list_thumbnails = true\n", "", true},
+		{"from ui section", "[ui]\nthumbnails = true\n", "", true},
+		{"env overrides file", "list_thumbnails = true\n", "false", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LIST_THUMBNAILS", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().ListThumbnails; got != tt.want {
+				t.Errorf("ListThumbnails = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadListStripes(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want bool
+	}{
+		{"default", "", "", false},
+		{"from file", "list_stripes = true\n", "", true},
+		{"from ui section", "[ui]\nstripes = true\n", "", true},
+		{"env overrides file", "list_stripes = true\n", "false", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LIST_STRIPES", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().ListStripes; got != tt.want {
+				t.Errorf("ListStripes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadThemeEnvOverridesFile(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://x/y")
+
+	tmp := t.TempDir()
+	xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+	if err := os.MkdirAll(xdgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(xdgDir, ConfigFile), "theme = \"latte\"\n")
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	t.Setenv("THEME", "")
+	if got := Load().Theme; got != "latte" {
+		t.Errorf("Load().Theme = %q, want latte", got)
+	}
+	t.Setenv("THEME", "nord")
+	if got := Load().Theme; got != "nord" {
+		t.Errorf("Load().Theme = %q, want nord", got)
+	}
+}
+
+func TestLoadImageCacheSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		envMB   string
+		wantMB  int
+		wantTTL int
+	}{
+		{"defaults", "", "", DefaultImageCacheMaxMB, DefaultImageCacheTTLDays},
+		{"from file", "image_cache_max_mb = 50\nimage_cache_ttl_days = 7\n", "", 50, 7},
+		{"disabled", "image_cache_max_mb = 0\n", "", 0, DefaultImageCacheTTLDays},
+		{"env overrides file", "image_cache_max_mb = 50\n", "10", 10, DefaultImageCacheTTLDays},
+		{"malformed falls back", "image_cache_max_mb = lots\n", "", DefaultImageCacheMaxMB, DefaultImageCacheTTLDays},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("IMAGE_CACHE_MAX_MB", tt.envMB)
+			t.Setenv("IMAGE_CACHE_TTL_DAYS", "")
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			cfg := Load()
+			if cfg.ImageCacheMaxMB != tt.wantMB || cfg.ImageCacheTTLDays != tt.wantTTL {
+				t.Errorf("cache settings = %d MB / %d days, want %d / %d",
+					cfg.ImageCacheMaxMB, cfg.ImageCacheTTLDays, tt.wantMB, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestLoadUIDefaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		env       map[string]string
+		wantProto string
+		wantSort  string
+		wantPage  int
+	}{
+		{"defaults", "", nil, "", "", 0},
+		{"from file", "image_protocol = \"sixel\"\nsort_column = \"year\"\npage_size = 25\n", nil, "sixel", "year", 25},
+		{"from ui section", "[ui]\nimage_protocol = \"kitty\"\nsort_column = \"label\"\npage_size = 5\n", nil, "kitty", "label", 5},
+		{"env overrides file", "image_protocol = \"sixel\"\npage_size = 25\n",
+			map[string]string{"IMAGE_PROTOCOL": "halfblock", "SORT_COLUMN": "album", "PAGE_SIZE": "10"}, "halfblock", "album", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"IMAGE_PROTOCOL", "SORT_COLUMN", "PAGE_SIZE"} {
+				t.Setenv(k, tt.env[k])
+			}
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			cfg := Load()
+			if cfg.ImageProtocol != tt.wantProto || cfg.SortColumn != tt.wantSort || cfg.PageSize != tt.wantPage {
+				t.Errorf("got proto=%q sort=%q page=%d, want %q/%q/%d",
+					cfg.ImageProtocol, cfg.SortColumn, cfg.PageSize, tt.wantProto, tt.wantSort, tt.wantPage)
+			}
+		})
+	}
+}
+
+func TestLoadDBConnectRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		envAttempts  string
+		envDelay     string
+		wantAttempts int
+		wantDelay    time.Duration
+	}{
+		{"defaults", "", "", "", DefaultDBConnectAttempts, DefaultDBConnectRetryDelay},
+		{"from file", "db_connect_attempts = 10\ndb_connect_retry_delay = \"2s\"\n", "", "", 10, 2 * time.Second},
+		{"from database section", "[database]\nconnect_attempts = 3\nconnect_retry_delay = \"250ms\"\n", "", "", 3, 250 * time.Millisecond},
+		{"env overrides file", "db_connect_attempts = 10\n", "2", "1s", 2, time.Second},
+		{"malformed delay falls back", "db_connect_retry_delay = \"soon\"\n", "", "", DefaultDBConnectAttempts, DefaultDBConnectRetryDelay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_CONNECT_ATTEMPTS", tt.envAttempts)
+			t.Setenv("DB_CONNECT_RETRY_DELAY", tt.envDelay)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			cfg := Load()
+			if cfg.DBConnectAttempts != tt.wantAttempts || cfg.DBConnectRetryDelay != tt.wantDelay {
+				t.Errorf("retry = %d / %v, want %d / %v",
+					cfg.DBConnectAttempts, cfg.DBConnectRetryDelay, tt.wantAttempts, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestLoadImageFetchTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want time.Duration
+	}{
+		{"default", "", "", DefaultImageFetchTimeout},
+		{"from file", "image_fetch_timeout = \"30s\"\n", "", 30 * time.Second},
+		{"from image_cache section", "[image_cache]\nfetch_timeout = \"5s\"\n", "", 5 * time.Second},
+		{"env overrides file", "image_fetch_timeout = \"30s\"\n", "3s", 3 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("IMAGE_FETCH_TIMEOUT", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().ImageFetchTimeout; got != tt.want {
+				t.Errorf("ImageFetchTimeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSearchDebounce(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want time.Duration
+	}{
+		{"default", "", "", DefaultSearchDebounce},
+		{"from file", "search_debounce = \"500ms\"\n", "", 500 * time.Millisecond},
+		{"from ui section", "search_debounce = \"500ms\"\n[ui]\nsearch_debounce = \"100ms\"\n", "", 100 * time.Millisecond},
+		{"zero disables", "search_debounce = \"0s\"\n", "", 0},
+		{"env overrides file", "search_debounce = \"500ms\"\n", "1s", time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SEARCH_DEBOUNCE", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().SearchDebounce; got != tt.want {
+				t.Errorf("SearchDebounce = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDBPool(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		envMax      string
+		wantMax     int
+		wantIdle    time.Duration
+		wantTimeout time.Duration
+	}{
+		{"defaults", "", "", DefaultDBMaxConns, DefaultDBMaxConnIdleTime, DefaultDBConnectTimeout},
+		{"from file", "db_max_conns = 2\ndb_max_conn_idle_time = \"1m\"\ndb_connect_timeout = \"3s\"\n", "", 2, time.Minute, 3 * time.Second},
+		{"from database section", "[database]\nmax_conns = 8\nmax_conn_idle_time = \"30s\"\nconnect_timeout = \"10s\"\n", "", 8, 30 * time.Second, 10 * time.Second},
+		{"env overrides file", "db_max_conns = 2\n", "6", 6, DefaultDBMaxConnIdleTime, DefaultDBConnectTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_MAX_CONNS", tt.envMax)
+			t.Setenv("DB_MAX_CONN_IDLE_TIME", "")
+			t.Setenv("DB_CONNECT_TIMEOUT", "")
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			cfg := Load()
+			if cfg.DBMaxConns != tt.wantMax || cfg.DBMaxConnIdleTime != tt.wantIdle || cfg.DBConnectTimeout != tt.wantTimeout {
+				t.Errorf("pool = %d / %v / %v, want %d / %v / %v",
+					cfg.DBMaxConns, cfg.DBMaxConnIdleTime, cfg.DBConnectTimeout, tt.wantMax, tt.wantIdle, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadDBQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want time.Duration
+	}{
+		{"default", "", "", DefaultDBQueryTimeout},
+		{"from file", "db_query_timeout = \"5s\"\n", "", 5 * time.Second},
+		{"from database section", "[database]\nquery_timeout = \"1m\"\n", "", time.Minute},
+		{"zero disables", "db_query_timeout = \"0s\"\n", "", 0},
+		{"env overrides file", "db_query_timeout = \"5s\"\n", "2s", 2 * time.Second},
+		{"malformed falls back", "db_query_timeout = \"soon\"\n", "", DefaultDBQueryTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_QUERY_TIMEOUT", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			xdgDir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(xdgDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(xdgDir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().DBQueryTimeout; got != tt.want {
+				t.Errorf("DBQueryTimeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigPathsXDGConfigHome(t *testing.T) {
+	home := t.TempDir()
+	xdg := t.TempDir()
+	tests := []struct {
+		name  string
+		xdg   string
+		first string
+	}{
+		{"absolute", xdg, filepath.Join(xdg, ConfigDir, ConfigFile)},
+		{"relative ignored", "relative/dir", ""},
+		{"unset", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_CONFIG_HOME", tt.xdg)
+			paths := configPaths()
+			fallback := filepath.Join(home, ".config", ConfigDir, ConfigFile)
+			if !slices.Contains(paths, fallback) {
+				t.Errorf("configPaths() = %v, want fallback %q", paths, fallback)
+			}
+			if tt.first != "" && paths[0] != tt.first {
+				t.Errorf("configPaths()[0] = %q, want %q", paths[0], tt.first)
+			}
+			for _, p := range paths {
+				if !filepath.IsAbs(p) {
+					t.Errorf("configPaths() = %v, want only absolute paths", paths)
+				}
+			}
+			seen := map[string]bool{}
+			for _, p := range paths {
+				if seen[p] {
+					t.Errorf("configPaths() = %v, duplicate %q", paths, p)
+				}
+				seen[p] = true
+			}
+		})
+	}
+}
+
+func TestLoadXDGConfigHomeWins(t *testing.T) {
+	t.Setenv("DATABASE_URL", "")
+	home := t.TempDir()
+	xdg := t.TempDir()
+	for _, dir := range []string{filepath.Join(home, ".config", ConfigDir), filepath.Join(xdg, ConfigDir)} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(home, ".config", ConfigDir, ConfigFile), `database_url = "postgres://home/db"`)
+	writeFile(t, filepath.Join(xdg, ConfigDir, ConfigFile), `database_url = "postgres://xdg/db"`)
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	if got := Load().DatabaseURL; got != "postgres://xdg/db" {
+		t.Errorf("DatabaseURL = %q, want XDG_CONFIG_HOME file", got)
+	}
+}
+
+func TestLoadListColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want []string
+	}{
+		{"default", "", "", nil},
+		{"flat key", "list_columns = [\"artist\", \"styles\"]\n", "", []string{"artist", "styles"}},
+		{"section wins", "list_columns = [\"artist\"]\n[ui]\ncolumns = [\"album\", \"size\"]\n", "", []string{"album", "size"}},
+		{"env overrides file", "list_columns = [\"artist\"]\n", " year , label ,", []string{"year", "label"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LIST_COLUMNS", tt.env)
+			t.Setenv("DATABASE_URL", "postgres://x/y")
+
+			tmp := t.TempDir()
+			dir := filepath.Join(tmp, ".config", ConfigDir)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(dir, ConfigFile), tt.file)
+			t.Setenv("HOME", tmp)
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			if got := Load().ListColumns; !slices.Equal(got, tt.want) {
+				t.Errorf("ListColumns = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	t.Setenv("DATABASE_URL", "")
+	xdg := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	path, err := Init()
+	if err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if want := filepath.Join(xdg, ConfigDir, ConfigFile); path != want {
+		t.Errorf("Init() path = %q, want %q", path, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("config perm = %o, want 600", perm)
+	}
+	dir, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := dir.Mode().Perm(); perm != 0700 {
+		t.Errorf("config dir perm = %o, want 700", perm)
+	}
+
+	cfg := Load()
+	if cfg.FileErr != nil {
+		t.Errorf("template does not parse: %v", cfg.FileErr)
+	}
+	if cfg.DatabaseURL != "" {
+		t.Errorf("template DatabaseURL = %q, want empty", cfg.DatabaseURL)
+	}
+}
+
+func TestInitNeverOverwrites(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	dir := filepath.Join(home, ".config", ConfigDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(dir, ConfigFile)
+	writeFile(t, existing, `database_url = "postgres://keep/me"`)
+
+	path, err := Init()
+	if !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("Init() error = %v, want fs.ErrExist", err)
+	}
+	if path != existing {
+		t.Errorf("Init() path = %q, want %q", path, existing)
+	}
+	data, err := os.ReadFile(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `database_url = "postgres://keep/me"` {
+		t.Errorf("existing config changed: %q", data)
+	}
+}
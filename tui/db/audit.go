@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditStore wraps a Store and appends one line per successful mutation to
+// w. Reads pass straight through to the embedded Store.
+type AuditStore struct {
+	Store
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+func NewAuditStore(inner Store, w io.Writer) *AuditStore {
+	return &AuditStore{Store: inner, w: w, now: time.Now}
+}
+
+// OpenAuditLog opens path for appending, creating it if needed.
+func OpenAuditLog(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	return f, nil
+}
+
+func (s *AuditStore) Create(ctx context.Context, r Record) (string, error) {
+	id, err := s.Store.Create(ctx, r)
+	if err != nil {
+		return "", err
+	}
+	s.log("create", id, recordSummary(r))
+	return id, nil
+}
+
+func (s *AuditStore) CreateBatch(ctx context.Context, records []Record) ([]string, error) {
+	ids, err := s.Store.CreateBatch(ctx, records)
+	if err != nil {
+		return ids, err
+	}
+	for i, r := range records {
+		s.log("create", ids[i], recordSummary(r))
+	}
+	return ids, nil
+}
+
+func (s *AuditStore) Update(ctx context.Context, r Record) error {
+	if err := s.Store.Update(ctx, r); err != nil {
+		return err
+	}
+	s.log("update", r.RecordID, recordSummary(r))
+	return nil
+}
+
+func (s *AuditStore) UpdateField(ctx context.Context, id, column, value string) error {
+	if err := s.Store.UpdateField(ctx, id, column, value); err != nil {
+		return err
+	}
+	s.log("update_field", id, fmt.Sprintf("%s=%q", column, strings.TrimSpace(value)))
+	return nil
+}
+
+func (s *AuditStore) UpdateFromDiscogs(ctx context.Context, r Record) error {
+	if err := s.Store.UpdateFromDiscogs(ctx, r); err != nil {
+		return err
+	}
+	s.log("discogs_update", r.RecordID, recordSummary(r))
+	return nil
+}
+
+func (s *AuditStore) Delete(ctx context.Context, id string) error {
+	if err := s.Store.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.log("delete", id, "")
+	return nil
+}
+
+func (s *AuditStore) DeleteBatch(ctx context.Context, ids []string) ([]string, error) {
+	deleted, err := s.Store.DeleteBatch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range deleted {
+		s.log("delete", id, "")
+	}
+	return deleted, nil
+}
+
+func (s *AuditStore) SetTags(ctx context.Context, id string, tags []string) error {
+	if err := s.Store.SetTags(ctx, id, tags); err != nil {
+		return err
+	}
+	s.log("set_tags", id, "tags="+strings.Join(tags, ","))
+	return nil
+}
+
+func (s *AuditStore) AddTag(ctx context.Context, id string, tag string) ([]string, error) {
+	tags, err := s.Store.AddTag(ctx, id, tag)
+	if err != nil {
+		return nil, err
+	}
+	s.log("add_tag", id, "tag="+strings.TrimSpace(tag))
+	return tags, nil
+}
+
+func (s *AuditStore) RemoveTag(ctx context.Context, id string, tag string) ([]string, error) {
+	tags, err := s.Store.RemoveTag(ctx, id, tag)
+	if err != nil {
+		return nil, err
+	}
+	s.log("remove_tag", id, "tag="+strings.TrimSpace(tag))
+	return tags, nil
+}
+
+func (s *AuditStore) SetNotes(ctx context.Context, id string, notes string) error {
+	if err := s.Store.SetNotes(ctx, id, notes); err != nil {
+		return err
+	}
+	s.log("set_notes", id, fmt.Sprintf("notes=%q", strings.TrimSpace(notes)))
+	return nil
+}
+
+func (s *AuditStore) SetRating(ctx context.Context, id string, rating int) error {
+	if err := s.Store.SetRating(ctx, id, rating); err != nil {
+		return err
+	}
+	s.log("set_rating", id, fmt.Sprintf("rating=%d", rating))
+	return nil
+}
+
+func (s *AuditStore) ClearRating(ctx context.Context, id string) error {
+	if err := s.Store.ClearRating(ctx, id); err != nil {
+		return err
+	}
+	s.log("clear_rating", id, "")
+	return nil
+}
+
+func (s *AuditStore) RecordPlay(ctx context.Context, id string) (Play, error) {
+	p, err := s.Store.RecordPlay(ctx, id)
+	if err != nil {
+		return p, err
+	}
+	s.log("play", id, fmt.Sprintf("plays=%d", p.Count))
+	return p, nil
+}
+
+func (s *AuditStore) MarkSyncedWithDiscogs(ctx context.Context, discogsIDs []string) error {
+	if err := s.Store.MarkSyncedWithDiscogs(ctx, discogsIDs); err != nil {
+		return err
+	}
+	if len(discogsIDs) > 0 {
+		s.log("mark_synced", "", "discogs_ids="+strings.Join(discogsIDs, ","))
+	}
+	return nil
+}
+
+func (s *AuditStore) log(action, recordID, summary string) {
+	if recordID == "" {
+		recordID = "-"
+	}
+	if summary == "" {
+		summary = "-"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = fmt.Fprintf(s.w, "%s\t%s\t%s\t%s\n",
+		s.now().UTC().Format(time.RFC3339), action, recordID, summary)
+}
+
+func recordSummary(r Record) string {
+	return fmt.Sprintf("%s — %s", r.ArtistName, r.AlbumTitle)
+}
@@ -0,0 +1,253 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	Store
+	err     error
+	created []Record
+	deleted []string
+}
+
+func (f *fakeStore) Create(_ context.Context, r Record) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.created = append(f.created, r)
+	return fmt.Sprintf("id-%d", len(f.created)), nil
+}
+
+func (f *fakeStore) CreateBatch(ctx context.Context, records []Record) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var ids []string
+	for _, r := range records {
+		id, _ := f.Create(ctx, r)
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (f *fakeStore) Update(_ context.Context, _ Record) error { return f.err }
+
+func (f *fakeStore) UpdateField(_ context.Context, _, _, _ string) error { return f.err }
+
+func (f *fakeStore) UpdateFromDiscogs(_ context.Context, _ Record) error { return f.err }
+
+func (f *fakeStore) AddTag(_ context.Context, _ string, tag string) ([]string, error) {
+	return []string{tag}, f.err
+}
+
+func (f *fakeStore) RemoveTag(_ context.Context, _ string, _ string) ([]string, error) {
+	return nil, f.err
+}
+
+func (f *fakeStore) SetNotes(_ context.Context, _ string, _ string) error { return f.err }
+
+func (f *fakeStore) SetRating(_ context.Context, _ string, _ int) error { return f.err }
+
+func (f *fakeStore) ClearRating(_ context.Context, _ string) error { return f.err }
+
+func (f *fakeStore) RecordPlay(_ context.Context, _ string) (Play, error) {
+	return Play{Count: 2, At: time.Now()}, f.err
+}
+
+func (f *fakeStore) Delete(_ context.Context, id string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeStore) DeleteBatch(_ context.Context, ids []string) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.deleted = append(f.deleted, ids...)
+	return ids, nil
+}
+
+func newTestAuditStore(inner Store) (*AuditStore, *bytes.Buffer) {
+	var buf bytes.Buffer
+	s := NewAuditStore(inner, &buf)
+	s.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return s, &buf
+}
+
+func TestAuditStoreCreateWritesEntry(t *testing.T) {
+	inner := &fakeStore{}
+	s, buf := newTestAuditStore(inner)
+
+	id, err := s.Create(context.Background(), Record{ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue"})
+	if err != nil || id != "id-1" {
+		t.Fatalf("Create = %q, %v; want id-1, nil", id, err)
+	}
+	if len(inner.created) != 1 {
+		t.Fatalf("inner Create calls = %d, want 1", len(inner.created))
+	}
+	want := "2026-01-02T03:04:05Z\tcreate\tid-1\tMiles Davis — Kind of Blue\n"
+	if got := buf.String(); got != want {
+		t.Errorf("audit entry = %q, want %q", got, want)
+	}
+}
+
+func TestAuditStoreCreateBatchWritesEntryPerRecord(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	ids, err := s.CreateBatch(context.Background(), []Record{
+		{ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue"},
+		{ArtistName: "John Coltrane", AlbumTitle: "A Love Supreme"},
+	})
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("CreateBatch = %v, %v; want two IDs", ids, err)
+	}
+	for _, want := range []string{"\tcreate\tid-1\tMiles Davis — Kind of Blue\n", "\tcreate\tid-2\tJohn Coltrane — A Love Supreme\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("audit log missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAuditStoreUpdateWritesEntry(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if err := s.Update(context.Background(), Record{RecordID: "abc-123", ArtistName: "A", AlbumTitle: "B"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "\tupdate\tabc-123\tA — B") {
+		t.Errorf("audit entry = %q, want update of abc-123", got)
+	}
+}
+
+func TestAuditStoreUpdateFromDiscogsWritesEntry(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if err := s.UpdateFromDiscogs(context.Background(), Record{RecordID: "abc-123", ArtistName: "A", AlbumTitle: "B"}); err != nil {
+		t.Fatalf("UpdateFromDiscogs: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "\tdiscogs_update\tabc-123\tA — B") {
+		t.Errorf("audit entry = %q, want discogs_update of abc-123", got)
+	}
+}
+
+func TestAuditStoreDeleteWritesEntry(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if err := s.Delete(context.Background(), "abc-123"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "\tdelete\tabc-123\t") {
+		t.Errorf("audit entry = %q, want delete of abc-123", got)
+	}
+}
+
+func TestAuditStoreDeleteBatchWritesEntryPerRecord(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if _, err := s.DeleteBatch(context.Background(), []string{"a", "b"}); err != nil {
+		t.Fatalf("DeleteBatch: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "\tdelete\ta\t") || !strings.Contains(got, "\tdelete\tb\t") {
+		t.Errorf("audit entries = %q, want deletes of a and b", got)
+	}
+}
+
+func TestAuditStoreTagWritesEntries(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if _, err := s.AddTag(context.Background(), "abc-123", " Wishlist "); err != nil {
+		t.Fatalf("AddTag: %v", err)
+	}
+	if _, err := s.RemoveTag(context.Background(), "abc-123", "For Sale"); err != nil {
+		t.Fatalf("RemoveTag: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "\tadd_tag\tabc-123\ttag=Wishlist\n") || !strings.Contains(got, "\tremove_tag\tabc-123\ttag=For Sale\n") {
+		t.Errorf("audit entries = %q, want add and remove of abc-123", got)
+	}
+}
+
+func TestAuditStoreSetNotesWritesEntry(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if err := s.SetNotes(context.Background(), "abc-123", "VG+\tsleeve split "); err != nil {
+		t.Fatalf("SetNotes: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "\tset_notes\tabc-123\tnotes=\"VG+\\tsleeve split\"\n") {
+		t.Errorf("audit entry = %q, want quoted notes for abc-123", got)
+	}
+}
+
+func TestAuditStoreUpdateFieldWritesEntry(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if err := s.UpdateField(context.Background(), "abc-123", "label_name", " Blue Note "); err != nil {
+		t.Fatalf("UpdateField: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "\tupdate_field\tabc-123\tlabel_name=\"Blue Note\"\n") {
+		t.Errorf("audit entry = %q, want the column and value for abc-123", got)
+	}
+
+	s, buf = newTestAuditStore(&fakeStore{err: errors.New("boom")})
+	if err := s.UpdateField(context.Background(), "abc-123", "label_name", "x"); err == nil {
+		t.Fatal("UpdateField should pass the inner error through")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("failed update logged %q", buf.String())
+	}
+}
+
+func TestAuditStoreRatingWritesEntries(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if err := s.SetRating(context.Background(), "abc-123", 4); err != nil {
+		t.Fatalf("SetRating: %v", err)
+	}
+	if err := s.ClearRating(context.Background(), "abc-123"); err != nil {
+		t.Fatalf("ClearRating: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "\tset_rating\tabc-123\trating=4\n") || !strings.Contains(got, "\tclear_rating\tabc-123\t-\n") {
+		t.Errorf("audit entries = %q, want set and clear of abc-123", got)
+	}
+}
+
+func TestAuditStoreRecordPlayWritesEntry(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{})
+
+	if _, err := s.RecordPlay(context.Background(), "abc-123"); err != nil {
+		t.Fatalf("RecordPlay: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "\tplay\tabc-123\tplays=2\n") {
+		t.Errorf("audit entry = %q, want play of abc-123", got)
+	}
+}
+
+func TestAuditStoreSkipsFailedMutations(t *testing.T) {
+	s, buf := newTestAuditStore(&fakeStore{err: errors.New("boom")})
+
+	if _, err := s.Create(context.Background(), Record{ArtistName: "A", AlbumTitle: "B"}); err == nil {
+		t.Fatal("Create should propagate inner error")
+	}
+	if _, err := s.CreateBatch(context.Background(), []Record{{ArtistName: "A", AlbumTitle: "B"}}); err == nil {
+		t.Fatal("CreateBatch should propagate inner error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("failed mutation should not be logged, got %q", buf.String())
+	}
+}
+
+func TestAuditStoreInterfaceCompliance(t *testing.T) {
+	var _ Store = (*AuditStore)(nil)
+}
@@ -1,43 +1,229 @@
 package db
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// defaultConnectTimeout bounds each connection attempt when
+// PoolOptions.ConnectTimeout is unset.
+const defaultConnectTimeout = 5 * time.Second
+
+// maxRetryDelay caps the doubling backoff between attempts.
+const maxRetryDelay = 30 * time.Second
+
+// Retry controls how ConnectRetry retries a database that isn't reachable
+// yet. The wait after failed attempt n is BaseDelay * 2^(n-1), capped at
+// 30s.
+type Retry struct {
+	Attempts  int
+	BaseDelay time.Duration
+
+	// OnRetry, if set, is called before each wait.
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// PoolOptions sizes the connection pool. Zero values keep pgx's defaults;
+// an unset ConnectTimeout uses the URL's connect_timeout, or else 5s.
+type PoolOptions struct {
+	MaxConns        int32
+	MaxConnIdleTime time.Duration
+	ConnectTimeout  time.Duration
+
+	// ReadOnly starts every session with default_transaction_read_only,
+	// so the server rejects writes even if one slips past the UI.
+	ReadOnly bool
+}
+
+// ErrSQLiteURL is returned by ConnectRetry for a database_url naming a
+// SQLite database, which OpenSQLite opens instead of a Postgres pool.
+var ErrSQLiteURL = errors.New("database_url names a SQLite database — open it with OpenSQLite")
+
 func Connect(databaseURL string) (*pgxpool.Pool, error) {
+	return ConnectRetry(context.Background(), databaseURL, Retry{Attempts: 1}, PoolOptions{})
+}
+
+// poolConfig parses databaseURL, forcing SSL unless the URL picks a mode,
+// applies opts and prepares the store's statements on each new connection.
+func poolConfig(databaseURL string, opts PoolOptions) (*pgxpool.Config, error) {
+	cfg, err := pgxpool.ParseConfig(ensureSSL(databaseURL))
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxConns > 0 {
+		cfg.MaxConns = opts.MaxConns
+		cfg.MinConns = min(cfg.MinConns, opts.MaxConns)
+	}
+	if opts.MaxConnIdleTime > 0 {
+		cfg.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	cfg.ConnConfig.ConnectTimeout = cmp.Or(opts.ConnectTimeout, cfg.ConnConfig.ConnectTimeout, defaultConnectTimeout)
+	if opts.ReadOnly {
+		cfg.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+	cfg.AfterConnect = prepareStatements
+	return cfg, nil
+}
+
+// ConnectRetry opens a pool and pings it, retrying failed pings with
+// exponential backoff. A malformed URL fails immediately. Cancelling ctx
+// stops the loop, including mid-wait.
+func ConnectRetry(ctx context.Context, databaseURL string, retry Retry, opts PoolOptions) (*pgxpool.Pool, error) {
 	if databaseURL == "" {
 		return nil, fmt.Errorf("database_url not configured — set it in ~/.config/myrecords/config.toml or DATABASE_URL env var")
 	}
+	if IsSQLiteURL(databaseURL) {
+		return nil, ErrSQLiteURL
+	}
+
+	cfg, err := poolConfig(databaseURL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("create pool: %w", err)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create pool: %w", err)
+	}
 
-	databaseURL = ensureSSL(databaseURL)
+	attempts := max(retry.Attempts, 1)
+	wait := retry.BaseDelay
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, cfg.ConnConfig.ConnectTimeout)
+		err = pool.Ping(pingCtx)
+		cancel()
+		if err == nil {
+			return pool, nil
+		}
+		if attempt >= attempts || ctx.Err() != nil {
+			break
+		}
+		if retry.OnRetry != nil {
+			retry.OnRetry(attempt, err, wait)
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			break
+		}
+		wait = min(wait*2, maxRetryDelay)
+	}
+	pool.Close()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("ping: %w", ctx.Err())
+	}
+	if attempts > 1 {
+		return nil, fmt.Errorf("ping after %d attempts: %w", attempts, err)
+	}
+	return nil, fmt.Errorf("ping: %w", err)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
 
-	pool, err := pgxpool.New(ctx, databaseURL)
+func ensureSSL(dsn string) string {
+	u, rawQuery, err := parseDSN(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("create pool: %w", err)
+		// Keyword/value DSNs ("host=... user=...") take sslmode as another pair.
+		if !strings.Contains(dsn, "://") && strings.Contains(dsn, "=") && !strings.Contains(dsn, "sslmode=") {
+			return dsn + " sslmode=require"
+		}
+		return dsn
 	}
 
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("ping: %w", err)
+	query, err := url.ParseQuery(rawQuery)
+	if err == nil && query.Has("sslmode") {
+		u.RawQuery = rawQuery
+		return u.String()
 	}
+	if rawQuery == "" {
+		u.RawQuery = "sslmode=require"
+	} else {
+		u.RawQuery = rawQuery + "&sslmode=require"
+	}
+	return u.String()
+}
+
+// parseDSN splits a postgres:// URL by hand so that passwords containing
+// unescaped '@', ':' or '/' survive. The host starts after the last '@'
+// before the query string; the password is everything after the first ':'
+// of the userinfo. Already percent-encoded credentials are decoded once so
+// they are not double-encoded on the way back out.
+func parseDSN(dsn string) (*url.URL, string, error) {
+	scheme, rest, ok := strings.Cut(dsn, "://")
+	if !ok || scheme == "" {
+		return nil, "", fmt.Errorf("not a URL DSN")
+	}
+
+	beforeQuery, rawQuery, _ := strings.Cut(rest, "?")
+	u := &url.URL{Scheme: scheme}
 
-	return pool, nil
+	hostAndPath := beforeQuery
+	if at := strings.LastIndex(beforeQuery, "@"); at >= 0 {
+		userinfo := beforeQuery[:at]
+		hostAndPath = beforeQuery[at+1:]
+		user, pass, hasPass := strings.Cut(userinfo, ":")
+		user = unescapeUserinfo(user)
+		if hasPass {
+			u.User = url.UserPassword(user, unescapeUserinfo(pass))
+		} else {
+			u.User = url.User(user)
+		}
+	}
+
+	host, path, hasPath := strings.Cut(hostAndPath, "/")
+	u.Host = host
+	if hasPath {
+		if unescaped, err := url.PathUnescape(path); err == nil {
+			path = unescaped
+		}
+		u.Path = "/" + path
+	}
+	return u, rawQuery, nil
+}
+
+func unescapeUserinfo(s string) string {
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
 }
 
-func ensureSSL(url string) string {
-	if strings.Contains(url, "sslmode=") {
-		return url
+// IsConnectionError reports whether err means the database couldn't be
+// reached or dropped the connection, as opposed to rejecting the query.
+// Those are worth retrying once the network is back. A TimeoutStore query
+// that ran out of time isn't one, even though the deadline error under it
+// is a net.Error; whether a timed-out Ping means the connection is gone is
+// up to the caller.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, ErrQueryTimeout) {
+		return false
 	}
-	if strings.Contains(url, "?") {
-		return url + "&sslmode=require"
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is "connection exception"; 57P01-57P03 are the server
+		// shutting down or still starting up.
+		return strings.HasPrefix(pgErr.Code, "08") || slices.Contains([]string{"57P01", "57P02", "57P03"}, pgErr.Code)
 	}
-	return url + "?sslmode=require"
+	var connErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		pgconn.Timeout(err)
 }
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvColumns is the column order WriteCSV uses. Every name is one ReadCSV
+// accepts, so an export can be imported again.
+var csvColumns = []string{
+	"artist_name", "album_title", "year_released", "label_name",
+	"catalog_number", "discogs_id", "discogs_uri", "upc_code",
+	"record_size", "vinyl_color", "is_shaped_vinyl", "genres", "styles", "tags",
+}
+
+// WriteCSV writes records with a header row. List columns are joined with
+// ", " and unset fields are left empty.
+func WriteCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+	for _, r := range records {
+		if err := cw.Write(csvRow(r)); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRow(r Record) []string {
+	str := func(p *string) string {
+		if p == nil {
+			return ""
+		}
+		return *p
+	}
+	var year, shaped string
+	if r.YearReleased != nil {
+		year = strconv.Itoa(*r.YearReleased)
+	}
+	if r.IsShapedVinyl != nil {
+		shaped = strconv.FormatBool(*r.IsShapedVinyl)
+	}
+	return []string{
+		r.ArtistName, r.AlbumTitle, year, str(r.LabelName),
+		str(r.CatalogNumber), str(r.DiscogsID), str(r.DiscogsURI), str(r.UPCCode),
+		str(r.RecordSize), str(r.VinylColor), shaped,
+		strings.Join(r.Genres, ", "), strings.Join(r.Styles, ", "), strings.Join(r.Tags, ", "),
+	}
+}
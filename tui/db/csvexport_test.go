@@ -0,0 +1,35 @@
+package db
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	year, label, size, shaped := 1959, "Columbia", `12"`, true
+	in := []Record{
+		{
+			ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", YearReleased: &year,
+			LabelName: &label, RecordSize: &size, IsShapedVinyl: &shaped,
+			Genres: []string{"Jazz", "Modal"}, Tags: []string{"to-sell"},
+		},
+		{ArtistName: "John Coltrane", AlbumTitle: "A Love Supreme"},
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, in); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	if header, _, _ := strings.Cut(buf.String(), "\n"); !strings.HasPrefix(header, "artist_name,album_title,year_released") {
+		t.Errorf("header = %q", header)
+	}
+
+	out, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadCSV of export: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadCSV parses records from CSV with a header row naming the columns to
+// fill. Headers use the column names (artist_name, album_title, ...); list
+// columns hold comma-separated values. Every row is validated and all
+// problems are reported together, so nothing is imported from a bad file.
+func ReadCSV(r io.Reader) ([]Record, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if _, ok := csvSetters[h]; !ok {
+			return nil, fmt.Errorf("unknown csv column %q", h)
+		}
+		header[i] = h
+	}
+
+	var records []Record
+	var errs []error
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		var rec Record
+		for i, cell := range row {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			if err := csvSetters[header[i]](&rec, cell); err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %s %w", line, header[i], err))
+			}
+		}
+		if err := rec.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+		}
+		records = append(records, rec)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return records, nil
+}
+
+var csvSetters = map[string]func(*Record, string) error{
+	"artist_name":    func(r *Record, v string) error { r.ArtistName = v; return nil },
+	"album_title":    func(r *Record, v string) error { r.AlbumTitle = v; return nil },
+	"label_name":     func(r *Record, v string) error { r.LabelName = &v; return nil },
+	"catalog_number": func(r *Record, v string) error { r.CatalogNumber = &v; return nil },
+	"discogs_id":     func(r *Record, v string) error { r.DiscogsID = &v; return nil },
+	"discogs_uri":    func(r *Record, v string) error { r.DiscogsURI = &v; return nil },
+	"upc_code":       func(r *Record, v string) error { r.UPCCode = &v; return nil },
+	"record_size":    func(r *Record, v string) error { r.RecordSize = &v; return nil },
+	"vinyl_color":    func(r *Record, v string) error { r.VinylColor = &v; return nil },
+	"genres":         func(r *Record, v string) error { r.Genres = splitList(v); return nil },
+	"styles":         func(r *Record, v string) error { r.Styles = splitList(v); return nil },
+	"tags":           func(r *Record, v string) error { r.Tags = splitList(v); return nil },
+	"year_released": func(r *Record, v string) error {
+		y, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("is not a number: %q", v)
+		}
+		r.YearReleased = &y
+		return nil
+	},
+	"is_shaped_vinyl": func(r *Record, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("is not true or false: %q", v)
+		}
+		r.IsShapedVinyl = &b
+		return nil
+	},
+}
+
+func splitList(v string) []string {
+	var out []string
+	for s := range strings.SplitSeq(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	in := `artist_name,album_title,year_released,label_name,record_size,genres,is_shaped_vinyl
+Miles Davis,Kind of Blue,1959,Columbia,"12""","Jazz, Modal",false
+John Coltrane,A Love Supreme,,,,,
+`
+	recs, err := ReadCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want 2", len(recs))
+	}
+	r := recs[0]
+	if r.ArtistName != "Miles Davis" || *r.YearReleased != 1959 || *r.LabelName != "Columbia" || *r.RecordSize != `12"` {
+		t.Errorf("first record = %+v", r)
+	}
+	if strings.Join(r.Genres, "|") != "Jazz|Modal" {
+		t.Errorf("genres = %q, want [Jazz Modal]", r.Genres)
+	}
+	if r.IsShapedVinyl == nil || *r.IsShapedVinyl {
+		t.Errorf("is_shaped_vinyl = %v, want false", r.IsShapedVinyl)
+	}
+	if recs[1].YearReleased != nil || recs[1].LabelName != nil {
+		t.Errorf("blank cells should stay nil: %+v", recs[1])
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty", "", []string{"csv is empty"}},
+		{"unknown column", "artist_name,rating\nA,5\n", []string{`unknown csv column "rating"`}},
+		{
+			"bad rows",
+			"artist_name,album_title,year_released\nA,B,nineteen\n,C,1970\n",
+			[]string{"line 2: year_released is not a number", "line 3:", "artist is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, err := ReadCSV(strings.NewReader(tt.in))
+			if err == nil {
+				t.Fatalf("ReadCSV = %v, want error", recs)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("error %q should contain %q", err, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Reasons a DuplicateGroup was formed, in the order they're listed.
+const (
+	DuplicateSameTitle   = "same artist and album"
+	DuplicateSameUPC     = "same UPC"
+	DuplicateSameCatalog = "same catalog #"
+)
+
+// DuplicateGroup is a set of records that look like the same release.
+type DuplicateGroup struct {
+	Reasons []string
+	Records []Record
+}
+
+// FindDuplicates groups records sharing a normalized artist and album, a
+// UPC, or a catalog number. Records linked through any of these land in
+// one group, so A~B by title and B~C by UPC make a group of three. Groups
+// are ordered by artist then album; records in a group by when they were
+// added.
+func FindDuplicates(records []Record) []DuplicateGroup {
+	parent := make([]int, len(records))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	keys := []struct {
+		reason string
+		key    func(Record) string
+	}{
+		{DuplicateSameTitle, titleKey},
+		{DuplicateSameUPC, func(r Record) string { return identifierKey(r.UPCCode) }},
+		{DuplicateSameCatalog, func(r Record) string { return identifierKey(r.CatalogNumber) }},
+	}
+	reasons := make(map[int][]string)
+	for _, k := range keys {
+		first := make(map[string]int)
+		for i, r := range records {
+			key := k.key(r)
+			if key == "" {
+				continue
+			}
+			if j, ok := first[key]; ok {
+				parent[find(i)] = find(j)
+			} else {
+				first[key] = i
+			}
+		}
+		// Attach the reason once the unions for this key are done; roots
+		// can still move when a later key merges two groups.
+		for i, r := range records {
+			if j, ok := first[k.key(r)]; ok && j != i {
+				reasons[i] = append(reasons[i], k.reason)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range records {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+	var groups []DuplicateGroup
+	for _, idx := range members {
+		if len(idx) < 2 {
+			continue
+		}
+		var g DuplicateGroup
+		for _, i := range idx {
+			g.Records = append(g.Records, records[i])
+			for _, reason := range reasons[i] {
+				if !slices.Contains(g.Reasons, reason) {
+					g.Reasons = append(g.Reasons, reason)
+				}
+			}
+		}
+		slices.SortFunc(g.Reasons, func(a, b string) int {
+			return cmp.Compare(reasonOrder(a), reasonOrder(b))
+		})
+		slices.SortStableFunc(g.Records, func(a, b Record) int { return a.CreatedAt.Compare(b.CreatedAt) })
+		groups = append(groups, g)
+	}
+	slices.SortFunc(groups, func(a, b DuplicateGroup) int {
+		return cmp.Or(
+			strings.Compare(foldKey(a.Records[0].ArtistName), foldKey(b.Records[0].ArtistName)),
+			strings.Compare(foldKey(a.Records[0].AlbumTitle), foldKey(b.Records[0].AlbumTitle)),
+			strings.Compare(a.Records[0].RecordID, b.Records[0].RecordID),
+		)
+	})
+	return groups
+}
+
+func reasonOrder(reason string) int {
+	switch reason {
+	case DuplicateSameTitle:
+		return 0
+	case DuplicateSameUPC:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// foldKey lowercases s, strips accents and drops everything but letters
+// and digits, so "Björk – Post!" and "bjork post" compare equal.
+func foldKey(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func titleKey(r Record) string {
+	artist, album := foldKey(r.ArtistName), foldKey(r.AlbumTitle)
+	if artist == "" || album == "" {
+		return ""
+	}
+	return artist + "\x00" + album
+}
+
+// identifierKey normalizes a UPC or catalog number; Discogs fills in
+// "none" for releases without a catalog number, which matches nothing.
+func identifierKey(s *string) string {
+	if s == nil {
+		return ""
+	}
+	k := foldKey(*s)
+	if k == "none" {
+		return ""
+	}
+	return k
+}
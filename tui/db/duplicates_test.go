@@ -0,0 +1,72 @@
+package db
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFoldKey(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Kind of Blue", "kindofblue"},
+		{"  KIND OF BLUE! ", "kindofblue"},
+		{"Björk", "bjork"},
+		{"Sigur Rós – ( )", "sigurros"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := foldKey(tt.in); got != tt.want {
+			t.Errorf("foldKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func groupIDs(groups []DuplicateGroup) [][]string {
+	var out [][]string
+	for _, g := range groups {
+		var ids []string
+		for _, r := range g.Records {
+			ids = append(ids, r.RecordID)
+		}
+		out = append(out, ids)
+	}
+	return out
+}
+
+func TestFindDuplicates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	recs := []Record{
+		{RecordID: "1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", CreatedAt: day(3)},
+		{RecordID: "2", ArtistName: "miles davis", AlbumTitle: "Kind Of Blue.", CreatedAt: day(1)},
+		{RecordID: "3", ArtistName: "Björk", AlbumTitle: "Post", UPCCode: new("0 75596 17382 1"), CreatedAt: day(1)},
+		{RecordID: "4", ArtistName: "Bjork", AlbumTitle: "Post (Reissue)", UPCCode: new("075596173821"), CreatedAt: day(2)},
+		{RecordID: "5", ArtistName: "Björk", AlbumTitle: "Post [Remaster]", CatalogNumber: new("TPLP51"), CreatedAt: day(3)},
+		{RecordID: "6", ArtistName: "Björk", AlbumTitle: "Post", CatalogNumber: new("tplp 51"), CreatedAt: day(4)},
+		{RecordID: "7", ArtistName: "Nina Simone", AlbumTitle: "Pastel Blues", CatalogNumber: new("none"), CreatedAt: day(1)},
+		{RecordID: "8", ArtistName: "John Coltrane", AlbumTitle: "Giant Steps", CatalogNumber: new("None"), CreatedAt: day(1)},
+		{RecordID: "9", ArtistName: "Nina Simone", AlbumTitle: "Wild Is the Wind", CreatedAt: day(1)},
+	}
+	groups := FindDuplicates(recs)
+	want := [][]string{{"3", "4", "5", "6"}, {"2", "1"}}
+	if got := groupIDs(groups); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("FindDuplicates groups = %v, want %v", got, want)
+	}
+	wantReasons := []string{DuplicateSameTitle, DuplicateSameUPC, DuplicateSameCatalog}
+	if !slices.Equal(groups[0].Reasons, wantReasons) {
+		t.Errorf("Björk reasons = %v, want %v", groups[0].Reasons, wantReasons)
+	}
+	if !slices.Equal(groups[1].Reasons, []string{DuplicateSameTitle}) {
+		t.Errorf("Miles Davis reasons = %v, want only the title", groups[1].Reasons)
+	}
+}
+
+func TestFindDuplicatesNone(t *testing.T) {
+	recs := []Record{
+		{RecordID: "1", ArtistName: "A", AlbumTitle: "One"},
+		{RecordID: "2", ArtistName: "", AlbumTitle: ""},
+		{RecordID: "3", ArtistName: "", AlbumTitle: ""},
+	}
+	if got := FindDuplicates(recs); len(got) != 0 {
+		t.Errorf("FindDuplicates = %v, want no groups", groupIDs(got))
+	}
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrColumnNotEditable is returned for a column UpdateField doesn't allow.
+var ErrColumnNotEditable = errors.New("column is not editable")
+
+// editableColumn is one entry of UpdateField's allowlist: the FieldError
+// name its problems are reported under, how typed text lands in a Record,
+// and the value then written to the database.
+type editableColumn struct {
+	field string
+	set   func(*Record, string) error
+	value func(Record) any
+}
+
+func optionalText(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// editableColumns is UpdateField's allowlist. The column name is spliced
+// into the UPDATE, so only names found here ever reach the SQL.
+var editableColumns = map[string]editableColumn{
+	"artist_name": {FieldArtist,
+		func(r *Record, v string) error { r.ArtistName = v; return nil },
+		func(r Record) any { return r.ArtistName }},
+	"album_title": {FieldAlbum,
+		func(r *Record, v string) error { r.AlbumTitle = v; return nil },
+		func(r Record) any { return r.AlbumTitle }},
+	"year_released": {FieldYear,
+		func(r *Record, v string) error {
+			if v == "" {
+				r.YearReleased = nil
+				return nil
+			}
+			y, err := strconv.Atoi(v)
+			if err != nil {
+				return ValidationErrors{{FieldYear, "must be a number"}}
+			}
+			r.YearReleased = &y
+			return nil
+		},
+		func(r Record) any { return r.YearReleased }},
+	"label_name": {FieldLabel,
+		func(r *Record, v string) error { r.LabelName = optionalText(v); return nil },
+		func(r Record) any { return r.LabelName }},
+	"catalog_number": {FieldCatalog,
+		func(r *Record, v string) error { r.CatalogNumber = optionalText(v); return nil },
+		func(r Record) any { return r.CatalogNumber }},
+	"upc_code": {FieldUPC,
+		func(r *Record, v string) error { r.UPCCode = optionalText(v); return nil },
+		func(r Record) any { return r.UPCCode }},
+	"record_size": {FieldSize,
+		func(r *Record, v string) error { r.RecordSize = optionalText(v); return nil },
+		func(r Record) any { return r.RecordSize }},
+	"vinyl_color": {FieldColor,
+		func(r *Record, v string) error { r.VinylColor = optionalText(v); return nil },
+		func(r Record) any { return r.VinylColor }},
+}
+
+// WithField returns r with column set from typed text the way UpdateField
+// stores it: trimmed, with "" clearing an optional field. A bad value comes
+// back as ValidationErrors.
+func (r Record) WithField(column, value string) (Record, error) {
+	col, ok := editableColumns[column]
+	if !ok {
+		return r, fmt.Errorf("%w: %s", ErrColumnNotEditable, column)
+	}
+	if err := col.set(&r, strings.TrimSpace(value)); err != nil {
+		return r, err
+	}
+	if verrs, ok := errors.AsType[ValidationErrors](r.Validate()); ok {
+		if problem := verrs.For(col.field); problem != "" {
+			return r, ValidationErrors{{col.field, problem}}
+		}
+	}
+	return r, nil
+}
+
+// FieldText is column's value as editable text, "" when it's unset.
+func (r Record) FieldText(column string) string {
+	col, ok := editableColumns[column]
+	if !ok {
+		return ""
+	}
+	switch v := col.value(r).(type) {
+	case string:
+		return v
+	case *string:
+		if v != nil {
+			return *v
+		}
+	case *int:
+		if v != nil {
+			return strconv.Itoa(*v)
+		}
+	}
+	return ""
+}
+
+// UpdateField sets one column of the record with id, validated like
+// WithField. Only columns on the allowlist are accepted.
+func (s *RecordStore) UpdateField(ctx context.Context, id, column, value string) error {
+	r, err := Record{}.WithField(column, value)
+	if err != nil {
+		return err
+	}
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE records SET `+pgx.Identifier{column}.Sanitize()+` = $2, updated_at = now() WHERE record_id = $1`,
+		id, editableColumns[column].value(r),
+	)
+	if err != nil {
+		return fmt.Errorf("update %s: %w", column, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return nil
+}
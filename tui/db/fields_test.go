@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithField(t *testing.T) {
+	label, year := "Columbia", 1959
+	base := Record{ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", LabelName: &label, YearReleased: &year}
+	tests := []struct {
+		column, value string
+		want          string
+		field         string
+	}{
+		{"artist_name", "  Miles  ", "Miles", ""},
+		{"artist_name", " ", "", FieldArtist},
+		{"year_released", "1960", "1960", ""},
+		{"year_released", "", "", ""},
+		{"year_released", "late fifties", "", FieldYear},
+		{"year_released", "1776", "", FieldYear},
+		{"label_name", "Blue Note", "Blue Note", ""},
+		{"label_name", "", "", ""},
+		{"record_size", `12"`, `12"`, ""},
+		{"record_size", "huge", "", FieldSize},
+		{"vinyl_color", "Red", "Red", ""},
+		{"catalog_number", "CL 1355", "CL 1355", ""},
+		{"upc_code", "074646", "074646", ""},
+	}
+	for _, tt := range tests {
+		got, err := base.WithField(tt.column, tt.value)
+		if tt.field != "" {
+			if verrs, ok := errors.AsType[ValidationErrors](err); !ok || verrs.For(tt.field) == "" || len(verrs) != 1 {
+				t.Errorf("WithField(%s, %q) err = %v, want only a %s error", tt.column, tt.value, err, tt.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("WithField(%s, %q): %v", tt.column, tt.value, err)
+			continue
+		}
+		if text := got.FieldText(tt.column); text != tt.want {
+			t.Errorf("WithField(%s, %q) left %q, want %q", tt.column, tt.value, text, tt.want)
+		}
+	}
+	if base.FieldText("label_name") != "Columbia" {
+		t.Error("WithField should not modify the record it was called on")
+	}
+}
+
+func TestUpdateFieldRejectsUnknownColumns(t *testing.T) {
+	s := &RecordStore{}
+	for _, column := range []string{"notes", "is_synced_with_discogs", "record_id", "artist_name = 'x'; DROP TABLE records; --"} {
+		if err := s.UpdateField(context.Background(), "id", column, "x"); !errors.Is(err, ErrColumnNotEditable) {
+			t.Errorf("UpdateField(%q) = %v, want ErrColumnNotEditable", column, err)
+		}
+	}
+}
+
+func TestUpdateFieldValidatesBeforeWriting(t *testing.T) {
+	s := &RecordStore{}
+	err := s.UpdateField(context.Background(), "id", "year_released", "soon")
+	if verrs, ok := errors.AsType[ValidationErrors](err); !ok || verrs.For(FieldYear) == "" {
+		t.Errorf("UpdateField(year_released, soon) = %v, want a year validation error", err)
+	}
+}
@@ -0,0 +1,441 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IsMemoryURL reports whether databaseURL asks for the in-memory store
+// (memory://) instead of Postgres.
+func IsMemoryURL(databaseURL string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(databaseURL)), "memory:")
+}
+
+// MemoryStore is a Store that keeps its records in a slice, for demos and
+// trying the app without a database. Nothing is persisted. Queries match
+// the RecordStore SQL, and records are copied in and out so callers never
+// share one with the store.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records []Record
+	nextID  int
+}
+
+// NewMemoryStore returns a store holding records, each given a fresh ID
+// and timestamps.
+func NewMemoryStore(records []Record) *MemoryStore {
+	s := &MemoryStore{}
+	for _, r := range records {
+		s.insert(r)
+	}
+	return s
+}
+
+// SampleRecords is a handful of well-known albums to seed a MemoryStore.
+func SampleRecords() []Record {
+	lp := func(artist, album string, year int, label, catalog string, genres, styles []string) Record {
+		return Record{
+			ArtistName: artist, AlbumTitle: album, YearReleased: &year,
+			LabelName: &label, CatalogNumber: &catalog,
+			Genres: genres, Styles: styles, RecordSize: new(`12"`), VinylColor: new("Black"),
+		}
+	}
+	records := []Record{
+		lp("Miles Davis", "Kind of Blue", 1959, "Columbia", "CL 1355", []string{"Jazz"}, []string{"Modal"}),
+		lp("John Coltrane", "A Love Supreme", 1965, "Impulse!", "A-77", []string{"Jazz"}, []string{"Hard Bop", "Modal"}),
+		lp("Nina Simone", "Pastel Blues", 1965, "Philips", "PHS 600-187", []string{"Jazz", "Blues"}, []string{"Vocal"}),
+		lp("Kraftwerk", "Trans-Europe Express", 1977, "Kling Klang", "1C 064-82 306", []string{"Electronic"}, []string{"Synth-pop"}),
+		lp("Talking Heads", "Remain in Light", 1980, "Sire", "SRK 6095", []string{"Rock", "Funk / Soul"}, []string{"New Wave", "Art Rock"}),
+		lp("Björk", "Homogenic", 1997, "One Little Indian", "TPLP71", []string{"Electronic", "Pop"}, []string{"Trip Hop"}),
+	}
+	records[0].Tags = []string{"favourite"}
+	records[0].Rating = new(5)
+	records[3].VinylColor = new("Red")
+	records[4].Tags = []string{"to sell"}
+	return records
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func (r Record) clone() Record {
+	r.YearReleased = clonePtr(r.YearReleased)
+	r.LabelName = clonePtr(r.LabelName)
+	r.CatalogNumber = clonePtr(r.CatalogNumber)
+	r.DiscogsID = clonePtr(r.DiscogsID)
+	r.DiscogsURI = clonePtr(r.DiscogsURI)
+	r.ThumbnailURL = clonePtr(r.ThumbnailURL)
+	r.CoverImageURL = clonePtr(r.CoverImageURL)
+	r.Genres = slices.Clone(r.Genres)
+	r.Styles = slices.Clone(r.Styles)
+	r.UPCCode = clonePtr(r.UPCCode)
+	r.RecordSize = clonePtr(r.RecordSize)
+	r.VinylColor = clonePtr(r.VinylColor)
+	r.IsShapedVinyl = clonePtr(r.IsShapedVinyl)
+	r.Tags = slices.Clone(r.Tags)
+	r.Notes = clonePtr(r.Notes)
+	r.Rating = clonePtr(r.Rating)
+	r.LastPlayedAt = clonePtr(r.LastPlayedAt)
+	return r
+}
+
+// insert adds a copy of r with a fresh ID and timestamps, and "manual"
+// when no data source is given, and returns the ID. The caller holds mu,
+// or owns s outright.
+func (s *MemoryStore) insert(r Record) string {
+	s.nextID++
+	now := time.Now()
+	r = r.clone()
+	r.RecordID = fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+	r.DataSource = cmp.Or(r.DataSource, "manual")
+	r.CreatedAt, r.UpdatedAt = now, now
+	s.records = append(s.records, r)
+	return r.RecordID
+}
+
+// create inserts r the way Create's INSERT would, leaving out the notes,
+// rating and plays it doesn't write.
+func (s *MemoryStore) create(r Record) string {
+	r.Notes, r.Rating, r.PlayCount, r.LastPlayedAt = nil, nil, 0, nil
+	return s.insert(r)
+}
+
+// query returns copies of the records match accepts, ordered like the SQL
+// queries: by artist, then album, ignoring case.
+func (s *MemoryStore) query(match func(Record) bool) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Record
+	for _, r := range s.records {
+		if match(r) {
+			out = append(out, r.clone())
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Record) int {
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(a.ArtistName), strings.ToLower(b.ArtistName)),
+			cmp.Compare(strings.ToLower(a.AlbumTitle), strings.ToLower(b.AlbumTitle)),
+		)
+	})
+	return out
+}
+
+// update applies f to the record with id under the lock.
+func (s *MemoryStore) update(id string, f func(*Record) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.records, func(r Record) bool { return r.RecordID == id })
+	if i < 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return f(&s.records[i])
+}
+
+func (s *MemoryStore) Ping(context.Context) error { return nil }
+
+func (s *MemoryStore) List(context.Context) ([]Record, error) {
+	return s.query(func(Record) bool { return true }), nil
+}
+
+func (s *MemoryStore) Search(_ context.Context, query string) ([]Record, error) {
+	return s.query(ParseSearchQuery(query).Matches), nil
+}
+
+func (s *MemoryStore) FilterByGenre(_ context.Context, genre string) ([]Record, error) {
+	return s.query(func(r Record) bool { return r.HasGenre(genre) }), nil
+}
+
+func (s *MemoryStore) ListByYearRange(_ context.Context, from, to int) ([]Record, error) {
+	return s.query(func(r Record) bool { return r.ReleasedBetween(from, to) }), nil
+}
+
+func (s *MemoryStore) ListByTag(_ context.Context, tag string) ([]Record, error) {
+	return s.query(func(r Record) bool { return r.HasTag(tag) }), nil
+}
+
+func (s *MemoryStore) ListByLabel(_ context.Context, label string) ([]Record, error) {
+	return s.query(func(r Record) bool { return r.HasLabel(label) }), nil
+}
+
+func (s *MemoryStore) ListUnsyncedDiscogsRecords(context.Context) ([]Record, error) {
+	return s.query(func(r Record) bool { return r.DiscogsID != nil && !r.IsSyncedWithDiscogs }), nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	deleted, _ := s.DeleteBatch(ctx, []string{id})
+	if len(deleted) == 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteBatch(_ context.Context, ids []string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted []string
+	s.records = slices.DeleteFunc(s.records, func(r Record) bool {
+		if slices.Contains(ids, r.RecordID) {
+			deleted = append(deleted, r.RecordID)
+			return true
+		}
+		return false
+	})
+	return deleted, nil
+}
+
+func (s *MemoryStore) Create(_ context.Context, r Record) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(r), nil
+}
+
+func (s *MemoryStore) CreateBatch(_ context.Context, records []Record) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, r := range records {
+		ids = append(ids, s.create(r))
+	}
+	return ids, nil
+}
+
+func (s *MemoryStore) Update(_ context.Context, r Record) error {
+	return s.update(r.RecordID, func(cur *Record) error {
+		r = r.clone()
+		cur.ArtistName, cur.AlbumTitle, cur.YearReleased = r.ArtistName, r.AlbumTitle, r.YearReleased
+		cur.LabelName, cur.CatalogNumber, cur.Genres, cur.Tags = r.LabelName, r.CatalogNumber, r.Genres, r.Tags
+		cur.RecordSize, cur.VinylColor = r.RecordSize, r.VinylColor
+		cur.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (s *MemoryStore) UpdateField(_ context.Context, id, column, value string) error {
+	return s.update(id, func(cur *Record) error {
+		updated, err := cur.WithField(column, value)
+		if err != nil {
+			return err
+		}
+		*cur = updated
+		cur.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (s *MemoryStore) UpdateFromDiscogs(_ context.Context, r Record) error {
+	return s.update(r.RecordID, func(cur *Record) error {
+		r = r.clone()
+		cur.YearReleased, cur.LabelName, cur.CatalogNumber = r.YearReleased, r.LabelName, r.CatalogNumber
+		cur.Genres, cur.Styles, cur.DiscogsURI = r.Genres, r.Styles, r.DiscogsURI
+		cur.ThumbnailURL, cur.CoverImageURL = r.ThumbnailURL, r.CoverImageURL
+		cur.IsSyncedWithDiscogs = true
+		cur.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (s *MemoryStore) SetTags(_ context.Context, id string, tags []string) error {
+	return s.update(id, func(cur *Record) error {
+		cur.Tags = slices.Clone(tags)
+		cur.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (s *MemoryStore) AddTag(_ context.Context, id string, tag string) ([]string, error) {
+	return s.updateTags("add tag", id, tag, addTag)
+}
+
+func (s *MemoryStore) RemoveTag(_ context.Context, id string, tag string) ([]string, error) {
+	return s.updateTags("remove tag", id, tag, removeTag)
+}
+
+// addTag and removeTag are the AddTag and RemoveTag changes for stores that
+// edit the tags in Go. They report whether they touched the tags.
+func addTag(cur *Record, tag string) bool {
+	if cur.HasTag(tag) {
+		return false
+	}
+	cur.Tags = append(cur.Tags, tag)
+	return true
+}
+
+func removeTag(cur *Record, tag string) bool {
+	if !cur.HasTag(tag) {
+		return false
+	}
+	cur.Tags = slices.DeleteFunc(cur.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+	return true
+}
+
+// updateTags runs an AddTag or RemoveTag change, which reports whether it
+// touched the tags, and returns the record's tags afterwards.
+func (s *MemoryStore) updateTags(op, id, tag string, change func(*Record, string) bool) ([]string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("%s: tag is empty", op)
+	}
+	var tags []string
+	err := s.update(id, func(cur *Record) error {
+		if change(cur, tag) {
+			cur.UpdatedAt = time.Now()
+		}
+		tags = slices.Clone(cur.Tags)
+		return nil
+	})
+	return tags, err
+}
+
+func (s *MemoryStore) SetNotes(_ context.Context, id string, notes string) error {
+	return s.update(id, func(cur *Record) error {
+		cur.Notes = optionalText(strings.TrimSpace(notes))
+		cur.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (s *MemoryStore) SetRating(_ context.Context, id string, rating int) error {
+	if !ValidRating(rating) {
+		return ValidationErrors{{FieldRating, ratingProblem}}
+	}
+	return s.setRating(id, &rating)
+}
+
+func (s *MemoryStore) ClearRating(_ context.Context, id string) error {
+	return s.setRating(id, nil)
+}
+
+func (s *MemoryStore) setRating(id string, rating *int) error {
+	return s.update(id, func(cur *Record) error {
+		cur.Rating = rating
+		cur.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (s *MemoryStore) RecordPlay(_ context.Context, id string) (Play, error) {
+	var p Play
+	err := s.update(id, func(cur *Record) error {
+		now := time.Now()
+		cur.PlayCount++
+		cur.LastPlayedAt = &now
+		p = Play{Count: cur.PlayCount, At: now}
+		return nil
+	})
+	return p, err
+}
+
+func (s *MemoryStore) ListDiscogsIDs(context.Context) (map[string]struct{}, error) {
+	ids := make(map[string]struct{})
+	for _, r := range s.query(func(r Record) bool { return r.DiscogsID != nil }) {
+		ids[*r.DiscogsID] = struct{}{}
+	}
+	return ids, nil
+}
+
+func (s *MemoryStore) MarkSyncedWithDiscogs(_ context.Context, discogsIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.records {
+		if r.DiscogsID != nil && slices.Contains(discogsIDs, *r.DiscogsID) {
+			s.records[i].IsSyncedWithDiscogs = true
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) Total(context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records), nil
+}
+
+func (s *MemoryStore) Stats(context.Context) (Stats, error) {
+	all := s.query(func(Record) bool { return true })
+	st := Stats{Total: len(all)}
+	var decades, labels, genres []string
+	for _, r := range all {
+		if r.IsSyncedWithDiscogs {
+			st.Synced++
+		}
+		decade := "Unknown"
+		if r.YearReleased != nil {
+			decade = fmt.Sprintf("%ds", *r.YearReleased/10*10)
+		}
+		decades = append(decades, decade)
+		if r.LabelName != nil && *r.LabelName != "" {
+			labels = append(labels, *r.LabelName)
+		}
+		genres = append(genres, r.Genres...)
+	}
+	st.Decades = countNames(decades)
+	slices.SortFunc(st.Decades, func(a, b Count) int { return cmp.Compare(a.Name, b.Name) })
+	st.TopLabels = topCounts(countNames(labels), StatsTopN)
+	st.TopGenres = topCounts(countNames(genres), StatsTopN)
+	return st, nil
+}
+
+func (s *MemoryStore) Labels(context.Context) ([]Count, error) {
+	var names []string
+	for _, r := range s.query(func(Record) bool { return true }) {
+		label := ""
+		if r.LabelName != nil {
+			label = *r.LabelName
+		}
+		names = append(names, label)
+	}
+	labels := countNames(names)
+	slices.SortFunc(labels, func(a, b Count) int {
+		if (a.Name == "") != (b.Name == "") {
+			if a.Name == "" {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.Name, b.Name))
+	})
+	return labels, nil
+}
+
+func (s *MemoryStore) Genres(context.Context) ([]Count, error) {
+	var names []string
+	for _, r := range s.query(func(Record) bool { return true }) {
+		names = append(names, r.Genres...)
+	}
+	return topCounts(countNames(names), 0), nil
+}
+
+// countNames tallies names like a GROUP BY, in no particular order.
+func countNames(names []string) []Count {
+	n := make(map[string]int)
+	for _, name := range names {
+		n[name]++
+	}
+	var out []Count
+	for name, c := range n {
+		out = append(out, Count{Name: name, N: c})
+	}
+	return out
+}
+
+// topCounts orders counts biggest first, ties by name, keeping the first
+// limit of them; 0 keeps all.
+func topCounts(counts []Count, limit int) []Count {
+	slices.SortFunc(counts, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.N, a.N), cmp.Compare(a.Name, b.Name))
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
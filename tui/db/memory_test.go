@@ -0,0 +1,225 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMemoryStoreInterfaceCompliance(t *testing.T) {
+	var _ Store = (*MemoryStore)(nil)
+}
+
+func TestIsMemoryURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"memory://", true},
+		{" MEMORY:// ", true},
+		{"memory:", true},
+		{"postgres://u:p@host/memory", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsMemoryURL(tt.url); got != tt.want {
+			t.Errorf("IsMemoryURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func albums(records []Record) []string {
+	var out []string
+	for _, r := range records {
+		out = append(out, r.AlbumTitle)
+	}
+	return out
+}
+
+func TestMemoryStoreQueries(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(SampleRecords())
+	tests := []struct {
+		name  string
+		query func() ([]Record, error)
+		want  []string
+	}{
+		{"list", func() ([]Record, error) { return s.List(ctx) },
+			[]string{"Homogenic", "A Love Supreme", "Trans-Europe Express", "Kind of Blue", "Pastel Blues", "Remain in Light"}},
+		{"search", func() ([]Record, error) { return s.Search(ctx, "jazz 1965") }, nil},
+		{"search terms", func() ([]Record, error) { return s.Search(ctx, "columbia") }, []string{"Kind of Blue"}},
+		{"search tag", func() ([]Record, error) { return s.Search(ctx, `tag:"TO SELL"`) }, []string{"Remain in Light"}},
+		{"genre", func() ([]Record, error) { return s.FilterByGenre(ctx, "Electronic") }, []string{"Homogenic", "Trans-Europe Express"}},
+		{"years", func() ([]Record, error) { return s.ListByYearRange(ctx, 1960, 1979) },
+			[]string{"A Love Supreme", "Trans-Europe Express", "Pastel Blues"}},
+		{"tag", func() ([]Record, error) { return s.ListByTag(ctx, "Favourite") }, []string{"Kind of Blue"}},
+		{"label", func() ([]Record, error) { return s.ListByLabel(ctx, "Sire") }, []string{"Remain in Light"}},
+		{"no label", func() ([]Record, error) { return s.ListByLabel(ctx, "") }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(albums(got), tt.want) {
+				t.Errorf("got %v, want %v", albums(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(nil)
+	id, err := s.Create(ctx, Record{ArtistName: "Can", AlbumTitle: "Tago Mago", Rating: new(4), PlayCount: 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := s.CreateBatch(ctx, []Record{{ArtistName: "Neu!", AlbumTitle: "Neu!", DataSource: "csv"}})
+	if len(ids) != 1 || err != nil {
+		t.Fatalf("CreateBatch = %v, %v", ids, err)
+	}
+	recs, _ := s.List(ctx)
+	if len(recs) != 2 || recs[0].RecordID != id || recs[1].RecordID != ids[0] || id == ids[0] {
+		t.Fatalf("List = %+v, want two records with the distinct IDs returned", recs)
+	}
+	can := recs[0]
+	if can.DataSource != "manual" || can.Rating != nil || can.PlayCount != 0 || can.CreatedAt.IsZero() {
+		t.Errorf("created %+v, want manual source, no rating or plays, and a timestamp", can)
+	}
+	if recs[1].DataSource != "csv" {
+		t.Errorf("data source = %q, want csv kept", recs[1].DataSource)
+	}
+
+	if err := s.Delete(ctx, "missing"); err == nil || err.Error() != "record not found: missing" {
+		t.Errorf("Delete(missing) = %v, want not found", err)
+	}
+	deleted, err := s.DeleteBatch(ctx, []string{can.RecordID, "missing"})
+	if err != nil || !slices.Equal(deleted, []string{can.RecordID}) {
+		t.Errorf("DeleteBatch = %v, %v; want only %s", deleted, err, can.RecordID)
+	}
+	if err := s.Delete(ctx, recs[1].RecordID); err != nil {
+		t.Errorf("Delete = %v", err)
+	}
+	if n, _ := s.Total(ctx); n != 0 {
+		t.Errorf("Total = %d, want 0", n)
+	}
+}
+
+func TestMemoryStoreCopiesRecords(t *testing.T) {
+	ctx := context.Background()
+	seed := SampleRecords()
+	s := NewMemoryStore(seed)
+	*seed[0].LabelName = "Changed"
+
+	recs, _ := s.ListByTag(ctx, "favourite")
+	recs[0].Tags[0] = "changed"
+	*recs[0].Rating = 1
+
+	again, _ := s.ListByTag(ctx, "favourite")
+	if len(again) != 1 || *again[0].LabelName != "Columbia" || *again[0].Rating != 5 {
+		t.Errorf("store record changed through a copy: %+v", again)
+	}
+}
+
+func TestMemoryStoreUpdates(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(SampleRecords())
+	recs, _ := s.Search(ctx, "kind of blue")
+	r := recs[0]
+
+	if err := s.UpdateField(ctx, r.RecordID, "year_released", "abc"); err == nil {
+		t.Error("UpdateField accepted a non-numeric year")
+	}
+	if err := s.UpdateField(ctx, r.RecordID, "notes", "x"); !errors.Is(err, ErrColumnNotEditable) {
+		t.Errorf("UpdateField(notes) = %v, want ErrColumnNotEditable", err)
+	}
+	if err := s.UpdateField(ctx, r.RecordID, "label_name", "  "); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := s.AddTag(ctx, r.RecordID, " Jazz ")
+	if err != nil || !slices.Equal(tags, []string{"favourite", "Jazz"}) {
+		t.Errorf("AddTag = %v, %v", tags, err)
+	}
+	if tags, _ := s.AddTag(ctx, r.RecordID, "JAZZ"); len(tags) != 2 {
+		t.Errorf("AddTag duplicate = %v, want it ignored", tags)
+	}
+	if tags, _ := s.RemoveTag(ctx, r.RecordID, "FAVOURITE"); !slices.Equal(tags, []string{"Jazz"}) {
+		t.Errorf("RemoveTag = %v, want [Jazz]", tags)
+	}
+	if _, err := s.AddTag(ctx, "missing", "x"); err == nil || !strings.Contains(err.Error(), "record not found") {
+		t.Errorf("AddTag(missing) = %v, want not found", err)
+	}
+	if err := s.SetRating(ctx, r.RecordID, 6); err == nil {
+		t.Error("SetRating accepted 6 stars")
+	}
+	if err := s.SetNotes(ctx, r.RecordID, "  "); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := s.RecordPlay(ctx, r.RecordID); err != nil || p.Count != 1 {
+		t.Errorf("RecordPlay = %+v, %v", p, err)
+	}
+
+	got, _ := s.Search(ctx, "kind of blue")
+	if got[0].LabelName != nil || got[0].Notes != nil || got[0].PlayCount != 1 || got[0].LastPlayedAt == nil {
+		t.Errorf("after updates %+v", got[0])
+	}
+	if unlabelled, _ := s.ListByLabel(ctx, ""); len(unlabelled) != 1 {
+		t.Errorf("ListByLabel(\"\") = %d records, want the one just cleared", len(unlabelled))
+	}
+}
+
+func TestMemoryStoreDiscogsSync(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore([]Record{
+		{ArtistName: "A", AlbumTitle: "One", DiscogsID: new("1")},
+		{ArtistName: "B", AlbumTitle: "Two", DiscogsID: new("2")},
+		{ArtistName: "C", AlbumTitle: "Three"},
+	})
+	if ids, _ := s.ListDiscogsIDs(ctx); len(ids) != 2 {
+		t.Errorf("ListDiscogsIDs = %v, want 1 and 2", ids)
+	}
+	_ = s.MarkSyncedWithDiscogs(ctx, []string{"1"})
+	unsynced, _ := s.ListUnsyncedDiscogsRecords(ctx)
+	if !slices.Equal(albums(unsynced), []string{"Two"}) {
+		t.Errorf("unsynced = %v, want [Two]", albums(unsynced))
+	}
+	two := unsynced[0]
+	two.Genres, two.ArtistName = []string{"Jazz"}, "Ignored"
+	if err := s.UpdateFromDiscogs(ctx, two); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.FilterByGenre(ctx, "Jazz")
+	if len(got) != 1 || !got[0].IsSyncedWithDiscogs || got[0].ArtistName != "B" {
+		t.Errorf("UpdateFromDiscogs left %+v", got)
+	}
+}
+
+func TestMemoryStoreCounts(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(append(SampleRecords(), Record{ArtistName: "Unknown", AlbumTitle: "Demo"}))
+
+	st, _ := s.Stats(ctx)
+	if st.Total != 7 || st.Synced != 0 {
+		t.Errorf("Stats totals = %d/%d, want 7/0", st.Total, st.Synced)
+	}
+	wantDecades := []Count{{"1950s", 1}, {"1960s", 2}, {"1970s", 1}, {"1980s", 1}, {"1990s", 1}, {"Unknown", 1}}
+	if !slices.Equal(st.Decades, wantDecades) {
+		t.Errorf("Decades = %v, want %v", st.Decades, wantDecades)
+	}
+	if len(st.TopGenres) != StatsTopN || st.TopGenres[0] != (Count{Name: "Jazz", N: 3}) {
+		t.Errorf("TopGenres = %v, want Jazz first of %d", st.TopGenres, StatsTopN)
+	}
+
+	labels, _ := s.Labels(ctx)
+	if labels[0].Name != "Columbia" || labels[len(labels)-1] != (Count{Name: "", N: 1}) {
+		t.Errorf("Labels = %v, want alphabetical with the unlabelled bucket last", labels)
+	}
+	genres, _ := s.Genres(ctx)
+	if genres[0] != (Count{Name: "Jazz", N: 3}) || genres[1] != (Count{Name: "Electronic", N: 2}) {
+		t.Errorf("Genres = %v, want biggest first", genres)
+	}
+}
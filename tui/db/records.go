@@ -2,34 +2,49 @@ package db
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Record is one row of the records table. JSON names match the column
+// names; nil pointers encode as null.
 type Record struct {
-	RecordID            string
-	ArtistName          string
-	AlbumTitle          string
-	YearReleased        *int
-	LabelName           *string
-	CatalogNumber       *string
-	DiscogsID           *string
-	DiscogsURI          *string
-	IsSyncedWithDiscogs bool
-	ThumbnailURL        *string
-	CoverImageURL       *string
-	Genres              []string
-	Styles              []string
-	UPCCode             *string
-	RecordSize          *string
-	VinylColor          *string
-	IsShapedVinyl       *bool
-	DataSource          string
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+	RecordID            string     `json:"record_id"`
+	ArtistName          string     `json:"artist_name"`
+	AlbumTitle          string     `json:"album_title"`
+	YearReleased        *int       `json:"year_released"`
+	LabelName           *string    `json:"label_name"`
+	CatalogNumber       *string    `json:"catalog_number"`
+	DiscogsID           *string    `json:"discogs_id"`
+	DiscogsURI          *string    `json:"discogs_uri"`
+	IsSyncedWithDiscogs bool       `json:"is_synced_with_discogs"`
+	ThumbnailURL        *string    `json:"thumbnail_url"`
+	CoverImageURL       *string    `json:"cover_image_url"`
+	Genres              []string   `json:"genres"`
+	Styles              []string   `json:"styles"`
+	UPCCode             *string    `json:"upc_code"`
+	RecordSize          *string    `json:"record_size"`
+	VinylColor          *string    `json:"vinyl_color"`
+	IsShapedVinyl       *bool      `json:"is_shaped_vinyl"`
+	DataSource          string     `json:"data_source"`
+	Tags                []string   `json:"tags"`
+	Notes               *string    `json:"notes"`
+	Rating              *int       `json:"rating"`
+	PlayCount           int        `json:"play_count"`
+	LastPlayedAt        *time.Time `json:"last_played_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
 func (r Record) YearString() string {
@@ -60,6 +75,71 @@ func (r Record) StylesString() string {
 	return "—"
 }
 
+func (r Record) TagsString() string {
+	if len(r.Tags) > 0 {
+		return strings.Join(r.Tags, ", ")
+	}
+	return "—"
+}
+
+// NotesString is the record's notes, or "—" when there are none.
+func (r Record) NotesString() string {
+	if r.Notes != nil && strings.TrimSpace(*r.Notes) != "" {
+		return *r.Notes
+	}
+	return "—"
+}
+
+// RatingString draws the rating as five stars, filled up to the rating,
+// or "—" for an unrated record.
+func (r Record) RatingString() string {
+	if r.Rating == nil {
+		return "—"
+	}
+	n := max(MinRating, min(*r.Rating, MaxRating))
+	return strings.Repeat("★", n) + strings.Repeat("☆", MaxRating-n)
+}
+
+// PlaysString is the play count, or "—" for a record never played.
+func (r Record) PlaysString() string {
+	if r.PlayCount == 0 {
+		return "—"
+	}
+	return strconv.Itoa(r.PlayCount)
+}
+
+// HasGenre reports whether genre is one of r.Genres, matching exactly like
+// FilterByGenre does.
+func (r Record) HasGenre(genre string) bool {
+	return slices.Contains(r.Genres, genre)
+}
+
+// ReleasedBetween reports whether the record's year is within from..to
+// inclusive, matching like ListByYearRange does: records without a year
+// never match.
+func (r Record) ReleasedBetween(from, to int) bool {
+	return r.YearReleased != nil && *r.YearReleased >= from && *r.YearReleased <= to
+}
+
+// HasTag reports whether the record carries tag, ignoring case.
+func (r Record) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLabel reports whether the record's label is label, matching exactly
+// like ListByLabel does; "" matches records with no label.
+func (r Record) HasLabel(label string) bool {
+	if r.LabelName == nil {
+		return label == ""
+	}
+	return *r.LabelName == label
+}
+
 func (r Record) SizeString() string {
 	if r.RecordSize != nil {
 		return *r.RecordSize
@@ -74,6 +154,16 @@ func (r Record) ColorString() string {
 	return "—"
 }
 
+func (r Record) ShapedString() string {
+	if r.IsShapedVinyl != nil {
+		if *r.IsShapedVinyl {
+			return "Yes"
+		}
+		return "No"
+	}
+	return "—"
+}
+
 func (r Record) ImageURL() string {
 	if r.CoverImageURL != nil {
 		return *r.CoverImageURL
@@ -84,90 +174,254 @@ func (r Record) ImageURL() string {
 	return ""
 }
 
+// ListImageURL is ImageURL for small renders: Discogs thumbnails are a
+// fraction of the cover's size, so they're preferred when present.
+func (r Record) ListImageURL() string {
+	if r.ThumbnailURL != nil {
+		return *r.ThumbnailURL
+	}
+	return r.ImageURL()
+}
+
 type Store interface {
 	List(ctx context.Context) ([]Record, error)
 	Search(ctx context.Context, query string) ([]Record, error)
+	FilterByGenre(ctx context.Context, genre string) ([]Record, error)
+	ListByYearRange(ctx context.Context, from, to int) ([]Record, error)
 	Delete(ctx context.Context, id string) error
-	Create(ctx context.Context, r Record) error
+	DeleteBatch(ctx context.Context, ids []string) ([]string, error)
+	Create(ctx context.Context, r Record) (string, error)
+	CreateBatch(ctx context.Context, records []Record) ([]string, error)
+	Update(ctx context.Context, r Record) error
+	UpdateField(ctx context.Context, id, column, value string) error
+	UpdateFromDiscogs(ctx context.Context, r Record) error
+	Stats(ctx context.Context) (Stats, error)
+	Total(ctx context.Context) (int, error)
+	Ping(ctx context.Context) error
+	SetTags(ctx context.Context, id string, tags []string) error
+	AddTag(ctx context.Context, id string, tag string) ([]string, error)
+	RemoveTag(ctx context.Context, id string, tag string) ([]string, error)
+	ListByTag(ctx context.Context, tag string) ([]Record, error)
+	Labels(ctx context.Context) ([]Count, error)
+	Genres(ctx context.Context) ([]Count, error)
+	ListByLabel(ctx context.Context, label string) ([]Record, error)
+	SetNotes(ctx context.Context, id string, notes string) error
+	SetRating(ctx context.Context, id string, rating int) error
+	ClearRating(ctx context.Context, id string) error
+	RecordPlay(ctx context.Context, id string) (Play, error)
 	ListDiscogsIDs(ctx context.Context) (map[string]struct{}, error)
 	MarkSyncedWithDiscogs(ctx context.Context, discogsIDs []string) error
 	ListUnsyncedDiscogsRecords(ctx context.Context) ([]Record, error)
 }
 
+// pgxPool is the part of *pgxpool.Pool that RecordStore uses, so tests
+// can stand in for the database.
+type pgxPool interface {
+	Ping(ctx context.Context) error
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type RecordStore struct {
-	pool *pgxpool.Pool
+	pool pgxPool
 }
 
+// NewRecordStore wraps a pool opened by Connect or ConnectRetry, which
+// prepare the statements the read queries run by name.
 func NewRecordStore(pool *pgxpool.Pool) *RecordStore {
 	return &RecordStore{pool: pool}
 }
 
+// Ping checks the database is still reachable. The pool dials a fresh
+// connection if the old ones died, so a successful Ping means it's back.
+func (s *RecordStore) Ping(ctx context.Context) error {
+	if err := s.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+	return nil
+}
+
 func (s *RecordStore) List(ctx context.Context) ([]Record, error) {
-	rows, err := s.pool.Query(ctx, `
-		SELECT record_id, artist_name, album_title, year_released, label_name,
-			catalog_number, discogs_id, discogs_uri, is_synced_with_discogs,
-			thumbnail_url, cover_image_url, genres, styles, upc_code,
-			record_size, vinyl_color, is_shaped_vinyl, data_source,
-			created_at, updated_at
-		FROM records
-		ORDER BY artist_name, album_title
-	`)
+	rows, err := s.pool.Query(ctx, stmtList)
 	if err != nil {
 		return nil, fmt.Errorf("query records: %w", err)
 	}
-	defer rows.Close()
-
-	var records []Record
-	for rows.Next() {
-		var r Record
-		err := rows.Scan(
-			&r.RecordID, &r.ArtistName, &r.AlbumTitle, &r.YearReleased,
-			&r.LabelName, &r.CatalogNumber, &r.DiscogsID, &r.DiscogsURI,
-			&r.IsSyncedWithDiscogs, &r.ThumbnailURL, &r.CoverImageURL,
-			&r.Genres, &r.Styles, &r.UPCCode, &r.RecordSize, &r.VinylColor,
-			&r.IsShapedVinyl, &r.DataSource, &r.CreatedAt, &r.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scan record: %w", err)
-		}
-		records = append(records, r)
-	}
-	return records, rows.Err()
+	return scanRecords(rows)
 }
 
 func (s *RecordStore) Search(ctx context.Context, query string) ([]Record, error) {
-	q := "%" + strings.ToLower(query) + "%"
+	where, args := searchWhere(ParseSearchQuery(query))
+	// The WHERE clause varies with the number of terms, so this can't be
+	// prepared up front; pgx's statement cache still reuses each shape.
 	rows, err := s.pool.Query(ctx, `
-		SELECT record_id, artist_name, album_title, year_released, label_name,
-			catalog_number, discogs_id, discogs_uri, is_synced_with_discogs,
-			thumbnail_url, cover_image_url, genres, styles, upc_code,
-			record_size, vinyl_color, is_shaped_vinyl, data_source,
-			created_at, updated_at
+		SELECT `+recordColumns+`
 		FROM records
-		WHERE LOWER(artist_name) LIKE $1 OR LOWER(album_title) LIKE $1
-		ORDER BY artist_name, album_title
-	`, q)
+		WHERE `+where+`
+		ORDER BY lower(artist_name), lower(album_title)
+	`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("search records: %w", err)
 	}
-	defer rows.Close()
+	return scanRecords(rows)
+}
 
-	var records []Record
-	for rows.Next() {
-		var r Record
-		err := rows.Scan(
-			&r.RecordID, &r.ArtistName, &r.AlbumTitle, &r.YearReleased,
-			&r.LabelName, &r.CatalogNumber, &r.DiscogsID, &r.DiscogsURI,
-			&r.IsSyncedWithDiscogs, &r.ThumbnailURL, &r.CoverImageURL,
-			&r.Genres, &r.Styles, &r.UPCCode, &r.RecordSize, &r.VinylColor,
-			&r.IsShapedVinyl, &r.DataSource, &r.CreatedAt, &r.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scan record: %w", err)
+// FilterByGenre returns records whose genres include genre exactly.
+func (s *RecordStore) FilterByGenre(ctx context.Context, genre string) ([]Record, error) {
+	rows, err := s.pool.Query(ctx, stmtFilterByGenre, genre)
+	if err != nil {
+		return nil, fmt.Errorf("filter records by genre: %w", err)
+	}
+	return scanRecords(rows)
+}
+
+// ListByTag returns records carrying tag, ignoring case like HasTag.
+func (s *RecordStore) ListByTag(ctx context.Context, tag string) ([]Record, error) {
+	rows, err := s.pool.Query(ctx, stmtListByTag, tag)
+	if err != nil {
+		return nil, fmt.Errorf("list records by tag: %w", err)
+	}
+	return scanRecords(rows)
+}
+
+// ListByLabel returns records on label; "" lists records with no label.
+func (s *RecordStore) ListByLabel(ctx context.Context, label string) ([]Record, error) {
+	rows, err := s.pool.Query(ctx, stmtListByLabel, label)
+	if err != nil {
+		return nil, fmt.Errorf("list records by label: %w", err)
+	}
+	return scanRecords(rows)
+}
+
+// ListByYearRange returns records released from..to inclusive. Records
+// without a year are left out.
+func (s *RecordStore) ListByYearRange(ctx context.Context, from, to int) ([]Record, error) {
+	rows, err := s.pool.Query(ctx, stmtListByYearRange, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list records by year range: %w", err)
+	}
+	return scanRecords(rows)
+}
+
+// SearchTerms splits a search query on whitespace into lower-cased terms.
+// Double-quoted phrases are kept together as a single literal term.
+func SearchTerms(query string) []string {
+	var terms []string
+	var cur strings.Builder
+	inQuote := false
+	flush := func() {
+		if t := strings.TrimSpace(cur.String()); t != "" {
+			terms = append(terms, strings.ToLower(t))
+		}
+		cur.Reset()
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			flush()
+			inQuote = !inQuote
+		case !inQuote && unicode.IsSpace(r):
+			flush()
+		default:
+			cur.WriteRune(r)
 		}
-		records = append(records, r)
 	}
-	return records, rows.Err()
+	flush()
+	return terms
+}
+
+// SearchQuery is a parsed search: free-text terms plus tag:<name> filters.
+type SearchQuery struct {
+	Terms []string
+	Tags  []string
+}
+
+func ParseSearchQuery(query string) SearchQuery {
+	var q SearchQuery
+	terms := SearchTerms(query)
+	for i := 0; i < len(terms); i++ {
+		tag, ok := strings.CutPrefix(terms[i], "tag:")
+		if !ok {
+			q.Terms = append(q.Terms, terms[i])
+			continue
+		}
+		// tag:"to sell" tokenizes as "tag:" followed by the quoted phrase.
+		if tag == "" && i+1 < len(terms) {
+			i++
+			tag = terms[i]
+		}
+		if tag != "" {
+			q.Tags = append(q.Tags, tag)
+		}
+	}
+	return q
+}
+
+// searchColumns are the text columns a free-text term is matched against,
+// in addition to each entry of genres.
+var searchColumns = []string{"artist_name", "album_title", "label_name", "catalog_number", "upc_code"}
+
+// searchText returns r's searchable values, lower-cased, in the same order
+// as searchColumns followed by the genres.
+func (r Record) searchText() []string {
+	fields := []*string{&r.ArtistName, &r.AlbumTitle, r.LabelName, r.CatalogNumber, r.UPCCode}
+	out := make([]string, 0, len(fields)+len(r.Genres))
+	for _, f := range fields {
+		if f != nil {
+			out = append(out, strings.ToLower(*f))
+		}
+	}
+	for _, g := range r.Genres {
+		out = append(out, strings.ToLower(g))
+	}
+	return out
+}
+
+// Matches applies the same rules as the SQL search to an in-memory record.
+func (q SearchQuery) Matches(r Record) bool {
+	text := r.searchText()
+	for _, t := range q.Terms {
+		if !slices.ContainsFunc(text, func(s string) bool { return strings.Contains(s, t) }) {
+			return false
+		}
+	}
+	for _, tag := range q.Tags {
+		if !r.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// likeEscaper escapes LIKE's wildcards and its escape character, so a term
+// matches as a plain substring the way Matches compares it.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// searchWhere builds a WHERE clause requiring every term to match at least
+// one searchable column or genre, and every tag filter to be present.
+// COALESCE keeps NULL columns from turning the whole OR into NULL.
+func searchWhere(q SearchQuery) (string, []any) {
+	var clauses []string
+	var args []any
+	for _, t := range q.Terms {
+		args = append(args, "%"+likeEscaper.Replace(t)+"%")
+		n := len(args)
+		var ors []string
+		for _, col := range searchColumns {
+			ors = append(ors, fmt.Sprintf(`LOWER(COALESCE(%s, '')) LIKE $%d ESCAPE '\'`, col, n))
+		}
+		ors = append(ors, fmt.Sprintf(`EXISTS (SELECT 1 FROM unnest(genres) AS g WHERE LOWER(g) LIKE $%d ESCAPE '\')`, n))
+		clauses = append(clauses, "("+strings.Join(ors, " OR ")+")")
+	}
+	for _, tag := range q.Tags {
+		args = append(args, tag)
+		clauses = append(clauses, fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = $%d)", len(args)))
+	}
+	if len(clauses) == 0 {
+		return "true", nil
+	}
+	return strings.Join(clauses, " AND "), args
 }
 
 func (s *RecordStore) Delete(ctx context.Context, id string) error {
@@ -181,13 +435,33 @@ func (s *RecordStore) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *RecordStore) Create(ctx context.Context, r Record) error {
+// DeleteBatch removes every record in ids with a single statement and
+// returns the IDs that existed and were deleted.
+func (s *RecordStore) DeleteBatch(ctx context.Context, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rows, err := s.pool.Query(ctx,
+		`DELETE FROM records WHERE record_id = ANY($1::uuid[]) RETURNING record_id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("delete records: %w", err)
+	}
+	deleted, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("delete records: %w", err)
+	}
+	return deleted, nil
+}
+
+// Create inserts r and returns the record_id the database gave it.
+func (s *RecordStore) Create(ctx context.Context, r Record) (string, error) {
 	dataSource := r.DataSource
 	if dataSource == "" {
 		dataSource = "manual"
 	}
 
-	_, err := s.pool.Exec(ctx, `
+	var id string
+	err := s.pool.QueryRow(ctx, `
 		INSERT INTO records (
 			artist_name,
 			album_title,
@@ -205,7 +479,8 @@ func (s *RecordStore) Create(ctx context.Context, r Record) error {
 			record_size,
 			vinyl_color,
 			is_shaped_vinyl,
-			data_source
+			data_source,
+			tags
 		)
 		VALUES (
 			$1,
@@ -224,8 +499,10 @@ func (s *RecordStore) Create(ctx context.Context, r Record) error {
 			$14,
 			$15,
 			$16,
-			$17
+			$17,
+			$18
 		)
+		RETURNING record_id
 	`,
 		r.ArtistName,
 		r.AlbumTitle,
@@ -244,9 +521,274 @@ func (s *RecordStore) Create(ctx context.Context, r Record) error {
 		r.VinylColor,
 		r.IsShapedVinyl,
 		dataSource,
+		r.Tags,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("insert record: %w", err)
+	}
+	return id, nil
+}
+
+// batchColumns are the columns Create inserts and CreateBatch copies after
+// record_id; the timestamps come from their defaults.
+var batchColumns = []string{
+	"artist_name", "album_title", "year_released", "label_name",
+	"catalog_number", "discogs_id", "discogs_uri", "is_synced_with_discogs",
+	"thumbnail_url", "cover_image_url", "genres", "styles", "upc_code",
+	"record_size", "vinyl_color", "is_shaped_vinyl", "data_source", "tags",
+}
+
+// CreateBatch inserts records with one COPY inside a transaction, so either
+// every row lands or none do. COPY can't return the generated keys, so the
+// record IDs are made here and returned in the order of records.
+func (s *RecordStore) CreateBatch(ctx context.Context, records []Record) ([]string, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	ids := make([]pgtype.UUID, len(records))
+	for i := range ids {
+		ids[i] = newRecordID()
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin batch: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"records"}, append([]string{"record_id"}, batchColumns...),
+		pgx.CopyFromSlice(len(records), func(i int) ([]any, error) {
+			r := records[i]
+			dataSource := r.DataSource
+			if dataSource == "" {
+				dataSource = "manual"
+			}
+			return []any{
+				ids[i], r.ArtistName, r.AlbumTitle, r.YearReleased, r.LabelName,
+				r.CatalogNumber, r.DiscogsID, r.DiscogsURI, r.IsSyncedWithDiscogs,
+				r.ThumbnailURL, r.CoverImageURL, r.Genres, r.Styles, r.UPCCode,
+				r.RecordSize, r.VinylColor, r.IsShapedVinyl, dataSource, r.Tags,
+			}, nil
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("copy records: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit batch: %w", err)
+	}
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out, nil
+}
+
+// newRecordID returns a random (version 4) UUID, the same kind the
+// record_id default generates.
+func newRecordID() pgtype.UUID {
+	var u pgtype.UUID
+	_, _ = rand.Read(u.Bytes[:])
+	u.Bytes[6] = u.Bytes[6]&0x0f | 0x40
+	u.Bytes[8] = u.Bytes[8]&0x3f | 0x80
+	u.Valid = true
+	return u
+}
+
+func (s *RecordStore) SetTags(ctx context.Context, id string, tags []string) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE records SET tags = $2, updated_at = now() WHERE record_id = $1`,
+		id, tags,
 	)
 	if err != nil {
-		return fmt.Errorf("insert record: %w", err)
+		return fmt.Errorf("set tags: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return nil
+}
+
+// AddTag puts tag on the record unless it already carries it (in any
+// case) and returns the record's tags afterwards.
+func (s *RecordStore) AddTag(ctx context.Context, id string, tag string) ([]string, error) {
+	return s.updateTags(ctx, "add tag", id, tag, `
+		UPDATE records SET tags = array_append(coalesce(tags, '{}'), $2), updated_at = now()
+		WHERE record_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($2))
+		RETURNING tags`)
+}
+
+// RemoveTag takes tag (in any case) off the record and returns the
+// record's tags afterwards.
+func (s *RecordStore) RemoveTag(ctx context.Context, id string, tag string) ([]string, error) {
+	return s.updateTags(ctx, "remove tag", id, tag, `
+		UPDATE records
+		SET tags = ARRAY(SELECT t FROM unnest(tags) AS t WHERE LOWER(t) <> LOWER($2)), updated_at = now()
+		WHERE record_id = $1
+		  AND EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($2))
+		RETURNING tags`)
+}
+
+// updateTags runs an AddTag or RemoveTag update. Those skip rows they
+// wouldn't change, so no row back means the tags were already right — or
+// the record is gone, which a second lookup tells apart.
+func (s *RecordStore) updateTags(ctx context.Context, op, id, tag, sql string) ([]string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("%s: tag is empty", op)
+	}
+	var tags []string
+	err := s.pool.QueryRow(ctx, sql, id, tag).Scan(&tags)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = s.pool.QueryRow(ctx, `SELECT tags FROM records WHERE record_id = $1`, id).Scan(&tags)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("record not found: %s", id)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tags, nil
+}
+
+// SetNotes replaces the record's notes; blank notes clear the column.
+func (s *RecordStore) SetNotes(ctx context.Context, id string, notes string) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE records SET notes = NULLIF($2, ''), updated_at = now() WHERE record_id = $1`,
+		id, strings.TrimSpace(notes),
+	)
+	if err != nil {
+		return fmt.Errorf("set notes: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return nil
+}
+
+// SetRating gives the record rating stars, which must be within
+// MinRating..MaxRating; ClearRating makes it unrated again.
+func (s *RecordStore) SetRating(ctx context.Context, id string, rating int) error {
+	if !ValidRating(rating) {
+		return ValidationErrors{{FieldRating, ratingProblem}}
+	}
+	return s.updateRating(ctx, id, &rating)
+}
+
+func (s *RecordStore) ClearRating(ctx context.Context, id string) error {
+	return s.updateRating(ctx, id, nil)
+}
+
+// Play is a record's listening history after RecordPlay.
+type Play struct {
+	Count int
+	At    time.Time
+}
+
+// RecordPlay counts one more play of the record and stamps it as played
+// now. Listening isn't an edit, so updated_at is left alone.
+func (s *RecordStore) RecordPlay(ctx context.Context, id string) (Play, error) {
+	var p Play
+	err := s.pool.QueryRow(ctx,
+		`UPDATE records SET play_count = play_count + 1, last_played_at = now()
+		WHERE record_id = $1 RETURNING play_count, last_played_at`,
+		id,
+	).Scan(&p.Count, &p.At)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Play{}, fmt.Errorf("record not found: %s", id)
+	}
+	if err != nil {
+		return Play{}, fmt.Errorf("record play: %w", err)
+	}
+	return p, nil
+}
+
+func (s *RecordStore) updateRating(ctx context.Context, id string, rating *int) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE records SET rating = $2, updated_at = now() WHERE record_id = $1`,
+		id, rating,
+	)
+	if err != nil {
+		return fmt.Errorf("set rating: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return nil
+}
+
+// Update writes the user-editable fields of r (the ones the edit form
+// covers) to the row with r.RecordID and bumps updated_at. Discogs-owned
+// fields such as the cover URLs and sync flag are left alone.
+func (s *RecordStore) Update(ctx context.Context, r Record) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE records SET
+			artist_name = $2,
+			album_title = $3,
+			year_released = $4,
+			label_name = $5,
+			catalog_number = $6,
+			genres = $7,
+			tags = $8,
+			record_size = $9,
+			vinyl_color = $10,
+			updated_at = now()
+		WHERE record_id = $1
+	`,
+		r.RecordID,
+		r.ArtistName,
+		r.AlbumTitle,
+		r.YearReleased,
+		r.LabelName,
+		r.CatalogNumber,
+		r.Genres,
+		r.Tags,
+		r.RecordSize,
+		r.VinylColor,
+	)
+	if err != nil {
+		return fmt.Errorf("update record: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("record not found: %s", r.RecordID)
+	}
+	return nil
+}
+
+// UpdateFromDiscogs writes the Discogs-owned fields of r (release metadata
+// and cover URLs) to the row with r.RecordID and marks it synced. Fields
+// the user edits, such as artist, title, tags, notes and rating, and the
+// play history are left alone.
+func (s *RecordStore) UpdateFromDiscogs(ctx context.Context, r Record) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE records SET
+			year_released = $2,
+			label_name = $3,
+			catalog_number = $4,
+			genres = $5,
+			styles = $6,
+			discogs_uri = $7,
+			thumbnail_url = $8,
+			cover_image_url = $9,
+			is_synced_with_discogs = true,
+			updated_at = now()
+		WHERE record_id = $1
+	`,
+		r.RecordID,
+		r.YearReleased,
+		r.LabelName,
+		r.CatalogNumber,
+		r.Genres,
+		r.Styles,
+		r.DiscogsURI,
+		r.ThumbnailURL,
+		r.CoverImageURL,
+	)
+	if err != nil {
+		return fmt.Errorf("update record from discogs: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("record not found: %s", r.RecordID)
 	}
 	return nil
 }
@@ -291,35 +833,9 @@ func (s *RecordStore) MarkSyncedWithDiscogs(ctx context.Context, discogsIDs []st
 }
 
 func (s *RecordStore) ListUnsyncedDiscogsRecords(ctx context.Context) ([]Record, error) {
-	rows, err := s.pool.Query(ctx, `
-		SELECT record_id, artist_name, album_title, year_released, label_name,
-			catalog_number, discogs_id, discogs_uri, is_synced_with_discogs,
-			thumbnail_url, cover_image_url, genres, styles, upc_code,
-			record_size, vinyl_color, is_shaped_vinyl, data_source,
-			created_at, updated_at
-		FROM records
-		WHERE discogs_id IS NOT NULL AND is_synced_with_discogs = false
-		ORDER BY artist_name, album_title
-	`)
+	rows, err := s.pool.Query(ctx, stmtListUnsynced)
 	if err != nil {
 		return nil, fmt.Errorf("query unsynced records: %w", err)
 	}
-	defer rows.Close()
-
-	var records []Record
-	for rows.Next() {
-		var r Record
-		err := rows.Scan(
-			&r.RecordID, &r.ArtistName, &r.AlbumTitle, &r.YearReleased,
-			&r.LabelName, &r.CatalogNumber, &r.DiscogsID, &r.DiscogsURI,
-			&r.IsSyncedWithDiscogs, &r.ThumbnailURL, &r.CoverImageURL,
-			&r.Genres, &r.Styles, &r.UPCCode, &r.RecordSize, &r.VinylColor,
-			&r.IsShapedVinyl, &r.DataSource, &r.CreatedAt, &r.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scan record: %w", err)
-		}
-		records = append(records, r)
-	}
-	return records, rows.Err()
+	return scanRecords(rows)
 }
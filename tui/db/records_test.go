@@ -1,8 +1,21 @@
 package db
 
 import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func TestYearString(t *testing.T) {
@@ -121,6 +134,104 @@ func TestColorString(t *testing.T) {
 	}
 }
 
+func TestShapedString(t *testing.T) {
+	tests := []struct {
+		name   string
+		shaped *bool
+		want   string
+	}{
+		{"shaped", new(true), "Yes"},
+		{"round", new(false), "No"},
+		{"nil", nil, "—"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Record{IsShapedVinyl: tt.shaped}
+			if got := r.ShapedString(); got != tt.want {
+				t.Errorf("ShapedString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotesString(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes *string
+		want  string
+	}{
+		{"set", new("Bought at Amoeba"), "Bought at Amoeba"},
+		{"nil", nil, "—"},
+		{"blank", new("  "), "—"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Record{Notes: tt.notes}
+			if got := r.NotesString(); got != tt.want {
+				t.Errorf("NotesString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingString(t *testing.T) {
+	tests := []struct {
+		name   string
+		rating *int
+		want   string
+	}{
+		{"unrated", nil, "—"},
+		{"one", new(1), "★☆☆☆☆"},
+		{"three", new(3), "★★★☆☆"},
+		{"five", new(5), "★★★★★"},
+		{"above range", new(9), "★★★★★"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Record{Rating: tt.rating}
+			if got := r.RatingString(); got != tt.want {
+				t.Errorf("RatingString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaysString(t *testing.T) {
+	tests := []struct {
+		plays int
+		want  string
+	}{
+		{0, "—"},
+		{1, "1"},
+		{42, "42"},
+	}
+	for _, tt := range tests {
+		if got := (Record{PlayCount: tt.plays}).PlaysString(); got != tt.want {
+			t.Errorf("PlaysString(%d) = %q, want %q", tt.plays, got, tt.want)
+		}
+	}
+}
+
+func TestTagUpdatesRejectEmptyTag(t *testing.T) {
+	s := &RecordStore{}
+	if _, err := s.AddTag(context.Background(), "id", "  "); err == nil || !strings.Contains(err.Error(), "tag is empty") {
+		t.Errorf("AddTag(blank) = %v, want empty-tag error", err)
+	}
+	if _, err := s.RemoveTag(context.Background(), "id", ""); err == nil || !strings.Contains(err.Error(), "tag is empty") {
+		t.Errorf("RemoveTag(empty) = %v, want empty-tag error", err)
+	}
+}
+
+func TestSetRatingRejectsOutOfRange(t *testing.T) {
+	s := &RecordStore{}
+	for _, rating := range []int{0, 6, -1} {
+		err := s.SetRating(context.Background(), "id", rating)
+		if verrs, ok := errors.AsType[ValidationErrors](err); !ok || verrs.For(FieldRating) == "" {
+			t.Errorf("SetRating(%d) = %v, want a rating validation error", rating, err)
+		}
+	}
+}
+
 func TestImageURL(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -142,6 +253,27 @@ func TestImageURL(t *testing.T) {
 	}
 }
 
+func TestListImageURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		cover     *string
+		thumbnail *string
+		want      string
+	}{
+		{"thumbnail preferred", new("https://cover.jpg"), new("https://thumb.jpg"), "https://thumb.jpg"},
+		{"cover fallback", new("https://cover.jpg"), nil, "https://cover.jpg"},
+		{"both nil", nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Record{CoverImageURL: tt.cover, ThumbnailURL: tt.thumbnail}
+			if got := r.ListImageURL(); got != tt.want {
+				t.Errorf("ListImageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConnectEmptyURL(t *testing.T) {
 	_, err := Connect("")
 	if err == nil {
@@ -163,3 +295,820 @@ func TestNewRecordStore(t *testing.T) {
 func TestStoreInterfaceCompliance(t *testing.T) {
 	var _ Store = (*RecordStore)(nil)
 }
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"single", "Miles", []string{"miles"}},
+		{"multiple", "miles  blue", []string{"miles", "blue"}},
+		{"quoted phrase", `"kind of blue"`, []string{"kind of blue"}},
+		{"phrase and term", `davis "kind of"`, []string{"davis", "kind of"}},
+		{"unterminated quote", `"love supreme`, []string{"love supreme"}},
+		{"empty", "   ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SearchTerms(tt.query)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SearchTerms(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func termClause(n int) string {
+	return fmt.Sprintf(`LOWER(COALESCE(artist_name, '')) LIKE $%[1]d ESCAPE '\' OR LOWER(COALESCE(album_title, '')) LIKE $%[1]d ESCAPE '\'`+
+		` OR LOWER(COALESCE(label_name, '')) LIKE $%[1]d ESCAPE '\' OR LOWER(COALESCE(catalog_number, '')) LIKE $%[1]d ESCAPE '\'`+
+		` OR LOWER(COALESCE(upc_code, '')) LIKE $%[1]d ESCAPE '\'`+
+		` OR EXISTS (SELECT 1 FROM unnest(genres) AS g WHERE LOWER(g) LIKE $%[1]d ESCAPE '\')`, n)
+}
+
+func TestSearchWhere(t *testing.T) {
+	where, args := searchWhere(SearchQuery{Terms: []string{"miles", "blue"}})
+	want := "(" + termClause(1) + ") AND (" + termClause(2) + ")"
+	if where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if len(args) != 2 || args[0] != "%miles%" || args[1] != "%blue%" {
+		t.Errorf("args = %v, want [%%miles%% %%blue%%]", args)
+	}
+
+	where, args = searchWhere(SearchQuery{})
+	if where != "true" || len(args) != 0 {
+		t.Errorf("empty terms where = %q args = %v", where, args)
+	}
+}
+
+func TestSearchWhereTags(t *testing.T) {
+	where, args := searchWhere(SearchQuery{Terms: []string{"miles"}, Tags: []string{"to-sell"}})
+	want := "(" + termClause(1) + ") AND EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = $2)"
+	if where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if len(args) != 2 || args[1] != "to-sell" {
+		t.Errorf("args = %v, want tag as second arg", args)
+	}
+}
+
+// sqlLike evaluates a LIKE pattern with ESCAPE '\' the way Postgres does.
+func sqlLike(s, pattern string) bool {
+	var re strings.Builder
+	re.WriteString(`(?s)^`)
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == '\\' && i+1 < len(runes):
+			i++
+			re.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case c == '%':
+			re.WriteString(`.*`)
+		case c == '_':
+			re.WriteString(`.`)
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString(`$`)
+	return regexp.MustCompile(re.String()).MatchString(s)
+}
+
+// TestSearchWhereAgreesWithMatches runs the LIKE patterns searchWhere binds
+// over the same text Matches reads, and checks both pick the records the
+// memory store's Search does, wildcards in the query included.
+func TestSearchWhereAgreesWithMatches(t *testing.T) {
+	records := []Record{
+		{ArtistName: "Pure", AlbumTitle: "100% Pure"},
+		{ArtistName: "Proof", AlbumTitle: "100 Proof"},
+		{ArtistName: "Under_Score", AlbumTitle: "One"},
+		{ArtistName: "UnderXScore", AlbumTitle: "Two"},
+		{ArtistName: `Back\Slash`, AlbumTitle: "Three"},
+		{ArtistName: "Backslash", AlbumTitle: "Four", Genres: []string{"Rock_n_Roll"}},
+	}
+	store := NewMemoryStore(records)
+	for _, query := range []string{"100%", "%", "_", "under_score", `back\s`, `\`, "rock_n", "k_n", "pure 100", ""} {
+		t.Run(query, func(t *testing.T) {
+			q := ParseSearchQuery(query)
+			where, args := searchWhere(q)
+			if n := strings.Count(where, " LIKE "); n != strings.Count(where, ` ESCAPE '\'`) {
+				t.Fatalf("%d LIKEs but not every one has ESCAPE '\\': %s", n, where)
+			}
+			var sql, matched []string
+			for _, r := range records {
+				if whereMatches(r, args) {
+					sql = append(sql, r.AlbumTitle)
+				}
+				if q.Matches(r) {
+					matched = append(matched, r.AlbumTitle)
+				}
+			}
+			got, _ := store.Search(context.Background(), query)
+			want := albums(got)
+			slices.Sort(sql)
+			slices.Sort(matched)
+			slices.Sort(want)
+			if !slices.Equal(sql, want) || !slices.Equal(matched, want) {
+				t.Errorf("SQL picks %q, Matches %q, memory store %q", sql, matched, want)
+			}
+		})
+	}
+}
+
+// whereMatches applies searchWhere's term args to r: every pattern must
+// match one of its searchable values.
+func whereMatches(r Record, args []any) bool {
+	text := r.searchText()
+	for _, a := range args {
+		if !slices.ContainsFunc(text, func(s string) bool { return sqlLike(s, a.(string)) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		terms []string
+		tags  []string
+	}{
+		{"terms only", "miles blue", []string{"miles", "blue"}, nil},
+		{"tag prefix", "tag:gift", nil, []string{"gift"}},
+		{"tag with terms", "Tag:To-Sell miles", []string{"miles"}, []string{"to-sell"}},
+		{"quoted tag", `tag:"rare pressing"`, nil, []string{"rare pressing"}},
+		{"bare tag prefix ignored", "tag:", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseSearchQuery(tt.query)
+			if !slices.Equal(got.Terms, tt.terms) {
+				t.Errorf("Terms = %q, want %q", got.Terms, tt.terms)
+			}
+			if !slices.Equal(got.Tags, tt.tags) {
+				t.Errorf("Tags = %q, want %q", got.Tags, tt.tags)
+			}
+		})
+	}
+}
+
+func TestSearchQueryMatchesTags(t *testing.T) {
+	r := Record{ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", Tags: []string{"Gift", "rare-pressing"}}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"tag:gift", true},
+		{"tag:gift tag:rare-pressing", true},
+		{"tag:to-sell", false},
+		{"miles tag:gift", true},
+		{"coltrane tag:gift", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := ParseSearchQuery(tt.query).Matches(r); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagsString(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{"multiple", []string{"gift", "to-sell"}, "gift, to-sell"},
+		{"empty", nil, "—"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Record{Tags: tt.tags}
+			if got := r.TagsString(); got != tt.want {
+				t.Errorf("TagsString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasGenre(t *testing.T) {
+	r := Record{Genres: []string{"Jazz", "Funk / Soul"}}
+	tests := []struct {
+		genre string
+		want  bool
+	}{
+		{"Jazz", true},
+		{"Funk / Soul", true},
+		{"jazz", false},
+		{"Rock", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := r.HasGenre(tt.genre); got != tt.want {
+			t.Errorf("HasGenre(%q) = %v, want %v", tt.genre, got, tt.want)
+		}
+	}
+}
+
+func TestHasLabel(t *testing.T) {
+	blueNote, blank := "Blue Note", ""
+	tests := []struct {
+		label *string
+		query string
+		want  bool
+	}{
+		{&blueNote, "Blue Note", true},
+		{&blueNote, "blue note", false},
+		{&blueNote, "", false},
+		{nil, "", true},
+		{nil, "Blue Note", false},
+		{&blank, "", true},
+	}
+	for _, tt := range tests {
+		r := Record{LabelName: tt.label}
+		if got := r.HasLabel(tt.query); got != tt.want {
+			t.Errorf("HasLabel(%q) on %q = %v, want %v", tt.query, r.LabelString(), got, tt.want)
+		}
+	}
+}
+
+func TestReleasedBetween(t *testing.T) {
+	year := func(y int) *int { return &y }
+	tests := []struct {
+		year *int
+		want bool
+	}{
+		{year(1970), true},
+		{year(1975), true},
+		{year(1979), true},
+		{year(1969), false},
+		{year(1980), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		r := Record{YearReleased: tt.year}
+		if got := r.ReleasedBetween(1970, 1979); got != tt.want {
+			t.Errorf("ReleasedBetween(1970, 1979) with year %v = %v, want %v", r.YearString(), got, tt.want)
+		}
+	}
+}
+
+func TestSearchQueryMatchesAllFields(t *testing.T) {
+	label, catalog, upc := "Blue Note", "BLP 1595", "0724349532923"
+	r := Record{
+		ArtistName: "Cannonball Adderley", AlbumTitle: "Somethin' Else",
+		LabelName: &label, CatalogNumber: &catalog, UPCCode: &upc,
+		Genres: []string{"Jazz"},
+	}
+	bare := Record{ArtistName: "Cannonball Adderley", AlbumTitle: "Somethin' Else"}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`"blue note"`, true},
+		{"blp", true},
+		{"0724349532923", true},
+		{"jazz", true},
+		{"jazz cannonball", true},
+		{"jazz rock", false},
+		{"verve", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := ParseSearchQuery(tt.query).Matches(r); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+	if ParseSearchQuery("blue").Matches(bare) {
+		t.Error("nil columns should not match")
+	}
+}
+
+func TestRecordJSONUsesColumnNames(t *testing.T) {
+	data, err := json.Marshal(Record{RecordID: "r1", ArtistName: "A", AlbumTitle: "B", YearReleased: new(1959)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["record_id"] != "r1" || got["year_released"] != float64(1959) {
+		t.Errorf("json = %s", data)
+	}
+	for _, key := range []string{"label_name", "discogs_uri", "is_shaped_vinyl", "genres"} {
+		v, ok := got[key]
+		if !ok || v != nil {
+			t.Errorf("%s = %v (present %v), want null", key, v, ok)
+		}
+	}
+	if len(got) != 25 {
+		t.Errorf("encoded %d fields, want all 25 columns", len(got))
+	}
+}
+
+// fakePool stands in for *pgxpool.Pool: it records every statement and
+// answers with the rows, command tag or error it was given. Queries take
+// the next of rowSets while any are left, then rows.
+type fakePool struct {
+	calls   []fakeCall
+	rowSets []*fakeRows
+	rows    *fakeRows
+	tag     pgconn.CommandTag
+	err     error
+}
+
+type fakeCall struct {
+	sql  string
+	args []any
+}
+
+func (p *fakePool) nextRows() *fakeRows {
+	if len(p.rowSets) > 0 {
+		rows := p.rowSets[0]
+		p.rowSets = p.rowSets[1:]
+		return rows
+	}
+	return p.rows
+}
+
+func (p *fakePool) Ping(context.Context) error { return p.err }
+
+func (p *fakePool) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	p.calls = append(p.calls, fakeCall{sql, args})
+	if p.err != nil {
+		return nil, p.err
+	}
+	rows := p.nextRows()
+	if rows == nil {
+		p.rows = &fakeRows{}
+		rows = p.rows
+	}
+	return rows, nil
+}
+
+func (p *fakePool) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	p.calls = append(p.calls, fakeCall{sql, args})
+	rows := p.nextRows()
+	if p.err != nil || rows == nil || len(rows.rows) == 0 {
+		return fakeRow{err: cmp.Or(p.err, pgx.ErrNoRows)}
+	}
+	return fakeRow{vals: rows.rows[0]}
+}
+
+func (p *fakePool) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	p.calls = append(p.calls, fakeCall{sql, args})
+	return p.tag, p.err
+}
+
+func (p *fakePool) Begin(context.Context) (pgx.Tx, error) {
+	return &fakeTx{pool: p}, nil
+}
+
+// fakeTx records a COPY on its pool as "COPY table (columns)" with one
+// []any arg per row copied. Its other pgx.Tx methods aren't implemented.
+type fakeTx struct {
+	pgx.Tx
+	pool      *fakePool
+	committed bool
+}
+
+func (tx *fakeTx) CopyFrom(_ context.Context, table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error) {
+	var rows []any
+	for src.Next() {
+		vals, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		rows = append(rows, vals)
+	}
+	tx.pool.calls = append(tx.pool.calls, fakeCall{"COPY " + table.Sanitize() + " (" + strings.Join(columns, ", ") + ")", rows})
+	return int64(len(rows)), tx.pool.err
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error { return nil }
+
+// fakeRows serves rows through fakeRow, then reports scanErr from Scan or
+// err from Err as a driver would.
+type fakeRows struct {
+	rows    [][]any
+	next    int
+	scanErr error
+	err     error
+	closed  bool
+}
+
+func (r *fakeRows) Close()                                       { r.closed = true }
+func (r *fakeRows) Err() error                                   { return r.err }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.next >= len(r.rows) {
+		r.Close()
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return fakeRow{vals: r.rows[r.next-1], err: r.scanErr}.Scan(dest...)
+}
+
+func (r *fakeRows) Values() ([]any, error) { return r.rows[r.next-1], nil }
+
+// recordRow returns a recordColumns row for a record with only the
+// required columns set.
+func recordRow(id, artist, album string) []any {
+	var now time.Time
+	return []any{
+		id, artist, album, (*int)(nil),
+		(*string)(nil), (*string)(nil), (*string)(nil), (*string)(nil),
+		false, (*string)(nil), (*string)(nil),
+		[]string(nil), []string(nil), (*string)(nil), (*string)(nil), (*string)(nil),
+		(*bool)(nil), "manual", []string(nil), (*string)(nil), (*int)(nil), 0, (*time.Time)(nil), now, now,
+	}
+}
+
+func TestRecordStoreList(t *testing.T) {
+	rows := &fakeRows{rows: [][]any{
+		recordRow("id-1", "Miles Davis", "Kind of Blue"),
+		recordRow("id-2", "Nina Simone", "Pastel Blues"),
+	}}
+	pool := &fakePool{rows: rows}
+	got, err := (&RecordStore{pool: pool}).List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(got) != 2 || got[0].RecordID != "id-1" || got[1].AlbumTitle != "Pastel Blues" {
+		t.Errorf("List = %+v, want both rows in order", got)
+	}
+	if len(pool.calls) != 1 || pool.calls[0].sql != stmtList || len(pool.calls[0].args) != 0 {
+		t.Errorf("calls = %+v, want the %s statement without args", pool.calls, stmtList)
+	}
+	if !rows.closed {
+		t.Error("List should close its rows")
+	}
+}
+
+func TestRecordStoreListErrors(t *testing.T) {
+	boom := errors.New("boom")
+	row := recordRow("id-1", "Miles Davis", "Kind of Blue")
+	tests := []struct {
+		name string
+		pool *fakePool
+		want string
+	}{
+		{"query", &fakePool{err: boom}, "query records: boom"},
+		{"scan", &fakePool{rows: &fakeRows{rows: [][]any{row}, scanErr: boom}}, "scan record: boom"},
+		{"rows", &fakePool{rows: &fakeRows{rows: [][]any{row}, err: boom}}, "read records: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&RecordStore{pool: tt.pool}).List(context.Background())
+			if err == nil || err.Error() != tt.want || !errors.Is(err, boom) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+			if got != nil {
+				t.Errorf("List = %+v, want no records on error", got)
+			}
+			if tt.pool.rows != nil && !tt.pool.rows.closed {
+				t.Error("rows left open")
+			}
+		})
+	}
+}
+
+func TestRecordStoreSearchBindsArgs(t *testing.T) {
+	pool := &fakePool{rows: &fakeRows{rows: [][]any{recordRow("id-1", "Miles Davis", "Kind of Blue")}}}
+	got, err := (&RecordStore{pool: pool}).Search(context.Background(), `Miles tag:"to sell"`)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Search = %v, %v; want one record", got, err)
+	}
+	where, _ := searchWhere(ParseSearchQuery(`Miles tag:"to sell"`))
+	call := pool.calls[0]
+	if !strings.Contains(call.sql, recordColumns) || !strings.Contains(call.sql, "WHERE "+where) {
+		t.Errorf("sql = %s, want the record columns and WHERE %s", call.sql, where)
+	}
+	if want := []any{"%miles%", "to sell"}; !slices.Equal(call.args, want) {
+		t.Errorf("args = %v, want %v", call.args, want)
+	}
+
+	pool = &fakePool{err: errors.New("boom")}
+	if _, err := (&RecordStore{pool: pool}).Search(context.Background(), "x"); err == nil || !strings.HasPrefix(err.Error(), "search records:") {
+		t.Errorf("err = %v, want wrapped search error", err)
+	}
+}
+
+func TestRecordStoreDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		pool *fakePool
+		want string
+	}{
+		{"deleted", &fakePool{tag: pgconn.NewCommandTag("DELETE 1")}, ""},
+		{"missing", &fakePool{tag: pgconn.NewCommandTag("DELETE 0")}, "record not found: id-1"},
+		{"exec", &fakePool{err: errors.New("boom")}, "delete record: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&RecordStore{pool: tt.pool}).Delete(context.Background(), "id-1")
+			if got := fmt.Sprint(err); (tt.want == "" && err != nil) || (tt.want != "" && got != tt.want) {
+				t.Errorf("Delete = %v, want %q", err, tt.want)
+			}
+			call := tt.pool.calls[0]
+			if !strings.HasPrefix(call.sql, "DELETE FROM records") || !slices.Equal(call.args, []any{"id-1"}) {
+				t.Errorf("call = %+v, want DELETE bound to id-1", call)
+			}
+		})
+	}
+}
+
+// insertColumns pulls the column list out of an INSERT statement.
+func insertColumns(sql string) []string {
+	_, rest, _ := strings.Cut(sql, "(")
+	list, _, _ := strings.Cut(rest, ")")
+	cols := strings.Split(list, ",")
+	for i := range cols {
+		cols[i] = strings.TrimSpace(cols[i])
+	}
+	return cols
+}
+
+func TestRecordStoreCreate(t *testing.T) {
+	pool := &fakePool{rows: &fakeRows{rows: [][]any{{"id-1"}}}}
+	r := Record{
+		ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", YearReleased: new(1959),
+		LabelName: new("Columbia"), CatalogNumber: new("CL 1355"), DiscogsID: new("123"),
+		DiscogsURI: new("https://discogs.com/release/123"), IsSyncedWithDiscogs: true,
+		ThumbnailURL: new("https://thumb.jpg"), CoverImageURL: new("https://cover.jpg"),
+		Genres: []string{"Jazz"}, Styles: []string{"Modal"}, UPCCode: new("0746"),
+		RecordSize: new("12\""), VinylColor: new("Black"), IsShapedVinyl: new(false),
+		Tags: []string{"favourite"},
+	}
+	id, err := (&RecordStore{pool: pool}).Create(context.Background(), r)
+	if err != nil || id != "id-1" {
+		t.Fatalf("Create = %q, %v; want id-1", id, err)
+	}
+	call := pool.calls[0]
+	if !strings.Contains(call.sql, "RETURNING record_id") {
+		t.Errorf("insert doesn't return the new record_id:\n%s", call.sql)
+	}
+	if got := insertColumns(call.sql); !slices.Equal(got, batchColumns) {
+		t.Errorf("insert columns = %v, want %v", got, batchColumns)
+	}
+	want := []any{
+		r.ArtistName, r.AlbumTitle, r.YearReleased, r.LabelName, r.CatalogNumber,
+		r.DiscogsID, r.DiscogsURI, r.IsSyncedWithDiscogs, r.ThumbnailURL, r.CoverImageURL,
+		r.Genres, r.Styles, r.UPCCode, r.RecordSize, r.VinylColor, r.IsShapedVinyl,
+		"manual", r.Tags,
+	}
+	if !reflect.DeepEqual(call.args, want) {
+		t.Errorf("args = %#v, want %#v", call.args, want)
+	}
+
+	pool = &fakePool{err: errors.New("boom")}
+	if _, err := (&RecordStore{pool: pool}).Create(context.Background(), r); err == nil || err.Error() != "insert record: boom" {
+		t.Errorf("err = %v, want wrapped insert error", err)
+	}
+}
+
+// squashSQL collapses whitespace so statements compare by their text,
+// not their indentation.
+func squashSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// statementText is the SQL a call ran, looking up prepared statements by
+// the name pgx is given in their place.
+func statementText(sql string) string {
+	if prepared, ok := preparedStatements[sql]; ok {
+		sql = prepared
+	}
+	return squashSQL(sql)
+}
+
+func selectRecords(where string) string {
+	if where != "" {
+		where = " WHERE " + where
+	}
+	return squashSQL("SELECT "+recordColumns+" FROM records") + where + " ORDER BY lower(artist_name), lower(album_title)"
+}
+
+func TestRecordStoreStatements(t *testing.T) {
+	rec := Record{
+		RecordID: "id-1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", YearReleased: new(1959),
+		LabelName: new("Columbia"), CatalogNumber: new("CL 1355"), DiscogsURI: new("https://discogs.com/release/123"),
+		ThumbnailURL: new("https://thumb.jpg"), CoverImageURL: new("https://cover.jpg"),
+		Genres: []string{"Jazz"}, Styles: []string{"Modal"}, Tags: []string{"favourite"},
+		RecordSize: new(`12"`), VinylColor: new("Black"),
+	}
+	played := time.Date(2026, 3, 2, 18, 40, 0, 0, time.UTC)
+	rows := func(vals ...[]any) *fakeRows { return &fakeRows{rows: vals} }
+	setTagsSQL := "UPDATE records SET tags = $2, updated_at = now() WHERE record_id = $1"
+	ratingSQL := "UPDATE records SET rating = $2, updated_at = now() WHERE record_id = $1"
+	genresSQL := "SELECT g, count(*) FROM records, unnest(genres) AS g GROUP BY g ORDER BY count(*) DESC, g"
+
+	tests := []struct {
+		name    string
+		rowSets []*fakeRows
+		run     func(context.Context, *RecordStore) error
+		want    []fakeCall
+	}{
+		{"List", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.List(ctx); return err },
+			[]fakeCall{{selectRecords(""), nil}}},
+		{"Search", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.Search(ctx, "50% tag:Jazz"); return err },
+			[]fakeCall{{selectRecords("(" + termClause(1) + ") AND EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = $2)"),
+				[]any{`%50\%%`, "jazz"}}}},
+		{"FilterByGenre", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.FilterByGenre(ctx, "Jazz"); return err },
+			[]fakeCall{{selectRecords("$1 = ANY(genres)"), []any{"Jazz"}}}},
+		{"ListByYearRange", nil,
+			func(ctx context.Context, s *RecordStore) error {
+				_, err := s.ListByYearRange(ctx, 1960, 1969)
+				return err
+			},
+			[]fakeCall{{selectRecords("year_released BETWEEN $1 AND $2"), []any{1960, 1969}}}},
+		{"ListByTag", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.ListByTag(ctx, "Favourite"); return err },
+			[]fakeCall{{selectRecords("EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($1))"), []any{"Favourite"}}}},
+		{"ListByLabel", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.ListByLabel(ctx, ""); return err },
+			[]fakeCall{{selectRecords("COALESCE(label_name, '') = $1"), []any{""}}}},
+		{"ListUnsyncedDiscogsRecords", nil,
+			func(ctx context.Context, s *RecordStore) error {
+				_, err := s.ListUnsyncedDiscogsRecords(ctx)
+				return err
+			},
+			[]fakeCall{{selectRecords("discogs_id IS NOT NULL AND is_synced_with_discogs = false"), nil}}},
+		{"Delete", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.Delete(ctx, "id-1") },
+			[]fakeCall{{"DELETE FROM records WHERE record_id = $1", []any{"id-1"}}}},
+		{"DeleteBatch", []*fakeRows{rows([]any{"id-1"})},
+			func(ctx context.Context, s *RecordStore) error {
+				_, err := s.DeleteBatch(ctx, []string{"id-1", "id-2"})
+				return err
+			},
+			[]fakeCall{{"DELETE FROM records WHERE record_id = ANY($1::uuid[]) RETURNING record_id", []any{[]string{"id-1", "id-2"}}}}},
+		{"Update", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.Update(ctx, rec) },
+			[]fakeCall{{"UPDATE records SET artist_name = $2, album_title = $3, year_released = $4, label_name = $5," +
+				" catalog_number = $6, genres = $7, tags = $8, record_size = $9, vinyl_color = $10, updated_at = now()" +
+				" WHERE record_id = $1",
+				[]any{rec.RecordID, rec.ArtistName, rec.AlbumTitle, rec.YearReleased, rec.LabelName,
+					rec.CatalogNumber, rec.Genres, rec.Tags, rec.RecordSize, rec.VinylColor}}}},
+		{"UpdateField", nil,
+			func(ctx context.Context, s *RecordStore) error {
+				return s.UpdateField(ctx, "id-1", "label_name", " Sire ")
+			},
+			[]fakeCall{{`UPDATE records SET "label_name" = $2, updated_at = now() WHERE record_id = $1`, []any{"id-1", new("Sire")}}}},
+		{"UpdateFromDiscogs", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.UpdateFromDiscogs(ctx, rec) },
+			[]fakeCall{{"UPDATE records SET year_released = $2, label_name = $3, catalog_number = $4, genres = $5," +
+				" styles = $6, discogs_uri = $7, thumbnail_url = $8, cover_image_url = $9," +
+				" is_synced_with_discogs = true, updated_at = now() WHERE record_id = $1",
+				[]any{rec.RecordID, rec.YearReleased, rec.LabelName, rec.CatalogNumber, rec.Genres,
+					rec.Styles, rec.DiscogsURI, rec.ThumbnailURL, rec.CoverImageURL}}}},
+		{"SetTags", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.SetTags(ctx, "id-1", []string{"a", "b"}) },
+			[]fakeCall{{setTagsSQL, []any{"id-1", []string{"a", "b"}}}}},
+		{"AddTag", []*fakeRows{rows([]any{[]string{"favourite", "Jazz"}})},
+			func(ctx context.Context, s *RecordStore) error { _, err := s.AddTag(ctx, "id-1", " Jazz "); return err },
+			[]fakeCall{{"UPDATE records SET tags = array_append(coalesce(tags, '{}'), $2), updated_at = now()" +
+				" WHERE record_id = $1 AND NOT EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($2))" +
+				" RETURNING tags", []any{"id-1", "Jazz"}}}},
+		{"RemoveTag unchanged", []*fakeRows{rows(), rows([]any{[]string{"favourite"}})},
+			func(ctx context.Context, s *RecordStore) error {
+				_, err := s.RemoveTag(ctx, "id-1", "jazz")
+				return err
+			},
+			[]fakeCall{
+				{"UPDATE records SET tags = ARRAY(SELECT t FROM unnest(tags) AS t WHERE LOWER(t) <> LOWER($2)), updated_at = now()" +
+					" WHERE record_id = $1 AND EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($2))" +
+					" RETURNING tags", []any{"id-1", "jazz"}},
+				{"SELECT tags FROM records WHERE record_id = $1", []any{"id-1"}},
+			}},
+		{"SetNotes", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.SetNotes(ctx, "id-1", " first pressing ") },
+			[]fakeCall{{"UPDATE records SET notes = NULLIF($2, ''), updated_at = now() WHERE record_id = $1", []any{"id-1", "first pressing"}}}},
+		{"SetRating", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.SetRating(ctx, "id-1", 4) },
+			[]fakeCall{{ratingSQL, []any{"id-1", new(4)}}}},
+		{"ClearRating", nil,
+			func(ctx context.Context, s *RecordStore) error { return s.ClearRating(ctx, "id-1") },
+			[]fakeCall{{ratingSQL, []any{"id-1", (*int)(nil)}}}},
+		{"RecordPlay", []*fakeRows{rows([]any{3, played})},
+			func(ctx context.Context, s *RecordStore) error { _, err := s.RecordPlay(ctx, "id-1"); return err },
+			[]fakeCall{{"UPDATE records SET play_count = play_count + 1, last_played_at = now()" +
+				" WHERE record_id = $1 RETURNING play_count, last_played_at", []any{"id-1"}}}},
+		{"ListDiscogsIDs", []*fakeRows{rows([]any{"123"})},
+			func(ctx context.Context, s *RecordStore) error { _, err := s.ListDiscogsIDs(ctx); return err },
+			[]fakeCall{{"SELECT discogs_id FROM records WHERE discogs_id IS NOT NULL", nil}}},
+		{"MarkSyncedWithDiscogs", nil,
+			func(ctx context.Context, s *RecordStore) error {
+				return s.MarkSyncedWithDiscogs(ctx, []string{"1", "2"})
+			},
+			[]fakeCall{{"UPDATE records SET is_synced_with_discogs = true WHERE discogs_id = ANY(ARRAY[$1,$2])", []any{"1", "2"}}}},
+		{"Total", []*fakeRows{rows([]any{7})},
+			func(ctx context.Context, s *RecordStore) error { _, err := s.Total(ctx); return err },
+			[]fakeCall{{"SELECT count(*) FROM records", nil}}},
+		{"Stats", []*fakeRows{rows([]any{7, 3}), rows([]any{"1950s", 1}), rows([]any{"Columbia", 1}), rows([]any{"Jazz", 2})},
+			func(ctx context.Context, s *RecordStore) error { _, err := s.Stats(ctx); return err },
+			[]fakeCall{
+				{"SELECT count(*), count(*) FILTER (WHERE is_synced_with_discogs) FROM records", nil},
+				{"SELECT COALESCE((year_released / 10 * 10)::text || 's', 'Unknown'), count(*) FROM records GROUP BY 1 ORDER BY 1", nil},
+				{"SELECT label_name, count(*) FROM records WHERE COALESCE(label_name, '') <> '' GROUP BY label_name" +
+					" ORDER BY count(*) DESC, label_name LIMIT $1", []any{StatsTopN}},
+				{genresSQL + " LIMIT $1", []any{StatsTopN}},
+			}},
+		{"Labels", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.Labels(ctx); return err },
+			[]fakeCall{{"SELECT label, count(*) FROM (SELECT COALESCE(label_name, '') AS label FROM records) AS l" +
+				" GROUP BY label ORDER BY label = '', lower(label), label", nil}}},
+		{"Genres", nil,
+			func(ctx context.Context, s *RecordStore) error { _, err := s.Genres(ctx); return err },
+			[]fakeCall{{genresSQL, nil}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &fakePool{rowSets: tt.rowSets, tag: pgconn.NewCommandTag("UPDATE 1")}
+			if err := tt.run(context.Background(), &RecordStore{pool: pool}); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if len(pool.calls) != len(tt.want) {
+				t.Fatalf("ran %d statements, want %d: %+v", len(pool.calls), len(tt.want), pool.calls)
+			}
+			for i, call := range pool.calls {
+				if got := statementText(call.sql); got != squashSQL(tt.want[i].sql) {
+					t.Errorf("statement %d:\n got  %s\n want %s", i, got, squashSQL(tt.want[i].sql))
+				}
+				if (len(call.args) > 0 || len(tt.want[i].args) > 0) && !reflect.DeepEqual(call.args, tt.want[i].args) {
+					t.Errorf("statement %d args = %#v, want %#v", i, call.args, tt.want[i].args)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordStoreCreateBatchCopies(t *testing.T) {
+	pool := &fakePool{}
+	records := []Record{
+		{ArtistName: "Can", AlbumTitle: "Tago Mago", Genres: []string{"Rock"}},
+		{ArtistName: "Neu!", AlbumTitle: "Neu!", DataSource: "csv", Tags: []string{"krautrock"}},
+	}
+	ids, err := (&RecordStore{pool: pool}).CreateBatch(context.Background(), records)
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("CreateBatch = %v, %v; want two IDs", ids, err)
+	}
+	if len(pool.calls) != 1 {
+		t.Fatalf("calls = %+v, want one COPY", pool.calls)
+	}
+	call := pool.calls[0]
+	if want := `COPY "records" (record_id, ` + strings.Join(batchColumns, ", ") + ")"; call.sql != want {
+		t.Errorf("sql = %s, want %s", call.sql, want)
+	}
+	if len(call.args) != len(records) {
+		t.Fatalf("copied %d rows, want %d", len(call.args), len(records))
+	}
+	for i, r := range records {
+		row := call.args[i].([]any)
+		if id := row[0].(pgtype.UUID).String(); id != ids[i] {
+			t.Errorf("row %d record_id = %s, want the returned %s", i, id, ids[i])
+		}
+		want := []any{
+			r.ArtistName, r.AlbumTitle, r.YearReleased, r.LabelName,
+			r.CatalogNumber, r.DiscogsID, r.DiscogsURI, r.IsSyncedWithDiscogs,
+			r.ThumbnailURL, r.CoverImageURL, r.Genres, r.Styles, r.UPCCode,
+			r.RecordSize, r.VinylColor, r.IsShapedVinyl, cmp.Or(r.DataSource, "manual"), r.Tags,
+		}
+		if !reflect.DeepEqual(row[1:], want) {
+			t.Errorf("row %d = %#v, want %#v", i, row[1:], want)
+		}
+	}
+}
+
+func TestNewRecordID(t *testing.T) {
+	a, b := newRecordID().String(), newRecordID().String()
+	if a == b {
+		t.Errorf("two IDs are both %s", a)
+	}
+	for _, id := range []string{a, b} {
+		if len(id) != 36 || id[14] != '4' || !strings.ContainsAny(id[19:20], "89ab") {
+			t.Errorf("ID %s is not a version 4 UUID", id)
+		}
+	}
+}
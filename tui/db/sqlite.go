@@ -0,0 +1,633 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// IsSQLiteURL reports whether databaseURL names a SQLite database rather
+// than Postgres: a sqlite: or file: URL, or a bare path to a .db, .sqlite
+// or .sqlite3 file. Postgres URLs and key=value DSNs never match.
+func IsSQLiteURL(databaseURL string) bool {
+	s := strings.ToLower(strings.TrimSpace(databaseURL))
+	if strings.HasPrefix(s, "sqlite:") || strings.HasPrefix(s, "file:") {
+		return true
+	}
+	if strings.Contains(s, "://") || strings.Contains(s, "=") {
+		return false
+	}
+	return strings.HasSuffix(s, ".db") || strings.HasSuffix(s, ".sqlite") || strings.HasSuffix(s, ".sqlite3")
+}
+
+// sqliteDSN turns a SQLite database_url into the driver's DSN: the
+// sqlite:// prefix comes off, file: URIs and plain paths pass through, and
+// the connection waits on a locked database instead of failing at once.
+// readOnly makes the connection refuse writes.
+func sqliteDSN(databaseURL string, readOnly bool) string {
+	s := trimSQLiteScheme(databaseURL)
+	params := "_pragma=busy_timeout(5000)&_time_format=sqlite"
+	if readOnly {
+		params += "&_pragma=query_only(1)"
+	}
+	if strings.Contains(s, "?") {
+		return s + "&" + params
+	}
+	return s + "?" + params
+}
+
+func trimSQLiteScheme(databaseURL string) string {
+	s := strings.TrimSpace(databaseURL)
+	for _, prefix := range []string{"sqlite://", "sqlite:"} {
+		if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			return s[len(prefix):]
+		}
+	}
+	return s
+}
+
+// sqlitePath is the file databaseURL names, "" for an in-memory database.
+func sqlitePath(databaseURL string) string {
+	s := trimSQLiteScheme(databaseURL)
+	if len(s) >= len("file:") && strings.EqualFold(s[:len("file:")], "file:") {
+		s = s[len("file:"):]
+	}
+	s, _, _ = strings.Cut(s, "?")
+	if s == ":memory:" {
+		return ""
+	}
+	return s
+}
+
+// sqliteSchema mirrors the Postgres records table. Arrays are stored as
+// JSON text and booleans as 0/1; record_id is a UUID made by the store.
+const sqliteSchema = `
+	CREATE TABLE IF NOT EXISTS records (
+		record_id              TEXT PRIMARY KEY NOT NULL,
+		artist_name            TEXT NOT NULL,
+		album_title            TEXT NOT NULL,
+		year_released          INTEGER,
+		label_name             TEXT,
+		catalog_number         TEXT,
+		discogs_id             TEXT UNIQUE,
+		discogs_uri            TEXT,
+		is_synced_with_discogs BOOLEAN NOT NULL DEFAULT 0,
+		thumbnail_url          TEXT,
+		cover_image_url        TEXT,
+		genres                 TEXT,
+		styles                 TEXT,
+		upc_code               TEXT,
+		record_size            TEXT,
+		vinyl_color            TEXT,
+		is_shaped_vinyl        BOOLEAN DEFAULT 0,
+		data_source            TEXT NOT NULL DEFAULT 'discogs',
+		tags                   TEXT,
+		notes                  TEXT,
+		rating                 INTEGER,
+		play_count             INTEGER NOT NULL DEFAULT 0,
+		last_played_at         TIMESTAMP,
+		created_at             TIMESTAMP NOT NULL,
+		updated_at             TIMESTAMP NOT NULL
+	)`
+
+// SQLite's lower() only folds ASCII; casefold lowers like strings.ToLower
+// so searches, tag matches and ordering agree with Postgres and Matches.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("casefold", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			switch v := args[0].(type) {
+			case string:
+				return strings.ToLower(v), nil
+			case []byte:
+				return strings.ToLower(string(v)), nil
+			}
+			return args[0], nil
+		})
+}
+
+// SQLiteStore is a Store kept in a SQLite file, for a collection that
+// doesn't need a Postgres server. Queries follow the RecordStore SQL.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens the SQLite database databaseURL names, creating the
+// file, its directory and the records table on first run. readOnly opens it so that
+// writes are refused.
+func OpenSQLite(ctx context.Context, databaseURL string, readOnly bool) (*SQLiteStore, error) {
+	if path := sqlitePath(databaseURL); path != "" && !readOnly {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("open sqlite: %w", err)
+		}
+	}
+	db, err := sql.Open("sqlite", sqliteDSN(databaseURL, readOnly))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	// One connection: SQLite takes a single writer anyway, and an
+	// in-memory database lives only as long as its connection.
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxIdleTime(0)
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create sqlite schema: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// jsonArray scans a JSON array column into a []string; NULL and [] both
+// come out nil, like textArray.
+type jsonArray struct {
+	dst *[]string
+}
+
+func (a jsonArray) Scan(src any) error {
+	*a.dst = nil
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("scan array: unexpected %T", src)
+	}
+	var out []string
+	if err := json.Unmarshal(data, &out); err != nil {
+		return fmt.Errorf("scan array: %w", err)
+	}
+	if len(out) > 0 {
+		*a.dst = out
+	}
+	return nil
+}
+
+// sqliteArray is the column value for v: a JSON array, or NULL when empty.
+func sqliteArray(v []string) any {
+	if len(v) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// sqliteTargets returns pointers to r's fields in recordColumns order.
+func (r *Record) sqliteTargets() []any {
+	return []any{
+		&r.RecordID, &r.ArtistName, &r.AlbumTitle, &r.YearReleased,
+		&r.LabelName, &r.CatalogNumber, &r.DiscogsID, &r.DiscogsURI,
+		&r.IsSyncedWithDiscogs, &r.ThumbnailURL, &r.CoverImageURL,
+		jsonArray{&r.Genres}, jsonArray{&r.Styles}, &r.UPCCode, &r.RecordSize, &r.VinylColor,
+		&r.IsShapedVinyl, &r.DataSource, jsonArray{&r.Tags}, &r.Notes, &r.Rating,
+		&r.PlayCount, &r.LastPlayedAt, &r.CreatedAt, &r.UpdatedAt,
+	}
+}
+
+// query runs a recordColumns query filtered by where, in the usual artist
+// then album order.
+func (s *SQLiteStore) query(ctx context.Context, op, where string, args ...any) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+recordColumns+`
+		FROM records
+		WHERE `+where+`
+		ORDER BY casefold(artist_name), casefold(album_title)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+	var records []Record
+	for rows.Next() {
+		var r Record
+		if err := rows.Scan(r.sqliteTargets()...); err != nil {
+			return nil, fmt.Errorf("scan record: %w", err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read records: %w", err)
+	}
+	return records, nil
+}
+
+// exec runs a single-record UPDATE or DELETE, reporting a missing record.
+func (s *SQLiteStore) exec(ctx context.Context, op, id, query string, args ...any) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return fmt.Errorf("record not found: %s", id)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) List(ctx context.Context) ([]Record, error) {
+	return s.query(ctx, "query records", "true")
+}
+
+func (s *SQLiteStore) Search(ctx context.Context, query string) ([]Record, error) {
+	where, args := sqliteSearchWhere(ParseSearchQuery(query))
+	return s.query(ctx, "search records", where, args...)
+}
+
+// sqliteSearchWhere is searchWhere for SQLite. instr matches a term as a
+// plain substring, so there are no LIKE wildcards to escape.
+func sqliteSearchWhere(q SearchQuery) (string, []any) {
+	var clauses []string
+	var args []any
+	for _, t := range q.Terms {
+		args = append(args, t)
+		n := len(args)
+		var ors []string
+		for _, col := range searchColumns {
+			ors = append(ors, fmt.Sprintf("instr(casefold(COALESCE(%s, '')), ?%d) > 0", col, n))
+		}
+		ors = append(ors, fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(records.genres) AS g WHERE instr(casefold(g.value), ?%d) > 0)", n))
+		clauses = append(clauses, "("+strings.Join(ors, " OR ")+")")
+	}
+	for _, tag := range q.Tags {
+		args = append(args, tag)
+		clauses = append(clauses, fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(records.tags) AS t WHERE casefold(t.value) = casefold(?%d))", len(args)))
+	}
+	if len(clauses) == 0 {
+		return "true", nil
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+func (s *SQLiteStore) FilterByGenre(ctx context.Context, genre string) ([]Record, error) {
+	return s.query(ctx, "filter records by genre",
+		`EXISTS (SELECT 1 FROM json_each(records.genres) AS g WHERE g.value = ?)`, genre)
+}
+
+func (s *SQLiteStore) ListByYearRange(ctx context.Context, from, to int) ([]Record, error) {
+	return s.query(ctx, "list records by year range", `year_released BETWEEN ? AND ?`, from, to)
+}
+
+func (s *SQLiteStore) ListByTag(ctx context.Context, tag string) ([]Record, error) {
+	return s.query(ctx, "list records by tag",
+		`EXISTS (SELECT 1 FROM json_each(records.tags) AS t WHERE casefold(t.value) = casefold(?))`, tag)
+}
+
+func (s *SQLiteStore) ListByLabel(ctx context.Context, label string) ([]Record, error) {
+	return s.query(ctx, "list records by label", `COALESCE(label_name, '') = ?`, label)
+}
+
+func (s *SQLiteStore) ListUnsyncedDiscogsRecords(ctx context.Context) ([]Record, error) {
+	return s.query(ctx, "query unsynced records", `discogs_id IS NOT NULL AND is_synced_with_discogs = 0`)
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	return s.exec(ctx, "delete record", id, `DELETE FROM records WHERE record_id = ?`, id)
+}
+
+func (s *SQLiteStore) DeleteBatch(ctx context.Context, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`DELETE FROM records WHERE record_id IN (SELECT value FROM json_each(?)) RETURNING record_id`,
+		sqliteArray(ids))
+	if err != nil {
+		return nil, fmt.Errorf("delete records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var deleted []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("delete records: %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("delete records: %w", err)
+	}
+	return deleted, nil
+}
+
+// sqliteInsert takes record_id, the batchColumns and the timestamps.
+var sqliteInsert = `INSERT INTO records (record_id, ` + strings.Join(batchColumns, ", ") +
+	`, created_at, updated_at) VALUES (?` + strings.Repeat(", ?", len(batchColumns)+2) + `)`
+
+// insertArgs are sqliteInsert's values for r, which gets id.
+func insertArgs(id string, r Record, now time.Time) []any {
+	return []any{
+		id, r.ArtistName, r.AlbumTitle, r.YearReleased, r.LabelName,
+		r.CatalogNumber, r.DiscogsID, r.DiscogsURI, r.IsSyncedWithDiscogs,
+		r.ThumbnailURL, r.CoverImageURL, sqliteArray(r.Genres), sqliteArray(r.Styles), r.UPCCode,
+		r.RecordSize, r.VinylColor, r.IsShapedVinyl, cmp.Or(r.DataSource, "manual"), sqliteArray(r.Tags),
+		now, now,
+	}
+}
+
+func (s *SQLiteStore) Create(ctx context.Context, r Record) (string, error) {
+	id := newRecordID().String()
+	if _, err := s.db.ExecContext(ctx, sqliteInsert, insertArgs(id, r, time.Now().UTC())...); err != nil {
+		return "", fmt.Errorf("insert record: %w", err)
+	}
+	return id, nil
+}
+
+// CreateBatch inserts records in one transaction, so either every row
+// lands or none do.
+func (s *SQLiteStore) CreateBatch(ctx context.Context, records []Record) ([]string, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin batch: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = newRecordID().String()
+		if _, err := tx.ExecContext(ctx, sqliteInsert, insertArgs(ids[i], r, now)...); err != nil {
+			return nil, fmt.Errorf("insert records: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit batch: %w", err)
+	}
+	return ids, nil
+}
+
+func (s *SQLiteStore) Update(ctx context.Context, r Record) error {
+	return s.exec(ctx, "update record", r.RecordID, `
+		UPDATE records SET
+			artist_name = ?, album_title = ?, year_released = ?, label_name = ?,
+			catalog_number = ?, genres = ?, tags = ?, record_size = ?, vinyl_color = ?,
+			updated_at = ?
+		WHERE record_id = ?`,
+		r.ArtistName, r.AlbumTitle, r.YearReleased, r.LabelName,
+		r.CatalogNumber, sqliteArray(r.Genres), sqliteArray(r.Tags), r.RecordSize, r.VinylColor,
+		time.Now().UTC(), r.RecordID)
+}
+
+func (s *SQLiteStore) UpdateField(ctx context.Context, id, column, value string) error {
+	r, err := Record{}.WithField(column, value)
+	if err != nil {
+		return err
+	}
+	// column is on the editableColumns allowlist, so it's safe to splice.
+	return s.exec(ctx, "update "+column, id,
+		`UPDATE records SET `+column+` = ?, updated_at = ? WHERE record_id = ?`,
+		editableColumns[column].value(r), time.Now().UTC(), id)
+}
+
+func (s *SQLiteStore) UpdateFromDiscogs(ctx context.Context, r Record) error {
+	return s.exec(ctx, "update record from discogs", r.RecordID, `
+		UPDATE records SET
+			year_released = ?, label_name = ?, catalog_number = ?, genres = ?, styles = ?,
+			discogs_uri = ?, thumbnail_url = ?, cover_image_url = ?,
+			is_synced_with_discogs = 1, updated_at = ?
+		WHERE record_id = ?`,
+		r.YearReleased, r.LabelName, r.CatalogNumber, sqliteArray(r.Genres), sqliteArray(r.Styles),
+		r.DiscogsURI, r.ThumbnailURL, r.CoverImageURL,
+		time.Now().UTC(), r.RecordID)
+}
+
+func (s *SQLiteStore) SetTags(ctx context.Context, id string, tags []string) error {
+	return s.exec(ctx, "set tags", id,
+		`UPDATE records SET tags = ?, updated_at = ? WHERE record_id = ?`,
+		sqliteArray(tags), time.Now().UTC(), id)
+}
+
+func (s *SQLiteStore) AddTag(ctx context.Context, id string, tag string) ([]string, error) {
+	return s.updateTags(ctx, "add tag", id, tag, addTag)
+}
+
+func (s *SQLiteStore) RemoveTag(ctx context.Context, id string, tag string) ([]string, error) {
+	return s.updateTags(ctx, "remove tag", id, tag, removeTag)
+}
+
+// updateTags reads the record's tags, applies change and writes them back
+// when it touched them, all in one transaction. It returns the tags
+// afterwards.
+func (s *SQLiteStore) updateTags(ctx context.Context, op, id, tag string, change func(*Record, string) bool) ([]string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("%s: tag is empty", op)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var r Record
+	err = tx.QueryRowContext(ctx, `SELECT tags FROM records WHERE record_id = ?`, id).Scan(jsonArray{&r.Tags})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("record not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if change(&r, tag) {
+		if _, err := tx.ExecContext(ctx, `UPDATE records SET tags = ?, updated_at = ? WHERE record_id = ?`,
+			sqliteArray(r.Tags), time.Now().UTC(), id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(r.Tags) == 0 {
+		return nil, nil
+	}
+	return r.Tags, nil
+}
+
+func (s *SQLiteStore) SetNotes(ctx context.Context, id string, notes string) error {
+	return s.exec(ctx, "set notes", id,
+		`UPDATE records SET notes = NULLIF(?, ''), updated_at = ? WHERE record_id = ?`,
+		strings.TrimSpace(notes), time.Now().UTC(), id)
+}
+
+func (s *SQLiteStore) SetRating(ctx context.Context, id string, rating int) error {
+	if !ValidRating(rating) {
+		return ValidationErrors{{FieldRating, ratingProblem}}
+	}
+	return s.updateRating(ctx, id, &rating)
+}
+
+func (s *SQLiteStore) ClearRating(ctx context.Context, id string) error {
+	return s.updateRating(ctx, id, nil)
+}
+
+func (s *SQLiteStore) updateRating(ctx context.Context, id string, rating *int) error {
+	return s.exec(ctx, "set rating", id,
+		`UPDATE records SET rating = ?, updated_at = ? WHERE record_id = ?`,
+		rating, time.Now().UTC(), id)
+}
+
+// RecordPlay counts one more play and stamps it as played now, leaving
+// updated_at alone like RecordStore does.
+func (s *SQLiteStore) RecordPlay(ctx context.Context, id string) (Play, error) {
+	p := Play{At: time.Now().UTC()}
+	err := s.db.QueryRowContext(ctx,
+		`UPDATE records SET play_count = play_count + 1, last_played_at = ?
+		WHERE record_id = ? RETURNING play_count`,
+		p.At, id,
+	).Scan(&p.Count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Play{}, fmt.Errorf("record not found: %s", id)
+	}
+	if err != nil {
+		return Play{}, fmt.Errorf("record play: %w", err)
+	}
+	return p, nil
+}
+
+func (s *SQLiteStore) ListDiscogsIDs(ctx context.Context) (map[string]struct{}, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT discogs_id FROM records WHERE discogs_id IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("query discogs ids: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ids := make(map[string]struct{})
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan discogs id: %w", err)
+		}
+		ids[id] = struct{}{}
+	}
+	return ids, rows.Err()
+}
+
+func (s *SQLiteStore) MarkSyncedWithDiscogs(ctx context.Context, discogsIDs []string) error {
+	if len(discogsIDs) == 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE records SET is_synced_with_discogs = 1 WHERE discogs_id IN (SELECT value FROM json_each(?))`,
+		sqliteArray(discogsIDs))
+	if err != nil {
+		return fmt.Errorf("mark synced: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Total(ctx context.Context) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM records`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count records: %w", err)
+	}
+	return n, nil
+}
+
+func (s *SQLiteStore) Stats(ctx context.Context) (Stats, error) {
+	var st Stats
+	err := s.db.QueryRowContext(ctx,
+		`SELECT count(*), count(*) FILTER (WHERE is_synced_with_discogs) FROM records`,
+	).Scan(&st.Total, &st.Synced)
+	if err != nil {
+		return Stats{}, fmt.Errorf("count records: %w", err)
+	}
+
+	if st.Decades, err = s.counts(ctx, `
+		SELECT COALESCE((year_released / 10 * 10) || 's', 'Unknown'), count(*)
+		FROM records
+		GROUP BY 1
+		ORDER BY 1
+	`); err != nil {
+		return Stats{}, fmt.Errorf("count decades: %w", err)
+	}
+	if st.TopLabels, err = s.counts(ctx, `
+		SELECT label_name, count(*)
+		FROM records
+		WHERE COALESCE(label_name, '') <> ''
+		GROUP BY label_name
+		ORDER BY count(*) DESC, label_name
+		LIMIT ?
+	`, StatsTopN); err != nil {
+		return Stats{}, fmt.Errorf("count labels: %w", err)
+	}
+	if st.TopGenres, err = s.counts(ctx, `
+		SELECT g.value, count(*)
+		FROM records, json_each(records.genres) AS g
+		GROUP BY g.value
+		ORDER BY count(*) DESC, g.value
+		LIMIT ?
+	`, StatsTopN); err != nil {
+		return Stats{}, fmt.Errorf("count genres: %w", err)
+	}
+	return st, nil
+}
+
+func (s *SQLiteStore) Labels(ctx context.Context) ([]Count, error) {
+	labels, err := s.counts(ctx, `
+		SELECT label, count(*)
+		FROM (SELECT COALESCE(label_name, '') AS label FROM records)
+		GROUP BY label
+		ORDER BY label = '', casefold(label), label
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("count labels: %w", err)
+	}
+	return labels, nil
+}
+
+func (s *SQLiteStore) Genres(ctx context.Context) ([]Count, error) {
+	genres, err := s.counts(ctx, `
+		SELECT g.value, count(*)
+		FROM records, json_each(records.genres) AS g
+		GROUP BY g.value
+		ORDER BY count(*) DESC, g.value
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("count genres: %w", err)
+	}
+	return genres, nil
+}
+
+// counts runs a two-column (name, count) aggregate query.
+func (s *SQLiteStore) counts(ctx context.Context, query string, args ...any) ([]Count, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []Count
+	for rows.Next() {
+		var c Count
+		if err := rows.Scan(&c.Name, &c.N); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// recordColumns is the SELECT list every record query uses, in the order
+// scanTargets expects.
+const recordColumns = `record_id, artist_name, album_title, year_released, label_name,
+			catalog_number, discogs_id, discogs_uri, is_synced_with_discogs,
+			thumbnail_url, cover_image_url, genres, styles, upc_code,
+			record_size, vinyl_color, is_shaped_vinyl, data_source,
+			tags, notes, rating, play_count, last_played_at,
+			created_at, updated_at`
+
+// scanTargets returns pointers to r's fields in recordColumns order.
+func (r *Record) scanTargets() []any {
+	return []any{
+		&r.RecordID, &r.ArtistName, &r.AlbumTitle, &r.YearReleased,
+		&r.LabelName, &r.CatalogNumber, &r.DiscogsID, &r.DiscogsURI,
+		&r.IsSyncedWithDiscogs, &r.ThumbnailURL, &r.CoverImageURL,
+		textArray{&r.Genres}, textArray{&r.Styles}, &r.UPCCode, &r.RecordSize, &r.VinylColor,
+		&r.IsShapedVinyl, &r.DataSource, textArray{&r.Tags}, &r.Notes, &r.Rating,
+		&r.PlayCount, &r.LastPlayedAt, &r.CreatedAt, &r.UpdatedAt,
+	}
+}
+
+// textArray scans a text[] column into a []string. Scanning straight into
+// a []string fails the whole row on a NULL element, leaving the slice half
+// overwritten; here NULL elements are skipped, and a NULL array, '{}' and
+// an array of only NULLs all come out nil.
+type textArray struct {
+	dst *[]string
+}
+
+func (a textArray) SetDimensions([]pgtype.ArrayDimension) error {
+	*a.dst = nil
+	return nil
+}
+
+func (a textArray) ScanIndex(int) any { return textArrayElem(a) }
+
+func (textArray) ScanIndexType() any { return textArrayElem{} }
+
+// textArrayElem appends each non-NULL element to the array's slice; pgx
+// scans elements in order.
+type textArrayElem struct {
+	dst *[]string
+}
+
+func (e textArrayElem) ScanText(v pgtype.Text) error {
+	if v.Valid {
+		*e.dst = append(*e.dst, v.String)
+	}
+	return nil
+}
+
+// scanRecord reads one recordColumns row. It takes a pgx.Row so a
+// QueryRow result works as well as the current row of a pgx.Rows.
+func scanRecord(row pgx.Row) (Record, error) {
+	var r Record
+	if err := row.Scan(r.scanTargets()...); err != nil {
+		return Record{}, fmt.Errorf("scan record: %w", err)
+	}
+	return r, nil
+}
+
+// scanRecords reads every row of a recordColumns query and closes rows.
+func scanRecords(rows pgx.Rows) ([]Record, error) {
+	defer rows.Close()
+	var records []Record
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read records: %w", err)
+	}
+	return records, nil
+}
+
+// Names of the statements prepareStatements creates. pgx runs a prepared
+// statement when its name is passed in place of the SQL.
+const (
+	stmtList            = "list_records"
+	stmtFilterByGenre   = "filter_records_by_genre"
+	stmtListByYearRange = "list_records_by_year_range"
+	stmtListUnsynced    = "list_unsynced_records"
+	stmtListByTag       = "list_records_by_tag"
+	stmtListByLabel     = "list_records_by_label"
+)
+
+var preparedStatements = map[string]string{
+	stmtList: `
+		SELECT ` + recordColumns + `
+		FROM records
+		ORDER BY lower(artist_name), lower(album_title)
+	`,
+	stmtFilterByGenre: `
+		SELECT ` + recordColumns + `
+		FROM records
+		WHERE $1 = ANY(genres)
+		ORDER BY lower(artist_name), lower(album_title)
+	`,
+	stmtListByYearRange: `
+		SELECT ` + recordColumns + `
+		FROM records
+		WHERE year_released BETWEEN $1 AND $2
+		ORDER BY lower(artist_name), lower(album_title)
+	`,
+	stmtListUnsynced: `
+		SELECT ` + recordColumns + `
+		FROM records
+		WHERE discogs_id IS NOT NULL AND is_synced_with_discogs = false
+		ORDER BY lower(artist_name), lower(album_title)
+	`,
+	stmtListByTag: `
+		SELECT ` + recordColumns + `
+		FROM records
+		WHERE EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($1))
+		ORDER BY lower(artist_name), lower(album_title)
+	`,
+	stmtListByLabel: `
+		SELECT ` + recordColumns + `
+		FROM records
+		WHERE COALESCE(label_name, '') = $1
+		ORDER BY lower(artist_name), lower(album_title)
+	`,
+}
+
+// prepareStatements runs as the pool's AfterConnect hook, so every
+// connection has the fixed read queries parsed and planned once up front.
+func prepareStatements(ctx context.Context, conn *pgx.Conn) error {
+	for name, sql := range preparedStatements {
+		if _, err := conn.Prepare(ctx, name, sql); err != nil {
+			return fmt.Errorf("prepare %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestRecordColumnsMatchScanTargets(t *testing.T) {
+	cols := strings.Split(recordColumns, ",")
+	var r Record
+	if got := len(r.scanTargets()); got != len(cols) {
+		t.Errorf("scanTargets has %d pointers for %d columns", got, len(cols))
+	}
+}
+
+func TestPreparedStatementsSelectRecordColumns(t *testing.T) {
+	for name, sql := range preparedStatements {
+		if !strings.Contains(sql, "SELECT "+recordColumns) {
+			t.Errorf("%s should select recordColumns", name)
+		}
+	}
+}
+
+func TestPoolConfigPreparesStatements(t *testing.T) {
+	cfg, err := poolConfig("postgres://u:p@localhost/db", PoolOptions{})
+	if err != nil {
+		t.Fatalf("poolConfig: %v", err)
+	}
+	if cfg.AfterConnect == nil {
+		t.Error("pool should prepare statements on connect")
+	}
+}
+
+// fakeRow scans vals into the destinations in order, like a driver row.
+type fakeRow struct {
+	vals []any
+	err  error
+}
+
+func (f fakeRow) Scan(dest ...any) error {
+	if f.err != nil {
+		return f.err
+	}
+	if len(dest) != len(f.vals) {
+		return fmt.Errorf("%d destinations for %d values", len(dest), len(f.vals))
+	}
+	for i, v := range f.vals {
+		if a, ok := dest[i].(textArray); ok {
+			*a.dst = v.([]string)
+			continue
+		}
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+func TestScanRecord(t *testing.T) {
+	year, label, shaped, notes, rating := 1959, "Columbia", false, "First pressing", 5
+	added := time.Date(2026, 3, 2, 18, 40, 0, 0, time.UTC)
+	row := fakeRow{vals: []any{
+		"id-1", "Miles Davis", "Kind of Blue", &year,
+		&label, (*string)(nil), (*string)(nil), (*string)(nil),
+		true, (*string)(nil), (*string)(nil),
+		[]string{"Jazz"}, []string{"Modal"}, (*string)(nil), (*string)(nil), (*string)(nil),
+		&shaped, "manual", []string{"favourite"}, &notes, &rating, 3, &added, added, added,
+	}}
+	r, err := scanRecord(row)
+	if err != nil {
+		t.Fatalf("scanRecord: %v", err)
+	}
+	if r.RecordID != "id-1" || r.AlbumTitle != "Kind of Blue" || *r.YearReleased != 1959 ||
+		*r.LabelName != "Columbia" || !r.IsSyncedWithDiscogs || r.Styles[0] != "Modal" ||
+		r.DataSource != "manual" || r.Tags[0] != "favourite" || *r.Notes != "First pressing" || *r.Rating != 5 || r.PlayCount != 3 || !r.LastPlayedAt.Equal(added) || !r.UpdatedAt.Equal(added) {
+		t.Errorf("scanRecord mapped columns wrong: %+v", r)
+	}
+
+	if _, err := scanRecord(fakeRow{err: errors.New("boom")}); err == nil || !strings.Contains(err.Error(), "scan record") {
+		t.Errorf("err = %v, want wrapped scan error", err)
+	}
+}
+
+func TestTextArrayScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []*string
+		null bool
+		want []string
+	}{
+		{"null array", nil, true, nil},
+		{"empty array", []*string{}, false, nil},
+		{"populated", []*string{new("Jazz"), new("Funk / Soul")}, false, []string{"Jazz", "Funk / Soul"}},
+		{"null element", []*string{nil, new("Jazz")}, false, []string{"Jazz"}},
+		{"only nulls", []*string{nil}, false, nil},
+	}
+	m := pgtype.NewMap()
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, tt := range tests {
+			var wire []byte
+			if !tt.null {
+				var err error
+				if wire, err = m.Encode(pgtype.TextArrayOID, format, tt.src, nil); err != nil {
+					t.Fatalf("%s: encode: %v", tt.name, err)
+				}
+			}
+			// Start from the previous row's value, as a reused Record would.
+			r := Record{Genres: []string{"Rock"}}
+			if err := m.Scan(pgtype.TextArrayOID, format, wire, textArray{&r.Genres}); err != nil {
+				t.Errorf("%s (format %d): %v", tt.name, format, err)
+				continue
+			}
+			if !slices.Equal(r.Genres, tt.want) || (tt.want == nil && r.Genres != nil) {
+				t.Errorf("%s (format %d) = %#v, want %#v", tt.name, format, r.Genres, tt.want)
+			}
+			wantString := "—"
+			if len(tt.want) > 0 {
+				wantString = strings.Join(tt.want, ", ")
+			}
+			if got := r.GenresString(); got != wantString {
+				t.Errorf("%s (format %d): GenresString = %q, want %q", tt.name, format, got, wantString)
+			}
+		}
+	}
+}
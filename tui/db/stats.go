@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// StatsTopN is how many labels and genres Stats returns.
+const StatsTopN = 5
+
+// Count is one bucket of a GROUP BY: a label, genre or decade and how many
+// records fall in it.
+type Count struct {
+	Name string
+	N    int
+}
+
+// Stats summarises the collection. Decades are in chronological order with
+// records missing a year last as "Unknown"; labels and genres are the
+// StatsTopN largest, biggest first.
+type Stats struct {
+	Total     int
+	Synced    int
+	Decades   []Count
+	TopLabels []Count
+	TopGenres []Count
+}
+
+// SyncedPercent is the share of records synced with Discogs, 0 for an
+// empty collection.
+func (s Stats) SyncedPercent() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Synced) * 100 / float64(s.Total)
+}
+
+// Total counts every record in the collection, whatever the UI has loaded.
+func (s *RecordStore) Total(ctx context.Context) (int, error) {
+	var n int
+	if err := s.pool.QueryRow(ctx, `SELECT count(*) FROM records`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count records: %w", err)
+	}
+	return n, nil
+}
+
+func (s *RecordStore) Stats(ctx context.Context) (Stats, error) {
+	var st Stats
+	err := s.pool.QueryRow(ctx,
+		`SELECT count(*), count(*) FILTER (WHERE is_synced_with_discogs) FROM records`,
+	).Scan(&st.Total, &st.Synced)
+	if err != nil {
+		return Stats{}, fmt.Errorf("count records: %w", err)
+	}
+
+	if st.Decades, err = s.counts(ctx, `
+		SELECT COALESCE((year_released / 10 * 10)::text || 's', 'Unknown'), count(*)
+		FROM records
+		GROUP BY 1
+		ORDER BY 1
+	`); err != nil {
+		return Stats{}, fmt.Errorf("count decades: %w", err)
+	}
+	if st.TopLabels, err = s.counts(ctx, `
+		SELECT label_name, count(*)
+		FROM records
+		WHERE COALESCE(label_name, '') <> ''
+		GROUP BY label_name
+		ORDER BY count(*) DESC, label_name
+		LIMIT $1
+	`, StatsTopN); err != nil {
+		return Stats{}, fmt.Errorf("count labels: %w", err)
+	}
+	if st.TopGenres, err = s.counts(ctx, `
+		SELECT g, count(*)
+		FROM records, unnest(genres) AS g
+		GROUP BY g
+		ORDER BY count(*) DESC, g
+		LIMIT $1
+	`, StatsTopN); err != nil {
+		return Stats{}, fmt.Errorf("count genres: %w", err)
+	}
+	return st, nil
+}
+
+// Labels counts records per label in alphabetical order. Records with no
+// label share one bucket, last, whose Name is "".
+func (s *RecordStore) Labels(ctx context.Context) ([]Count, error) {
+	labels, err := s.counts(ctx, `
+		SELECT label, count(*)
+		FROM (SELECT COALESCE(label_name, '') AS label FROM records) AS l
+		GROUP BY label
+		ORDER BY label = '', lower(label), label
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("count labels: %w", err)
+	}
+	return labels, nil
+}
+
+// Genres counts records per genre, biggest first. A record with several
+// genres counts towards each.
+func (s *RecordStore) Genres(ctx context.Context) ([]Count, error) {
+	genres, err := s.counts(ctx, `
+		SELECT g, count(*)
+		FROM records, unnest(genres) AS g
+		GROUP BY g
+		ORDER BY count(*) DESC, g
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("count genres: %w", err)
+	}
+	return genres, nil
+}
+
+// counts runs a two-column (name, count) aggregate query.
+func (s *RecordStore) counts(ctx context.Context, query string, args ...any) ([]Count, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Count
+	for rows.Next() {
+		var c Count
+		if err := rows.Scan(&c.Name, &c.N); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
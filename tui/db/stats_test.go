@@ -0,0 +1,19 @@
+package db
+
+import "testing"
+
+func TestSyncedPercent(t *testing.T) {
+	tests := []struct {
+		stats Stats
+		want  float64
+	}{
+		{Stats{}, 0},
+		{Stats{Total: 4, Synced: 1}, 25},
+		{Stats{Total: 3, Synced: 3}, 100},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.SyncedPercent(); got != tt.want {
+			t.Errorf("SyncedPercent(%d/%d) = %v, want %v", tt.stats.Synced, tt.stats.Total, got, tt.want)
+		}
+	}
+}
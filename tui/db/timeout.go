@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQueryTimeout is returned by a TimeoutStore call that ran past its
+// deadline, wrapping the driver's error.
+var ErrQueryTimeout = errors.New("query timed out")
+
+// errStoreDeadline is the cause a TimeoutStore's own deadline cancels
+// with, telling it apart from a deadline the caller set.
+var errStoreDeadline = errors.New("store deadline")
+
+// TimeoutStore wraps a Store so every call gets its own deadline. Calls
+// are also cancelled when parent is, which lets the owner abandon whatever
+// is still in flight, say when it reconnects.
+type TimeoutStore struct {
+	inner   Store
+	parent  context.Context
+	timeout time.Duration
+}
+
+// NewTimeoutStore bounds each call on inner by timeout; timeout <= 0
+// only ties calls to parent.
+func NewTimeoutStore(parent context.Context, inner Store, timeout time.Duration) *TimeoutStore {
+	return &TimeoutStore{inner: inner, parent: parent, timeout: timeout}
+}
+
+// call runs f with ctx narrowed by the timeout and parent, and marks an
+// error from a deadline it hit with ErrQueryTimeout, keeping the driver's
+// error underneath. Only this store's own deadline is reported with its
+// duration; the caller's may have been shorter.
+func call[T any](s *TimeoutStore, ctx context.Context, f func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if s.timeout > 0 {
+		ctx, cancel = context.WithTimeoutCause(ctx, s.timeout, errStoreDeadline)
+		defer cancel()
+	}
+	stop := context.AfterFunc(s.parent, cancel)
+	defer stop()
+	v, err := f(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if errors.Is(context.Cause(ctx), errStoreDeadline) {
+			return v, fmt.Errorf("%w after %s: %w", ErrQueryTimeout, s.timeout, err)
+		}
+		return v, fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+	}
+	return v, err
+}
+
+// exec is call for methods that only return an error.
+func exec(s *TimeoutStore, ctx context.Context, f func(context.Context) error) error {
+	_, err := call(s, ctx, func(ctx context.Context) (struct{}, error) { return struct{}{}, f(ctx) })
+	return err
+}
+
+func (s *TimeoutStore) List(ctx context.Context) ([]Record, error) {
+	return call(s, ctx, s.inner.List)
+}
+
+func (s *TimeoutStore) Search(ctx context.Context, query string) ([]Record, error) {
+	return call(s, ctx, func(ctx context.Context) ([]Record, error) { return s.inner.Search(ctx, query) })
+}
+
+func (s *TimeoutStore) FilterByGenre(ctx context.Context, genre string) ([]Record, error) {
+	return call(s, ctx, func(ctx context.Context) ([]Record, error) { return s.inner.FilterByGenre(ctx, genre) })
+}
+
+func (s *TimeoutStore) ListByYearRange(ctx context.Context, from, to int) ([]Record, error) {
+	return call(s, ctx, func(ctx context.Context) ([]Record, error) { return s.inner.ListByYearRange(ctx, from, to) })
+}
+
+func (s *TimeoutStore) ListByTag(ctx context.Context, tag string) ([]Record, error) {
+	return call(s, ctx, func(ctx context.Context) ([]Record, error) { return s.inner.ListByTag(ctx, tag) })
+}
+
+func (s *TimeoutStore) Labels(ctx context.Context) ([]Count, error) {
+	return call(s, ctx, s.inner.Labels)
+}
+
+func (s *TimeoutStore) Genres(ctx context.Context) ([]Count, error) {
+	return call(s, ctx, s.inner.Genres)
+}
+
+func (s *TimeoutStore) ListByLabel(ctx context.Context, label string) ([]Record, error) {
+	return call(s, ctx, func(ctx context.Context) ([]Record, error) { return s.inner.ListByLabel(ctx, label) })
+}
+
+func (s *TimeoutStore) Delete(ctx context.Context, id string) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.Delete(ctx, id) })
+}
+
+func (s *TimeoutStore) DeleteBatch(ctx context.Context, ids []string) ([]string, error) {
+	return call(s, ctx, func(ctx context.Context) ([]string, error) { return s.inner.DeleteBatch(ctx, ids) })
+}
+
+func (s *TimeoutStore) Create(ctx context.Context, r Record) (string, error) {
+	return call(s, ctx, func(ctx context.Context) (string, error) { return s.inner.Create(ctx, r) })
+}
+
+func (s *TimeoutStore) CreateBatch(ctx context.Context, records []Record) ([]string, error) {
+	return call(s, ctx, func(ctx context.Context) ([]string, error) { return s.inner.CreateBatch(ctx, records) })
+}
+
+func (s *TimeoutStore) Update(ctx context.Context, r Record) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.Update(ctx, r) })
+}
+
+func (s *TimeoutStore) UpdateField(ctx context.Context, id, column, value string) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.UpdateField(ctx, id, column, value) })
+}
+
+func (s *TimeoutStore) UpdateFromDiscogs(ctx context.Context, r Record) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.UpdateFromDiscogs(ctx, r) })
+}
+
+func (s *TimeoutStore) Stats(ctx context.Context) (Stats, error) {
+	return call(s, ctx, s.inner.Stats)
+}
+
+func (s *TimeoutStore) Total(ctx context.Context) (int, error) {
+	return call(s, ctx, s.inner.Total)
+}
+
+func (s *TimeoutStore) Ping(ctx context.Context) error {
+	return exec(s, ctx, s.inner.Ping)
+}
+
+func (s *TimeoutStore) SetTags(ctx context.Context, id string, tags []string) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.SetTags(ctx, id, tags) })
+}
+
+func (s *TimeoutStore) AddTag(ctx context.Context, id string, tag string) ([]string, error) {
+	return call(s, ctx, func(ctx context.Context) ([]string, error) { return s.inner.AddTag(ctx, id, tag) })
+}
+
+func (s *TimeoutStore) RemoveTag(ctx context.Context, id string, tag string) ([]string, error) {
+	return call(s, ctx, func(ctx context.Context) ([]string, error) { return s.inner.RemoveTag(ctx, id, tag) })
+}
+
+func (s *TimeoutStore) SetNotes(ctx context.Context, id string, notes string) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.SetNotes(ctx, id, notes) })
+}
+
+func (s *TimeoutStore) SetRating(ctx context.Context, id string, rating int) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.SetRating(ctx, id, rating) })
+}
+
+func (s *TimeoutStore) ClearRating(ctx context.Context, id string) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.ClearRating(ctx, id) })
+}
+
+func (s *TimeoutStore) RecordPlay(ctx context.Context, id string) (Play, error) {
+	return call(s, ctx, func(ctx context.Context) (Play, error) { return s.inner.RecordPlay(ctx, id) })
+}
+
+func (s *TimeoutStore) ListDiscogsIDs(ctx context.Context) (map[string]struct{}, error) {
+	return call(s, ctx, s.inner.ListDiscogsIDs)
+}
+
+func (s *TimeoutStore) MarkSyncedWithDiscogs(ctx context.Context, discogsIDs []string) error {
+	return exec(s, ctx, func(ctx context.Context) error { return s.inner.MarkSyncedWithDiscogs(ctx, discogsIDs) })
+}
+
+func (s *TimeoutStore) ListUnsyncedDiscogsRecords(ctx context.Context) ([]Record, error) {
+	return call(s, ctx, s.inner.ListUnsyncedDiscogsRecords)
+}
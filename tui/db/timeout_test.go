@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingStore's List waits for its context to end, like a query on a
+// stalled connection; Total answers straight away.
+type blockingStore struct {
+	Store
+}
+
+func (blockingStore) List(ctx context.Context) ([]Record, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingStore) Total(_ context.Context) (int, error) { return 3, nil }
+
+func TestTimeoutStoreDeadline(t *testing.T) {
+	s := NewTimeoutStore(context.Background(), blockingStore{}, 10*time.Millisecond)
+	_, err := s.List(context.Background())
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("List err = %v, want ErrQueryTimeout", err)
+	}
+	if err.Error() != "query timed out after 10ms: context deadline exceeded" || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %q, want the timeout wrapping the driver error", err)
+	}
+	if IsConnectionError(err) {
+		t.Error("a query timeout should not read as a dropped connection")
+	}
+
+	if n, err := s.Total(context.Background()); n != 3 || err != nil {
+		t.Errorf("Total = %d, %v; fast calls should pass through", n, err)
+	}
+}
+
+func TestTimeoutStoreParentCancel(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	s := NewTimeoutStore(parent, blockingStore{}, time.Hour)
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.List(context.Background())
+		done <- err
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) || errors.Is(err, ErrQueryTimeout) {
+			t.Errorf("List err = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelling the parent should end the call")
+	}
+}
+
+func TestTimeoutStoreCallerCancel(t *testing.T) {
+	for _, timeout := range []time.Duration{0, time.Hour} {
+		s := NewTimeoutStore(context.Background(), blockingStore{}, timeout)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := s.List(ctx)
+		cancel()
+		if !errors.Is(err, ErrQueryTimeout) || !errors.Is(err, context.DeadlineExceeded) ||
+			err.Error() != "query timed out: context deadline exceeded" {
+			t.Errorf("timeout %s: List err = %v; the caller's own deadline should read as a plain timeout", timeout, err)
+		}
+	}
+}
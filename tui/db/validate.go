@@ -0,0 +1,97 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Field names used in FieldError.Field.
+const (
+	FieldArtist  = "artist"
+	FieldAlbum   = "album"
+	FieldYear    = "year"
+	FieldLabel   = "label"
+	FieldCatalog = "catalog"
+	FieldUPC     = "upc"
+	FieldSize    = "size"
+	FieldColor   = "color"
+	FieldRating  = "rating"
+)
+
+// MinYear is the earliest release year accepted; nothing was pressed on
+// disc before the phonograph.
+const MinYear = 1877
+
+// MinRating and MaxRating bound a star rating. An unrated record has no
+// rating at all rather than zero stars.
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+var ratingProblem = fmt.Sprintf("must be between %d and %d", MinRating, MaxRating)
+
+// ValidRating reports whether n is a rating SetRating accepts.
+func ValidRating(n int) bool {
+	return n >= MinRating && n <= MaxRating
+}
+
+// RecordSizes are the record_size values Validate accepts.
+var RecordSizes = []string{`7"`, `10"`, `12"`}
+
+type FieldError struct {
+	Field   string
+	Problem string
+}
+
+// ValidationErrors lists every problem found, in field order, so a form can
+// flag all bad inputs at once.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	parts := make([]string, len(v))
+	for i, e := range v {
+		parts[i] = e.Field + " " + e.Problem
+	}
+	return strings.Join(parts, "; ")
+}
+
+// For returns the problem reported for field, or "" if it is valid.
+func (v ValidationErrors) For(field string) string {
+	for _, e := range v {
+		if e.Field == field {
+			return e.Problem
+		}
+	}
+	return ""
+}
+
+// Validate checks the fields a user can type. It returns nil or a
+// ValidationErrors with one entry per bad field.
+func (r Record) Validate() error {
+	var errs ValidationErrors
+	if strings.TrimSpace(r.ArtistName) == "" {
+		errs = append(errs, FieldError{FieldArtist, "is required"})
+	}
+	if strings.TrimSpace(r.AlbumTitle) == "" {
+		errs = append(errs, FieldError{FieldAlbum, "is required"})
+	}
+	if r.YearReleased != nil {
+		maxYear := time.Now().Year() + 1
+		if y := *r.YearReleased; y < MinYear || y > maxYear {
+			errs = append(errs, FieldError{FieldYear, fmt.Sprintf("must be between %d and %d", MinYear, maxYear)})
+		}
+	}
+	if r.RecordSize != nil && !slices.Contains(RecordSizes, strings.TrimSpace(*r.RecordSize)) {
+		errs = append(errs, FieldError{FieldSize, "must be one of " + strings.Join(RecordSizes, ", ")})
+	}
+	if r.Rating != nil && !ValidRating(*r.Rating) {
+		errs = append(errs, FieldError{FieldRating, ratingProblem})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validRecord() Record {
+	year := 1959
+	size := `12"`
+	return Record{ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", YearReleased: &year, RecordSize: &size}
+}
+
+func TestValidate(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	tests := []struct {
+		name   string
+		mutate func(*Record)
+		want   []string
+	}{
+		{"valid", func(*Record) {}, nil},
+		{"optional fields unset", func(r *Record) { r.YearReleased, r.RecordSize = nil, nil }, nil},
+		{"missing artist", func(r *Record) { r.ArtistName = "" }, []string{FieldArtist}},
+		{"blank artist", func(r *Record) { r.ArtistName = "   " }, []string{FieldArtist}},
+		{"missing album", func(r *Record) { r.AlbumTitle = "\t" }, []string{FieldAlbum}},
+		{"year too early", func(r *Record) { y := MinYear - 1; r.YearReleased = &y }, []string{FieldYear}},
+		{"year at minimum", func(r *Record) { y := MinYear; r.YearReleased = &y }, nil},
+		{"year next year", func(r *Record) { r.YearReleased = &nextYear }, nil},
+		{"year too late", func(r *Record) { y := nextYear + 1; r.YearReleased = &y }, []string{FieldYear}},
+		{"zero year", func(r *Record) { y := 0; r.YearReleased = &y }, []string{FieldYear}},
+		{"seven inch", func(r *Record) { s := `7"`; r.RecordSize = &s }, nil},
+		{"ten inch padded", func(r *Record) { s := ` 10" `; r.RecordSize = &s }, nil},
+		{"unknown size", func(r *Record) { s := "LP"; r.RecordSize = &s }, []string{FieldSize}},
+		{"empty size", func(r *Record) { s := ""; r.RecordSize = &s }, []string{FieldSize}},
+		{"rating in range", func(r *Record) { r.Rating = new(MaxRating) }, nil},
+		{"zero rating", func(r *Record) { r.Rating = new(0) }, []string{FieldRating}},
+		{"rating too high", func(r *Record) { r.Rating = new(MaxRating + 1) }, []string{FieldRating}},
+		{
+			"everything wrong",
+			func(r *Record) {
+				y, s := 3000, `9"`
+				r.ArtistName, r.AlbumTitle, r.YearReleased, r.RecordSize = "", "", &y, &s
+			},
+			[]string{FieldArtist, FieldAlbum, FieldYear, FieldSize},
+		},
+		{"artist and size", func(r *Record) { s := "big"; r.ArtistName, r.RecordSize = "", &s }, []string{FieldArtist, FieldSize}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validRecord()
+			tt.mutate(&r)
+			err := r.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			verrs, ok := errors.AsType[ValidationErrors](err)
+			if !ok {
+				t.Fatalf("Validate() = %v, want ValidationErrors", err)
+			}
+			var fields []string
+			for _, e := range verrs {
+				fields = append(fields, e.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("fields = %v, want %v", fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsFor(t *testing.T) {
+	errs := ValidationErrors{{FieldArtist, "is required"}, {FieldYear, "must be between 1877 and 2027"}}
+	if got := errs.For(FieldYear); got != "must be between 1877 and 2027" {
+		t.Errorf("For(year) = %q", got)
+	}
+	if got := errs.For(FieldSize); got != "" {
+		t.Errorf("For(size) = %q, want empty", got)
+	}
+	if got := errs.Error(); got != "artist is required; year must be between 1877 and 2027" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -1,31 +1,227 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"sync"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"my-record-collection-tui/config"
 	"my-record-collection-tui/db"
+	"my-record-collection-tui/state"
 	"my-record-collection-tui/ui"
 )
 
+// errUsage is returned for a malformed command line; main exits 2 for it.
+var errUsage = errors.New("usage: records-tui import <file.csv>")
+
 func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+// run does main's work and returns instead of exiting, so its deferred
+// closes of the audit log and database always run.
+func run() error {
 	cfg := config.Load()
+	if cfg.FileErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", cfg.FileErr)
+	}
+	if cfg.DatabaseURL == "" {
+		if path, err := config.Init(); err == nil {
+			return fmt.Errorf("wrote a starter config to %s; set database.url there (or DATABASE_URL) and run again", path)
+		}
+	}
+
+	var audit io.Writer
+	if cfg.AuditLog != "" {
+		auditFile, err := db.OpenAuditLog(cfg.AuditLog)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = auditFile.Close() }()
+		audit = auditFile
+	}
+	conn := &connector{cfg: cfg, audit: audit}
+	defer conn.Close()
 
-	pool, err := db.Connect(cfg.DatabaseURL)
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if len(os.Args) != 3 {
+			return errUsage
+		}
+		if cfg.ReadOnly {
+			return errors.New("import: read_only is set in the config")
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		conn.onRetry = func(attempt int, err error, wait time.Duration) {
+			fmt.Fprintf(os.Stderr, "database not reachable (attempt %d/%d): %v; retrying in %s\n",
+				attempt, cfg.DBConnectAttempts, err, wait)
+		}
+		store, err := conn.Connect(ctx)
+		stop()
+		if err != nil {
+			return fmt.Errorf("database connection failed: %w", err)
+		}
+		if err := importCSV(store, os.Args[2]); err != nil {
+			return fmt.Errorf("import failed: %w", err)
+		}
+		return nil
+	}
+
+	statePath := state.Path()
+	st, err := state.Load(statePath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "database connection failed: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
 	}
-	defer pool.Close()
 
-	store := db.NewRecordStore(pool)
-	m := ui.NewModel(store, cfg.DiscogsUsername, cfg.DiscogsToken, cfg.DiscogsUserAgent)
+	m := ui.NewModel(nil, cfg.DiscogsUsername, cfg.DiscogsToken, cfg.DiscogsUserAgent).
+		WithConnect(conn.Connect).
+		WithQueryTimeout(cfg.DBQueryTimeout).
+		WithLastLaunch(st.LastLaunch).
+		WithSearchHistory(st.SearchHistory).
+		WithReadOnly(cfg.ReadOnly).
+		WithReverseList(cfg.ReverseList).
+		WithLineNumbers(cfg.LineNumbers).
+		WithThumbnails(cfg.ListThumbnails).
+		WithStripes(cfg.ListStripes).
+		WithTheme(cfg.Theme).
+		WithImageProtocol(cfg.ImageProtocol).
+		WithSortColumn(cfg.SortColumn).
+		WithIgnoreArticles(cfg.IgnoreArticles).
+		WithPageSize(cfg.PageSize).
+		WithColumns(cfg.ListColumns).
+		WithSearchDebounce(cfg.SearchDebounce).
+		WithImageTimeout(cfg.ImageFetchTimeout)
+	if cfg.ImageCacheMaxMB > 0 {
+		m = m.WithImageDiskCache(cfg.ImageCacheDir,
+			int64(cfg.ImageCacheMaxMB)<<20,
+			time.Duration(cfg.ImageCacheTTLDays)*24*time.Hour)
+	}
 
 	p := tea.NewProgram(m)
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(1)
+	launched := time.Now()
+	final, err := p.Run()
+	if fm, ok := final.(ui.Model); ok {
+		fmt.Print(fm.ClearGraphics())
+		st.SearchHistory = fm.SearchHistory()
+	}
+
+	st.LastLaunch = launched
+	if err := state.Save(statePath, st); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+	return nil
+}
+
+func importCSV(store db.Store, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	records, err := db.ReadCSV(f)
+	if err != nil {
+		return err
+	}
+	ids, err := store.CreateBatch(context.Background(), records)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d records from %s\n", len(ids), path)
+	return nil
+}
+
+// connector opens the database pool on demand, so the TUI can start and
+// retry while the database is down. It keeps the pool for Close. A
+// memory:// database_url uses an in-memory store seeded with sample
+// records instead, kept across reconnects, and a SQLite database_url opens
+// that file once.
+type connector struct {
+	cfg     config.Config
+	audit   io.Writer
+	onRetry func(attempt int, err error, wait time.Duration)
+
+	mu     sync.Mutex
+	pool   *pgxpool.Pool
+	memory *db.MemoryStore
+	sqlite *db.SQLiteStore
+}
+
+func (c *connector) Connect(ctx context.Context) (db.Store, error) {
+	if db.IsMemoryURL(c.cfg.DatabaseURL) {
+		c.mu.Lock()
+		if c.memory == nil {
+			c.memory = db.NewMemoryStore(db.SampleRecords())
+		}
+		memory := c.memory
+		c.mu.Unlock()
+		return c.audited(memory), nil
+	}
+	if db.IsSQLiteURL(c.cfg.DatabaseURL) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.sqlite == nil {
+			store, err := db.OpenSQLite(ctx, c.cfg.DatabaseURL, c.cfg.ReadOnly)
+			if err != nil {
+				return nil, err
+			}
+			c.sqlite = store
+		}
+		return c.audited(c.sqlite), nil
+	}
+
+	pool, err := db.ConnectRetry(ctx, c.cfg.DatabaseURL, db.Retry{
+		Attempts:  c.cfg.DBConnectAttempts,
+		BaseDelay: c.cfg.DBConnectRetryDelay,
+		OnRetry:   c.onRetry,
+	}, db.PoolOptions{
+		MaxConns:        int32(c.cfg.DBMaxConns),
+		MaxConnIdleTime: c.cfg.DBMaxConnIdleTime,
+		ConnectTimeout:  c.cfg.DBConnectTimeout,
+		ReadOnly:        c.cfg.ReadOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.pool != nil {
+		c.pool.Close()
+	}
+	c.pool = pool
+	c.mu.Unlock()
+
+	return c.audited(db.NewRecordStore(pool)), nil
+}
+
+// audited wraps store to log its mutations when an audit log is set.
+func (c *connector) audited(store db.Store) db.Store {
+	if c.audit != nil {
+		return db.NewAuditStore(store, c.audit)
+	}
+	return store
+}
+
+func (c *connector) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pool != nil {
+		c.pool.Close()
+	}
+	if c.sqlite != nil {
+		_ = c.sqlite.Close()
 	}
 }
@@ -0,0 +1,73 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	StateDir  = "myrecords"
+	StateFile = "state.json"
+)
+
+// State is small bookkeeping that survives between runs. It is not
+// configuration and is rewritten by the app on clean exit.
+type State struct {
+	LastLaunch    time.Time `json:"last_launch"`
+	SearchHistory []string  `json:"search_history,omitempty"`
+}
+
+func Path() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, StateDir, StateFile)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", StateDir, StateFile)
+}
+
+// Load returns the zero State when the file does not exist yet.
+func Load(path string) (State, error) {
+	var s State
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}, fmt.Errorf("decode state: %w", err)
+	}
+	return s, nil
+}
+
+func Save(path string, s State) error {
+	if path == "" {
+		return fmt.Errorf("no state path available")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("replace state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPathUsesXDGStateHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/custom/state")
+	want := filepath.Join("/custom/state", StateDir, StateFile)
+	if got := Path(); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestPathFallsBackToHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/home/tester")
+	want := filepath.Join("/home/tester", ".local", "state", StateDir, StateFile)
+	if got := Path(); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Load missing file: %v", err)
+	}
+	if !s.LastLaunch.IsZero() {
+		t.Errorf("LastLaunch = %v, want zero", s.LastLaunch)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", StateFile)
+	launched := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	history := []string{"miles", "tag:to-sell"}
+	if err := Save(path, State{LastLaunch: launched, SearchHistory: history}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !s.LastLaunch.Equal(launched) {
+		t.Errorf("LastLaunch = %v, want %v", s.LastLaunch, launched)
+	}
+	if !slices.Equal(s.SearchHistory, history) {
+		t.Errorf("SearchHistory = %q, want %q", s.SearchHistory, history)
+	}
+}
+
+func TestLoadCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StateFile)
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load of corrupt file should return error")
+	}
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"my-record-collection-tui/db"
+)
+
+const alphabetIndexLetters = "#ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// artistInitial returns the index-bar letter for an artist: the uppercased
+// first letter for A–Z, '#' for anything else.
+func artistInitial(name string) rune {
+	for _, r := range strings.TrimSpace(name) {
+		r = unicode.ToUpper(r)
+		if r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '#'
+	}
+	return '#'
+}
+
+// initialRows maps each initial to the first row in recs that carries it.
+// Initials come from the artist's sort key so they follow the list order.
+func initialRows(recs []db.Record, ignoreArticles bool) map[rune]int {
+	rows := make(map[rune]int)
+	for i, r := range recs {
+		initial := artistInitial(artistSortKey(r.ArtistName, ignoreArticles))
+		if _, ok := rows[initial]; !ok {
+			rows[initial] = i
+		}
+	}
+	return rows
+}
+
+// displayedRecords is m.filtered in on-screen order.
+func (m Model) displayedRecords() []db.Record {
+	out := make([]db.Record, len(m.filtered))
+	for i := range out {
+		out[i] = m.recordAt(i)
+	}
+	return out
+}
+
+// jumpToInitial moves the cursor to the next (dir > 0) or previous
+// (dir < 0) run of a different initial, in display order.
+func (m Model) jumpToInitial(dir int) Model {
+	if len(m.filtered) == 0 {
+		return m
+	}
+	target := -1
+	for _, row := range initialRows(m.displayedRecords(), m.ignoreArticles) {
+		switch {
+		case dir > 0 && row > m.cursor && (target < 0 || row < target):
+			target = row
+		case dir < 0 && row < m.cursor && row > target:
+			target = row
+		}
+	}
+	if target < 0 {
+		return m
+	}
+	m.cursor = target
+	visible := m.listVisibleRows()
+	if m.cursor < m.offset || m.cursor >= m.offset+visible {
+		m.offset = max(0, min(m.cursor, len(m.filtered)-visible))
+	}
+	return m
+}
+
+func (m Model) renderAlphabetIndex() string {
+	available := initialRows(m.filtered, m.ignoreArticles)
+	current := rune(0)
+	if rec, ok := m.selectedRecord(); ok {
+		current = artistInitial(artistSortKey(rec.ArtistName, m.ignoreArticles))
+	}
+	// Narrow terminals get the letters packed together, or no bar at all.
+	n := len(alphabetIndexLetters)
+	gap := " "
+	switch {
+	case m.width < n+1:
+		return ""
+	case m.width < 2*n+1:
+		gap = ""
+	}
+	var b strings.Builder
+	b.WriteString(" ")
+	for _, r := range alphabetIndexLetters {
+		cell := gap + string(r)
+		_, ok := available[r]
+		switch {
+		case r == current:
+			b.WriteString(m.styles.indexCurrent.Render(cell))
+		case ok:
+			b.WriteString(m.styles.indexAvailable.Render(cell))
+		default:
+			b.WriteString(m.styles.indexEmpty.Render(cell))
+		}
+	}
+	return b.String()
+}
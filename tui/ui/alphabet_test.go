@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+func TestArtistInitial(t *testing.T) {
+	tests := []struct {
+		name string
+		want rune
+	}{
+		{"Miles Davis", 'M'},
+		{"miles davis", 'M'},
+		{"  Nina Simone", 'N'},
+		{"2Pac", '#'},
+		{"Éric Serra", '#'},
+		{"", '#'},
+	}
+	for _, tt := range tests {
+		if got := artistInitial(tt.name); got != tt.want {
+			t.Errorf("artistInitial(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func alphabetRecords() []db.Record {
+	return []db.Record{
+		{RecordID: "1", ArtistName: "10cc"},
+		{RecordID: "2", ArtistName: "Abba"},
+		{RecordID: "3", ArtistName: "Aphex Twin"},
+		{RecordID: "4", ArtistName: "Coltrane"},
+		{RecordID: "5", ArtistName: "Cream"},
+		{RecordID: "6", ArtistName: "Zappa"},
+	}
+}
+
+func TestInitialRows(t *testing.T) {
+	got := initialRows(alphabetRecords(), false)
+	want := map[rune]int{'#': 0, 'A': 1, 'C': 3, 'Z': 5}
+	if len(got) != len(want) {
+		t.Fatalf("initialRows = %v, want %v", got, want)
+	}
+	for r, row := range want {
+		if got[r] != row {
+			t.Errorf("initialRows[%q] = %d, want %d", r, got[r], row)
+		}
+	}
+	if _, ok := got['B']; ok {
+		t.Error("B has no records and should not be mapped")
+	}
+}
+
+func TestInitialRowsIgnoreArticles(t *testing.T) {
+	recs := []db.Record{
+		{RecordID: "1", ArtistName: "The Beatles"},
+		{RecordID: "2", ArtistName: "Élan"},
+	}
+	if got := initialRows(recs, false); got['T'] != 0 || got['E'] != 1 {
+		t.Errorf("initialRows = %v, want T at 0 and E at 1", got)
+	}
+	if got := initialRows(recs, true); got['B'] != 0 || len(got) != 2 {
+		t.Errorf("initialRows ignoring The = %v, want B at 0", got)
+	}
+}
+
+func TestJumpToInitial(t *testing.T) {
+	m := newTestModel(alphabetRecords())
+
+	var rows []int
+	for range 4 {
+		updated, _ := m.Update(keyMsg("]"))
+		m = updated.(Model)
+		rows = append(rows, m.cursor)
+	}
+	if got := rows; got[0] != 1 || got[1] != 3 || got[2] != 5 || got[3] != 5 {
+		t.Errorf("cursor after ] presses = %v, want [1 3 5 5]", got)
+	}
+
+	m.cursor = 4
+	updated, _ := m.Update(keyMsg("["))
+	if got := updated.(Model).cursor; got != 3 {
+		t.Errorf("[ from mid-letter = %d, want start of letter 3", got)
+	}
+}
+
+func TestJumpToInitialReversed(t *testing.T) {
+	m := newTestModel(alphabetRecords()).WithReverseList(true)
+
+	updated, _ := m.Update(keyMsg("]"))
+	m = updated.(Model)
+	rec, _ := m.selectedRecord()
+	if rec.ArtistName != "Cream" {
+		t.Errorf("] on reversed list selected %q, want Cream", rec.ArtistName)
+	}
+}
+
+func TestAlphabetIndexShownInList(t *testing.T) {
+	m := newTestModel(alphabetRecords())
+	bar := ansi.Strip(m.renderAlphabetIndex())
+	if strings.Join(strings.Fields(bar), "") != alphabetIndexLetters {
+		t.Errorf("index bar = %q, want every letter of %q", bar, alphabetIndexLetters)
+	}
+	if !strings.Contains(m.renderList(), m.renderAlphabetIndex()) {
+		t.Error("list should render the index bar under the title")
+	}
+}
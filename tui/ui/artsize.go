@@ -0,0 +1,26 @@
+package ui
+
+// artSize is the detail-view cover box in terminal cells.
+type artSize struct {
+	w, h int
+}
+
+// defaultArtSize is used until the terminal reports its size.
+var defaultArtSize = artSize{w: 30, h: 15}
+
+const (
+	minArtRows = 8
+	maxArtRows = 40
+)
+
+// artSize scales the cover with the terminal: half the height or a fifth
+// of the width, whichever is smaller. Cells are about twice as tall as
+// they are wide, so the box is twice as many columns as rows to stay
+// square.
+func (m Model) artSize() artSize {
+	if m.width <= 0 || m.height <= 0 {
+		return defaultArtSize
+	}
+	rows := min(max(min(m.height/2, m.width/5), minArtRows), maxArtRows)
+	return artSize{w: rows * 2, h: rows}
+}
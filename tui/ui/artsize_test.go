@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestArtSize(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          artSize
+	}{
+		{0, 0, defaultArtSize},
+		{120, 40, artSize{w: 40, h: 20}},
+		{80, 24, artSize{w: 24, h: 12}},
+		{60, 80, artSize{w: 24, h: 12}},
+		{40, 10, artSize{w: 16, h: 8}},
+		{400, 120, artSize{w: 80, h: 40}},
+	}
+	for _, tt := range tests {
+		m := newTestModel(testRecords())
+		m.width, m.height = tt.width, tt.height
+		if got := m.artSize(); got != tt.want {
+			t.Errorf("artSize at %dx%d = %+v, want %+v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestResizeRerendersArt(t *testing.T) {
+	recs := testRecords()
+	url := "http://img/cover.png"
+	recs[0].CoverImageURL = &url
+	m := newTestModel(recs)
+	m.imgDiskCache = nil
+	m.view = detailView
+	m.imgCache.set(url, protoMosaic, m.artSize(), cachedImage{render: "big"})
+	m, _ = m.showArt()
+	if m.artRender != "big" {
+		t.Fatalf("artRender = %q, want the cached art", m.artRender)
+	}
+
+	updated, cmd := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(Model)
+	if m.artRender != "" || !m.artLoading || cmd == nil {
+		t.Errorf("resize should reload the art at the new size, got render %q loading %v", m.artRender, m.artLoading)
+	}
+
+	m.imgCache.set(url, protoMosaic, m.artSize(), cachedImage{render: "small"})
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if got := updated.(Model).artRender; got != "big" {
+		t.Errorf("artRender = %q after resizing back, want the cached big art", got)
+	}
+
+	m.view = listView
+	updated, cmd = m.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	if cmd != nil {
+		t.Error("resizing the list should not load art")
+	}
+}
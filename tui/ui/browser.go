@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"os/exec"
+	"runtime"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+type browserOpenedMsg struct {
+	url string
+	err error
+}
+
+// browserCommand returns the OS's "open this URL" command.
+func browserCommand(goos, url string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		// The empty argument is start's window title.
+		return "cmd", []string{"/c", "start", "", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// startBrowser launches the browser without waiting for it; tests replace it.
+var startBrowser = func(url string) error {
+	name, args := browserCommand(runtime.GOOS, url)
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+func openBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		return browserOpenedMsg{url: url, err: startBrowser(url)}
+	}
+}
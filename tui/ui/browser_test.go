@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	tests := []struct {
+		goos string
+		name string
+		args []string
+	}{
+		{"linux", "xdg-open", []string{"https://x"}},
+		{"freebsd", "xdg-open", []string{"https://x"}},
+		{"darwin", "open", []string{"https://x"}},
+		{"windows", "cmd", []string{"/c", "start", "", "https://x"}},
+	}
+	for _, tt := range tests {
+		name, args := browserCommand(tt.goos, "https://x")
+		if name != tt.name || !slices.Equal(args, tt.args) {
+			t.Errorf("%s: %s %q, want %s %q", tt.goos, name, args, tt.name, tt.args)
+		}
+	}
+}
+
+func TestDetailOpenDiscogs(t *testing.T) {
+	var opened []string
+	failWith := error(nil)
+	orig := startBrowser
+	startBrowser = func(url string) error {
+		opened = append(opened, url)
+		return failWith
+	}
+	t.Cleanup(func() { startBrowser = orig })
+
+	recs := testRecords()
+	recs[0].DiscogsURI = stringPointer("https://www.discogs.com/release/1")
+	m := newTestModel(recs)
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("o"))
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("o should return a command")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(opened) != 1 || opened[0] != "https://www.discogs.com/release/1" {
+		t.Fatalf("opened = %v", opened)
+	}
+	if !strings.Contains(m.renderDetail(), "Opened https://www.discogs.com/release/1") {
+		t.Error("detail view should confirm the open")
+	}
+
+	failWith = errors.New("xdg-open: not found")
+	updated, cmd = m.Update(keyMsg("o"))
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !strings.Contains(m.renderDetail(), "open browser: xdg-open: not found") {
+		t.Error("failure should show as a status line")
+	}
+
+	m.cursor = 1
+	updated, cmd = m.Update(keyMsg("o"))
+	m = updated.(Model)
+	if cmd != nil || len(opened) != 2 {
+		t.Error("a record without a URI should not launch the browser")
+	}
+	if !strings.Contains(m.renderDetail(), "No Discogs link") {
+		t.Error("detail view should say there is no link")
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"my-record-collection-tui/db"
+)
+
+// changedSince splits records into those created after since and those
+// only modified after it.
+func changedSince(records []db.Record, since time.Time) (added, updated []db.Record) {
+	for _, r := range records {
+		switch {
+		case r.CreatedAt.After(since):
+			added = append(added, r)
+		case r.UpdatedAt.After(since):
+			updated = append(updated, r)
+		}
+	}
+	return added, updated
+}
+
+func (m Model) changesLines() []string {
+	if m.lastLaunch.IsZero() {
+		return []string{"  No previous launch recorded — changes will be tracked from this session."}
+	}
+	added, updated := changedSince(m.records, m.lastLaunch)
+	if len(added) == 0 && len(updated) == 0 {
+		return []string{"  Nothing has changed since your last launch."}
+	}
+
+	var lines []string
+	section := func(heading string, recs []db.Record, stamp func(db.Record) time.Time) {
+		lines = append(lines, m.styles.label.Render(fmt.Sprintf("  %s (%d)", heading, len(recs))))
+		if len(recs) == 0 {
+			lines = append(lines, m.styles.normalRow.Render("    —"))
+		}
+		for _, r := range recs {
+			lines = append(lines, m.styles.normalRow.Render(fmt.Sprintf("    %s  %s — %s",
+				stamp(r).Local().Format("2006-01-02 15:04"), r.ArtistName, r.AlbumTitle)))
+		}
+		lines = append(lines, "")
+	}
+	section("Added", added, func(r db.Record) time.Time { return r.CreatedAt })
+	section("Updated", updated, func(r db.Record) time.Time { return r.UpdatedAt })
+	return lines
+}
+
+func (m Model) handleChangesKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "q", "esc", "w":
+		m.view = listView
+		m.changesOffset = 0
+	case "up":
+		if m.changesOffset > 0 {
+			m.changesOffset--
+		}
+	case "down":
+		if m.changesOffset < len(m.changesLines())-m.listVisibleRows() {
+			m.changesOffset++
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderChanges() string {
+	var b strings.Builder
+	title := m.styles.title.Render("♫ Changes")
+	since := "first launch"
+	if !m.lastLaunch.IsZero() {
+		since = "since " + m.lastLaunch.Local().Format("2006-01-02 15:04")
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", m.styles.statusBar.Render(since)))
+	b.WriteString("\n\n")
+
+	lines := m.changesLines()
+	visible := m.listVisibleRows()
+	start := min(m.changesOffset, max(0, len(lines)-1))
+	end := min(start+visible, len(lines))
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.helpLine(changesKeys))
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"my-record-collection-tui/db"
+)
+
+func changeRecords(since time.Time) []db.Record {
+	return []db.Record{
+		{RecordID: "1", ArtistName: "Old Artist", AlbumTitle: "Untouched", CreatedAt: since.Add(-48 * time.Hour), UpdatedAt: since.Add(-48 * time.Hour)},
+		{RecordID: "2", ArtistName: "New Artist", AlbumTitle: "Fresh", CreatedAt: since.Add(time.Hour), UpdatedAt: since.Add(time.Hour)},
+		{RecordID: "3", ArtistName: "Edited Artist", AlbumTitle: "Tweaked", CreatedAt: since.Add(-48 * time.Hour), UpdatedAt: since.Add(2 * time.Hour)},
+	}
+}
+
+func TestChangedSince(t *testing.T) {
+	since := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	added, updated := changedSince(changeRecords(since), since)
+	if len(added) != 1 || added[0].RecordID != "2" {
+		t.Errorf("added = %v, want record 2", added)
+	}
+	if len(updated) != 1 || updated[0].RecordID != "3" {
+		t.Errorf("updated = %v, want record 3", updated)
+	}
+}
+
+func TestChangesViewRendersBuckets(t *testing.T) {
+	since := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	m := newTestModel(changeRecords(since)).WithLastLaunch(since)
+
+	updated, _ := m.Update(keyMsg("w"))
+	m = updated.(Model)
+	if m.view != changesView {
+		t.Fatal("w should open the changes view")
+	}
+	body := m.View().Content
+	for _, want := range []string{"Added (1)", "Updated (1)", "Fresh", "Tweaked"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("changes view should contain %q", want)
+		}
+	}
+	if strings.Contains(body, "Untouched") {
+		t.Error("changes view should not list unchanged records")
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.view != listView {
+		t.Error("esc should return to list view")
+	}
+}
+
+func TestChangesViewFirstLaunch(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = changesView
+	if !strings.Contains(m.View().Content, "No previous launch") {
+		t.Error("changes view without a last launch should say so")
+	}
+}
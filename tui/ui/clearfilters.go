@@ -0,0 +1,34 @@
+package ui
+
+import tea "charm.land/bubbletea/v2"
+
+// filtersActive reports whether anything narrows the list.
+func (m Model) filtersActive() bool {
+	return m.search != "" || m.genreFilter != "" || m.tagFilter != "" || m.labelFilter != nil || m.yearRange.active() || m.unsyncedOnly
+}
+
+// clearFilters drops the search and every filter, back to the whole
+// collection in the current sort order. Filters the database applied
+// (search, genre, tag, label, years) need a reload; the rest is local.
+func (m Model) clearFilters() (tea.Model, tea.Cmd) {
+	if !m.filtersActive() {
+		return m, m.setStatus("No filters to clear.")
+	}
+	reload := m.recordsSearched || m.genreFilter != "" || m.tagFilter != "" || m.labelFilter != nil || m.yearRange.active()
+	m.search = ""
+	m.cancelSearch()
+	m.genreFilter = ""
+	m.tagFilter = ""
+	m.labelFilter = nil
+	m.yearRange = yearRange{}
+	m.unsyncedOnly = false
+	m.deleteConfirm = false
+	m.cursor, m.offset = 0, 0
+	status := m.setStatus("Filters cleared.")
+	if reload {
+		m.loading = true
+		return m, tea.Batch(status, m.fetchRecords(false))
+	}
+	m.applyFilters()
+	return m, status
+}
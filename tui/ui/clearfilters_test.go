@@ -0,0 +1,54 @@
+package ui
+
+import "testing"
+
+func TestClearFiltersReloads(t *testing.T) {
+	recs := genreRecords()
+	m := newTestModel(recs)
+	m.genreFilter = "Rock"
+	m.yearRange = yearRange{from: 1960, to: 1969}
+	m.search = "miles"
+	m.recordsSearched = true
+	m.unsyncedOnly = true
+	m.filtered = recs[1:2]
+	m.cursor = 0
+
+	updated, cmd := m.Update(keyMsg("c"))
+	m = updated.(Model)
+	if m.filtersActive() || !m.loading || cmd == nil {
+		t.Fatal("c should clear every filter and reload")
+	}
+	if m.status != "Filters cleared." {
+		t.Errorf("status = %q, want Filters cleared.", m.status)
+	}
+	updated, _ = m.Update(m.fetchRecords(false)())
+	m = updated.(Model)
+	if len(m.filtered) != len(recs) || m.cursor != 0 || m.offset != 0 {
+		t.Errorf("after reload: %d records, cursor %d offset %d; want all %d at the top",
+			len(m.filtered), m.cursor, m.offset, len(recs))
+	}
+}
+
+func TestClearFiltersLocalOnly(t *testing.T) {
+	recs := testRecords()
+	recs[0].IsSyncedWithDiscogs = true
+	m := newTestModel(recs)
+	m = m.toggleUnsynced()
+	m.cursor = 1
+
+	updated, _ := m.Update(keyMsg("c"))
+	m = updated.(Model)
+	if m.unsyncedOnly || m.loading || len(m.filtered) != len(recs) || m.cursor != 0 {
+		t.Errorf("c should show every record again without a reload: unsynced=%v loading=%v n=%d cursor=%d",
+			m.unsyncedOnly, m.loading, len(m.filtered), m.cursor)
+	}
+}
+
+func TestClearFiltersNothingActive(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg("c"))
+	m = updated.(Model)
+	if m.loading || m.status != "No filters to clear." {
+		t.Errorf("loading=%v status=%q, want no reload and a note", m.loading, m.status)
+	}
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"strings"
+
+	"my-record-collection-tui/db"
+)
+
+// listColumn describes one column the list can show. Columns with a fixed
+// width keep it; the rest share the remaining width by weight.
+type listColumn struct {
+	name     string
+	title    string
+	fixed    int
+	weight   int
+	sortable bool
+	sort     sortColumn
+	value    func(db.Record) string
+}
+
+var listColumns = []listColumn{
+	{name: "artist", title: "Artist", weight: 25, sortable: true, sort: sortArtist, value: func(r db.Record) string { return r.ArtistName }},
+	{name: "album", title: "Album", weight: 30, sortable: true, sort: sortAlbum, value: albumCell},
+	{name: "year", title: "Year", fixed: 6, sortable: true, sort: sortYear, value: db.Record.YearString},
+	{name: "label", title: "Label", weight: 18, sortable: true, sort: sortLabel, value: db.Record.LabelString},
+	{name: "genres", title: "Genres", weight: 18, value: db.Record.GenresString},
+	{name: "styles", title: "Styles", weight: 18, value: db.Record.StylesString},
+	{name: "size", title: "Size", fixed: 5, value: db.Record.SizeString},
+	{name: "color", title: "Color", weight: 12, value: db.Record.ColorString},
+	{name: "tags", title: "Tags", weight: 15, value: db.Record.TagsString},
+	{name: "rating", title: "Rating", fixed: 8, sortable: true, sort: sortRating, value: db.Record.RatingString},
+	{name: "plays", title: "Plays", fixed: 7, sortable: true, sort: sortPlays, value: db.Record.PlaysString},
+	{name: "catalog", title: "Catalog #", weight: 12, value: func(r db.Record) string {
+		if r.CatalogNumber != nil {
+			return *r.CatalogNumber
+		}
+		return "—"
+	}},
+}
+
+// defaultListColumns is the column set used when none is configured.
+var defaultListColumns = []string{"artist", "album", "year", "label", "genres"}
+
+// parseListColumns maps column names to columns in the given order,
+// skipping unknown names and repeats. It falls back to the defaults when
+// none of the names is usable.
+func parseListColumns(names []string) []listColumn {
+	var cols []listColumn
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+		for _, c := range listColumns {
+			if c.name == name {
+				cols = append(cols, c)
+				seen[name] = true
+				break
+			}
+		}
+	}
+	if len(cols) == 0 {
+		return parseListColumns(defaultListColumns)
+	}
+	return cols
+}
+
+// WithColumns sets which columns the list shows, in order, by name
+// ("artist", "album", "year", "label", "genres", "styles", "size",
+// "color", "tags", "rating", "plays", "catalog"). Unknown names are
+// ignored; an empty or unusable list keeps the default columns.
+func (m Model) WithColumns(names []string) Model {
+	if len(names) > 0 {
+		m.columns = parseListColumns(names)
+	}
+	return m
+}
+
+// Below these widths the list drops to artist and album, then to a single
+// "Artist — Album" column, rather than squeezing every column into a
+// couple of cells.
+const (
+	compactListWidth = 60
+	minimalListWidth = 36
+)
+
+// artistAlbumColumn is the only column of the minimal layout.
+var artistAlbumColumn = listColumn{
+	name: "artist_album", title: "Artist — Album", weight: 1, sortable: true, sort: sortArtist,
+	value: func(r db.Record) string { return r.ArtistName + " — " + albumCell(r) },
+}
+
+// rowWidth is the width left for columns after the row's leading cells.
+func (m Model) rowWidth() int {
+	return m.width - m.thumbnailWidth() - m.selectionWidth() - m.lineNumberWidth()
+}
+
+// shownColumns is the configured column set, or a compact one when the
+// terminal is too narrow for it.
+func (m Model) shownColumns() []listColumn {
+	switch w := m.rowWidth(); {
+	case w < minimalListWidth:
+		return []listColumn{artistAlbumColumn}
+	case w < compactListWidth:
+		return parseListColumns([]string{"artist", "album"})
+	}
+	return m.columns
+}
+
+// columnWidths sizes the shown columns: fixed columns keep their width
+// and the others split what is left by weight.
+func (m Model) columnWidths() []int {
+	cols := m.shownColumns()
+	w := max(m.rowWidth()-len(cols), 0)
+	flex, weights := w, 0
+	for _, c := range cols {
+		flex -= c.fixed
+		weights += c.weight
+	}
+	widths := make([]int, len(cols))
+	for i, c := range cols {
+		if c.fixed > 0 {
+			widths[i] = c.fixed
+		} else {
+			widths[i] = max(flex, 0) * c.weight / weights
+		}
+	}
+	return widths
+}
+
+func (m Model) columnHeader(c listColumn) string {
+	if c.sortable {
+		return m.headerLabel(c.title, c.sort)
+	}
+	return c.title
+}
+
+// listRow renders rec's cells for the visible columns.
+func (m Model) listRow(rec db.Record, widths []int) string {
+	cols := m.shownColumns()
+	cells := make([]string, len(cols))
+	for i, c := range cols {
+		cells[i] = truncPad(c.value(rec), widths[i])
+	}
+	return strings.Join(cells, " ")
+}
+
+func (m Model) listHeader(widths []int) string {
+	cols := m.shownColumns()
+	cells := make([]string, len(cols))
+	for i, c := range cols {
+		cells[i] = truncPad(m.columnHeader(c), widths[i])
+	}
+	return strings.Join(cells, " ")
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+func columnNames(cols []listColumn) string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestParseListColumns(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want string
+	}{
+		{nil, "artist,album,year,label,genres"},
+		{[]string{"Album", " artist ", "size"}, "album,artist,size"},
+		{[]string{"styles", "bogus", "styles"}, "styles"},
+		{[]string{"bogus"}, "artist,album,year,label,genres"},
+	}
+	for _, tt := range tests {
+		if got := columnNames(parseListColumns(tt.in)); got != tt.want {
+			t.Errorf("parseListColumns(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestColumnWidthsFillSelectedSet(t *testing.T) {
+	m := newTestModel(testRecords()).WithColumns([]string{"artist", "year", "size", "styles"})
+	m.width = 100
+	widths := m.columnWidths()
+	if len(widths) != 4 {
+		t.Fatalf("widths = %v, want 4 columns", widths)
+	}
+	if widths[1] != 6 || widths[2] != 5 {
+		t.Errorf("fixed widths = %d, %d; want 6, 5", widths[1], widths[2])
+	}
+	total := len(widths)
+	for _, w := range widths {
+		total += w
+	}
+	if total > m.width {
+		t.Errorf("row width %d exceeds terminal width %d", total, m.width)
+	}
+}
+
+func TestListShowsConfiguredColumns(t *testing.T) {
+	recs := testRecords()
+	recs[0].Styles = []string{"Modal"}
+	recs[0].RecordSize = new(`12"`)
+	m := newTestModel(recs).WithColumns([]string{"album", "styles", "size"})
+
+	list := m.renderList()
+	for _, want := range []string{"Album", "Styles", "Size", "Modal", `12"`} {
+		if !strings.Contains(list, want) {
+			t.Errorf("list missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Genres", "Label", "Miles Davis"} {
+		if strings.Contains(list, unwanted) {
+			t.Errorf("list should not show %q", unwanted)
+		}
+	}
+}
+
+func TestHighlightedRowFollowsColumns(t *testing.T) {
+	m := newTestModel(testRecords()).WithColumns([]string{"year", "album"})
+	rec := db.Record{RecordID: "1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", YearReleased: new(1959)}
+	row := m.highlightedRow(0, rec, []int{12}, m.columnWidths(), m.styles.normalRow)
+	if !strings.Contains(row, "1959") || strings.Contains(row, "Miles") {
+		t.Errorf("row = %q, want year and album only", row)
+	}
+}
+
+func TestShownColumnsNarrow(t *testing.T) {
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{120, "artist,album,year,label,genres"},
+		{compactListWidth, "artist,album,year,label,genres"},
+		{compactListWidth - 1, "artist,album"},
+		{minimalListWidth, "artist,album"},
+		{minimalListWidth - 1, "artist_album"},
+		{5, "artist_album"},
+	}
+	for _, tt := range tests {
+		m := newTestModel(testRecords())
+		m.width = tt.width
+		if got := columnNames(m.shownColumns()); got != tt.want {
+			t.Errorf("width %d: shownColumns = %s, want %s", tt.width, got, tt.want)
+		}
+	}
+
+	m := newTestModel(testRecords()).WithLineNumbers(true)
+	m.width = compactListWidth
+	if got := columnNames(m.shownColumns()); got != "artist,album" {
+		t.Errorf("line numbers should count against the width: got %s", got)
+	}
+}
+
+func TestNarrowListFitsWidth(t *testing.T) {
+	for _, width := range []int{20, 30, 40, 50, 59, 60, 80} {
+		m := newTestModel(testRecords())
+		m.width = width
+		for _, line := range strings.Split(m.renderList(), "\n") {
+			if w := ansi.StringWidth(line); w > width {
+				t.Errorf("width %d: line is %d wide: %q", width, w, ansi.Strip(line))
+			}
+		}
+	}
+
+	m := newTestModel(testRecords())
+	m.width = 30
+	if list := m.renderList(); !strings.Contains(list, "Miles Davis — Kind of Blue") {
+		t.Errorf("minimal layout should combine artist and album:\n%s", list)
+	}
+}
+
+func TestHighlightedRowCombinedColumn(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.width = 30
+	rec := db.Record{RecordID: "1", ArtistName: "Miles", AlbumTitle: "Kind"}
+	// "miles kind": M at 0, K at 6.
+	row := m.highlightedRow(0, rec, []int{0, 6}, m.columnWidths(), m.styles.normalRow)
+	hl := m.styles.match.Inherit(m.styles.normalRow)
+	for _, want := range []string{hl.Render("M"), hl.Render("K")} {
+		if !strings.Contains(row, want) {
+			t.Errorf("row %q should highlight %q", row, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// commandHelp lists what the : prompt understands; it is shown for an
+// unknown command.
+const commandHelp = "commands: sort <column> [asc|desc], filter genre=<name>|tag=<name>|year=<range>|unsynced|off, tag <name>, export json|csv [path], q"
+
+func (m Model) handleCommandKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.commandMode = false
+		return m, nil
+	case "enter":
+		m.commandMode = false
+		line := strings.TrimSpace(m.commandInput)
+		m.commandInput = ""
+		if line == "" {
+			return m, nil
+		}
+		return m.runCommand(line)
+	case "backspace":
+		runes := []rune(m.commandInput)
+		if len(runes) == 0 {
+			m.commandMode = false
+			return m, nil
+		}
+		m.commandInput = string(runes[:len(runes)-1])
+		return m, nil
+	default:
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.commandInput) < maxSearchRunes {
+			m.commandInput += string(r)
+		}
+		return m, nil
+	}
+}
+
+// runCommand parses and dispatches one command line. Problems land in
+// commandErr, shown where status messages go.
+func (m Model) runCommand(line string) (tea.Model, tea.Cmd) {
+	name, args, _ := strings.Cut(line, " ")
+	args = strings.TrimSpace(args)
+	switch strings.ToLower(name) {
+	case "q", "quit":
+		return m.quit()
+	case "sort":
+		return m.sortCommand(args)
+	case "filter":
+		return m.filterCommand(args)
+	case "tag":
+		return m.tagCommand(args)
+	case "export":
+		return m.exportCommand(args)
+	}
+	m.commandErr = fmt.Sprintf("unknown command %q — %s", name, commandHelp)
+	return m, nil
+}
+
+// sortCommand handles "sort year", "sort artist desc".
+func (m Model) sortCommand(args string) (tea.Model, tea.Cmd) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		m.commandErr = "usage: sort artist|album|year|label|rating|plays [asc|desc]"
+		return m, nil
+	}
+	col, ok := parseSortColumn(fields[0])
+	if !ok {
+		m.commandErr = fmt.Sprintf("unknown sort column %q", fields[0])
+		return m, nil
+	}
+	desc := false
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+		case "desc":
+			desc = true
+		default:
+			m.commandErr = fmt.Sprintf("sort order must be asc or desc, not %q", fields[1])
+			return m, nil
+		}
+	}
+	m.sortCol, m.sortDesc = col, desc
+	m.applyFilters()
+	m.cursor, m.offset = 0, 0
+	return m, nil
+}
+
+// filterCommand handles "filter genre=Jazz", "filter tag=Wishlist",
+// "filter year=1970-1979", "filter unsynced" and "filter off". Genre and
+// tag values run to the end of the line so names like "Funk / Soul" work
+// unquoted.
+func (m Model) filterCommand(args string) (tea.Model, tea.Cmd) {
+	key, value, _ := strings.Cut(args, "=")
+	key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+	switch key {
+	case "genre":
+		if value == "" {
+			m.commandErr = "usage: filter genre=<name>"
+			return m, nil
+		}
+		m.genreFilter = value
+	case "tag":
+		if value == "" {
+			m.commandErr = "usage: filter tag=<name>"
+			return m, nil
+		}
+		m.tagFilter = value
+	case "year":
+		r, err := parseYearRange(value)
+		if err != nil {
+			m.commandErr = "filter year: " + err.Error()
+			return m, nil
+		}
+		m.yearRange = r
+	case "unsynced":
+		if !m.unsyncedOnly {
+			m = m.toggleUnsynced()
+		}
+		return m, nil
+	case "off", "none", "clear":
+		m.genreFilter = ""
+		m.tagFilter = ""
+		m.labelFilter = nil
+		m.yearRange = yearRange{}
+		if m.unsyncedOnly {
+			m = m.toggleUnsynced()
+		}
+	default:
+		m.commandErr = "usage: filter genre=<name> | tag=<name> | year=<range> | unsynced | off"
+		return m, nil
+	}
+	m.loading = true
+	return m, m.fetchRecords(false)
+}
+
+// exportCommand handles "export json [path]" and "export csv [path]",
+// writing the list as shown. Without a path the default under $HOME is
+// used.
+func (m Model) exportCommand(args string) (tea.Model, tea.Cmd) {
+	format, path, _ := strings.Cut(args, " ")
+	path = strings.TrimSpace(path)
+	if len(m.filtered) == 0 {
+		m.commandErr = "export: nothing to export"
+		return m, nil
+	}
+	switch strings.ToLower(format) {
+	case "json":
+		if path == "" {
+			path = defaultJSONExportPath()
+		}
+		m.jsonExportErr = ""
+		return m, exportJSON(m.displayedRecords(), path)
+	case "csv":
+		if path == "" {
+			path = defaultCSVExportPath()
+		}
+		return m, exportCSV(m.displayedRecords(), path)
+	}
+	m.commandErr = "usage: export json|csv [path]"
+	return m, nil
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// typeCommand opens the : prompt, types line and presses enter.
+func typeCommand(t *testing.T, m Model, line string) (Model, tea.Cmd) {
+	t.Helper()
+	updated, _ := m.Update(keyMsg(":"))
+	m = updated.(Model)
+	if !m.commandMode {
+		t.Fatal(": should open command mode")
+	}
+	for _, r := range line {
+		updated, _ = m.Update(tea.KeyPressMsg{Code: r})
+		m = updated.(Model)
+	}
+	updated, cmd := m.Update(keyMsg("enter"))
+	return updated.(Model), cmd
+}
+
+func TestCommandSort(t *testing.T) {
+	m := newTestModel(sortTestRecords())
+	m, _ = typeCommand(t, m, "sort year desc")
+	if m.commandMode || m.commandErr != "" {
+		t.Fatalf("commandMode=%v err=%q, want prompt closed without error", m.commandMode, m.commandErr)
+	}
+	if m.sortCol != sortYear || !m.sortDesc {
+		t.Errorf("sort = %v desc=%v, want year desc", m.sortCol, m.sortDesc)
+	}
+	if got := recordIDs(m.filtered); got != "2,4,1,3" {
+		t.Errorf("order = %s, want 2,4,1,3", got)
+	}
+}
+
+func TestCommandFilterGenre(t *testing.T) {
+	m := newTestModel(genreRecords())
+	m, cmd := typeCommand(t, m, "filter genre=Rock")
+	if m.genreFilter != "Rock" || cmd == nil {
+		t.Fatalf("genreFilter = %q, want Rock and a refetch", m.genreFilter)
+	}
+	updated, _ := m.Update(cmd())
+	if got := recordIDs(updated.(Model).filtered); got != "3,2" {
+		t.Errorf("rock records = %s, want 3,2", got)
+	}
+
+	m, _ = typeCommand(t, updated.(Model), "filter off")
+	if m.genreFilter != "" {
+		t.Errorf("filter off should clear the genre, got %q", m.genreFilter)
+	}
+}
+
+func TestCommandFilterYear(t *testing.T) {
+	m := newTestModel(sortTestRecords())
+	m, _ = typeCommand(t, m, "filter year=1959")
+	if m.yearRange != (yearRange{1959, 1959}) {
+		t.Errorf("yearRange = %v, want 1959", m.yearRange)
+	}
+	m, _ = typeCommand(t, m, "filter year=soon")
+	if !strings.Contains(m.commandErr, "filter year") {
+		t.Errorf("commandErr = %q, want a year error", m.commandErr)
+	}
+}
+
+func TestCommandExportCSV(t *testing.T) {
+	m := newTestModel(testRecords())
+	path := filepath.Join(t.TempDir(), "out.csv")
+	m, cmd := typeCommand(t, m, "export csv "+path)
+	if cmd == nil {
+		t.Fatal("export should return a command")
+	}
+	updated, status := m.Update(cmd())
+	if status == nil || !strings.Contains(updated.(Model).status, "Exported 3 records") {
+		t.Errorf("status = %q, want export summary", updated.(Model).status)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "artist_name,album_title") || !strings.Contains(string(data), "Kind of Blue") {
+		t.Errorf("csv = %q", data)
+	}
+}
+
+func TestCommandQuit(t *testing.T) {
+	m := newTestModel(testRecords())
+	_, cmd := typeCommand(t, m, "q")
+	if cmd == nil {
+		t.Fatal(":q should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error(":q should return tea.Quit")
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"frobnicate", `unknown command "frobnicate"`},
+		{"sort", "usage: sort"},
+		{"sort colour", `unknown sort column "colour"`},
+		{"sort year sideways", "asc or desc"},
+		{"filter", "usage: filter"},
+		{"export xml", "usage: export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			m, _ := typeCommand(t, newTestModel(testRecords()), tt.line)
+			if !strings.Contains(m.commandErr, tt.want) {
+				t.Errorf("commandErr = %q, want it to contain %q", m.commandErr, tt.want)
+			}
+			if !strings.Contains(m.renderList(), tt.want) {
+				t.Error("the error should be shown in the list view")
+			}
+			updated, _ := m.Update(keyMsg("j"))
+			if updated.(Model).commandErr != "" {
+				t.Error("the next key should clear the error")
+			}
+		})
+	}
+}
+
+func TestCommandEscCancels(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg(":"))
+	updated, _ = updated.(Model).Update(keyMsg("q"))
+	updated, cmd := updated.(Model).Update(keyMsg("esc"))
+	if updated.(Model).commandMode || cmd != nil {
+		t.Error("esc should close the prompt without running anything")
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+// ConnectFunc opens the record store. The model calls it from a command
+// when it has no store yet, and again each time the user retries.
+type ConnectFunc func(ctx context.Context) (db.Store, error)
+
+type storeConnectedMsg struct {
+	store db.Store
+	err   error
+}
+
+func connectStore(ctx context.Context, connect ConnectFunc) tea.Cmd {
+	return func() tea.Msg {
+		if connect == nil {
+			return storeConnectedMsg{err: errors.New("no database configured")}
+		}
+		store, err := connect(ctx)
+		return storeConnectedMsg{store: store, err: err}
+	}
+}
+
+// WithConnect lets a model built with a nil store connect on startup, so
+// an unreachable database shows an error with a retry key instead of
+// keeping the TUI from starting.
+func (m Model) WithConnect(connect ConnectFunc) Model {
+	m.connect = connect
+	return m
+}
+
+func (m Model) handleDisconnectedKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "r":
+		if m.loading || m.connect == nil {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, connectStore(m.ctx, m.connect)
+	case "q", "ctrl+c":
+		return m.quit()
+	}
+	return m, nil
+}
+
+func (m Model) renderDisconnected() string {
+	var b strings.Builder
+	b.WriteString(m.styles.title.Render("♫ Record Collection"))
+	b.WriteString("\n\n")
+	if m.loading {
+		b.WriteString("  Connecting to database...\n")
+		return b.String()
+	}
+	b.WriteString(m.styles.error.Render(fmt.Sprintf("  Database unavailable: %v", m.err)))
+	b.WriteString("\n")
+	keys := disconnectedKeys
+	if m.connect == nil {
+		keys = keys[1:]
+	}
+	b.WriteString(m.helpLine(keys))
+	return b.String()
+}
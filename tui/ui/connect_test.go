@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func newDisconnectedModel(connect ConnectFunc) Model {
+	m := NewModel(nil, "", "", "").WithConnect(connect)
+	m.width = 120
+	m.height = 40
+	m.imgProto = protoMosaic
+	return m
+}
+
+func TestConnectFailureThenRetry(t *testing.T) {
+	store := &mockStore{records: testRecords()}
+	calls := 0
+	m := newDisconnectedModel(func(context.Context) (db.Store, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return store, nil
+	})
+
+	if !strings.Contains(m.View().Content, "Connecting to database") {
+		t.Errorf("view = %q, want connecting message", m.View().Content)
+	}
+	updated, _ := m.Update(m.Init()())
+	m = updated.(Model)
+	if m.loading || m.err == nil {
+		t.Fatalf("loading = %v, err = %v; want failed connect", m.loading, m.err)
+	}
+	view := m.View().Content
+	for _, want := range []string{"Database unavailable: connection refused", "retry connection"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	// List keys must not reach the nil store.
+	for _, key := range []string{"a", "d", "e", "s", "enter", "/"} {
+		updated, cmd := m.Update(keyMsg(key))
+		if cmd != nil {
+			t.Errorf("key %q returned a command while disconnected", key)
+		}
+		m = updated.(Model)
+	}
+
+	updated, cmd := m.Update(keyMsg("r"))
+	m = updated.(Model)
+	if !m.loading || cmd == nil {
+		t.Fatal("r should start a connect attempt")
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if m.store == nil || m.err != nil {
+		t.Fatalf("store = %v, err = %v; want connected", m.store, m.err)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.records) != 3 || m.loading {
+		t.Errorf("records = %d, loading = %v; want 3 loaded", len(m.records), m.loading)
+	}
+}
+
+func TestDisconnectedQuit(t *testing.T) {
+	m := newDisconnectedModel(nil)
+	updated, _ := m.Update(m.Init()())
+	m = updated.(Model)
+	if m.err == nil {
+		t.Fatal("expected an error without a connect func")
+	}
+	if strings.Contains(m.View().Content, "retry connection") {
+		t.Error("retry hint shown without a connect func")
+	}
+	if _, cmd := m.Update(keyMsg("r")); cmd != nil {
+		t.Error("r should do nothing without a connect func")
+	}
+	for _, key := range []string{"q", "ctrl+c"} {
+		if _, cmd := m.Update(keyMsg(key)); cmd == nil {
+			t.Errorf("%s should quit while disconnected", key)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	lipgloss "charm.land/lipgloss/v2"
+	"my-record-collection-tui/db"
+)
+
+// countBrowser is the state behind the views that list GROUP BY buckets
+// (labels, genres) and filter the list to the one picked.
+type countBrowser struct {
+	counts  []db.Count
+	cursor  int
+	offset  int
+	loading bool
+	err     string
+}
+
+// load marks the browser as waiting on a fresh set of counts.
+func (b *countBrowser) load() {
+	b.loading = true
+	b.err = ""
+}
+
+// loaded takes a query's result, putting the cursor on current (the
+// active filter, if any) so reopening a browser picks up where it left
+// off.
+func (b *countBrowser) loaded(counts []db.Count, err error, current *string, visible int) {
+	b.loading = false
+	if err != nil {
+		b.err = err.Error()
+		return
+	}
+	b.counts = counts
+	b.cursor = 0
+	if current != nil {
+		b.cursor = max(0, slices.IndexFunc(counts, func(c db.Count) bool { return c.Name == *current }))
+	}
+	b.scroll(visible)
+}
+
+func (b *countBrowser) move(delta, visible int) {
+	b.cursor = max(0, min(b.cursor+delta, len(b.counts)-1))
+	b.scroll(visible)
+}
+
+// scroll keeps the cursor inside the visible rows.
+func (b *countBrowser) scroll(visible int) {
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	} else if b.cursor >= b.offset+visible {
+		b.offset = b.cursor - visible + 1
+	}
+}
+
+func (b countBrowser) selected() (db.Count, bool) {
+	if b.cursor < len(b.counts) {
+		return b.counts[b.cursor], true
+	}
+	return db.Count{}, false
+}
+
+// renderCountBrowser draws b as a two-column name/count list under title,
+// with name mapping a bucket to its display text.
+func (m Model) renderCountBrowser(b countBrowser, title, noun string, name func(string) string, keys []keyBinding) string {
+	var s strings.Builder
+	heading := m.styles.title.Render("♫ " + title)
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, heading, "  ", m.styles.statusBar.Render(fmt.Sprintf("%d %s", len(b.counts), noun))))
+	s.WriteString("\n\n")
+
+	switch {
+	case b.loading:
+		s.WriteString("  Loading...\n")
+	case b.err != "":
+		s.WriteString(m.styles.error.Render("  "+b.err) + "\n")
+	case len(b.counts) == 0:
+		s.WriteString("  No records yet.\n")
+	}
+	if !b.loading {
+		nameWidth := 0
+		for _, c := range b.counts {
+			nameWidth = max(nameWidth, lipgloss.Width(name(c.Name)))
+		}
+		nameWidth = min(nameWidth, max(m.width-14, 10))
+		start := min(b.offset, max(0, len(b.counts)-1))
+		end := min(start+m.listVisibleRows(), len(b.counts))
+		for i := start; i < end; i++ {
+			c := b.counts[i]
+			line := fmt.Sprintf("  %s  %5d", truncPad(name(c.Name), nameWidth), c.N)
+			if i == b.cursor {
+				s.WriteString(m.styles.selectedRow.Render(line))
+			} else {
+				s.WriteString(m.styles.normalRow.Render(line))
+			}
+			s.WriteString("\n")
+		}
+	}
+
+	s.WriteString(m.helpLine(keys))
+	return s.String()
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+const coverExportWorkers = 4
+
+type coverExportProgress struct {
+	Total   int
+	Done    int
+	Saved   int
+	Failed  int
+	Skipped int
+	Errors  []string
+}
+
+type coverExportProgressMsg struct {
+	progress coverExportProgress
+	updates  <-chan coverExportProgress
+	done     <-chan coverExportDoneMsg
+}
+
+type coverExportDoneMsg struct {
+	progress coverExportProgress
+	err      error
+}
+
+func defaultCoverExportDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return "record-covers"
+	}
+	return filepath.Join(home, "Pictures", "record-covers")
+}
+
+// startCoverExport runs exportCovers in the background and streams progress
+// back through the returned command chain.
+func startCoverExport(ctx context.Context, records []db.Record, dir string) tea.Cmd {
+	updates := make(chan coverExportProgress)
+	done := make(chan coverExportDoneMsg, 1)
+	go func() {
+		p, err := exportCovers(ctx, records, dir, coverExportWorkers, func(p coverExportProgress) {
+			updates <- p
+		})
+		done <- coverExportDoneMsg{progress: p, err: err}
+		close(updates)
+	}()
+	return waitCoverExport(updates, done)
+}
+
+func waitCoverExport(updates <-chan coverExportProgress, done <-chan coverExportDoneMsg) tea.Cmd {
+	return func() tea.Msg {
+		if p, ok := <-updates; ok {
+			return coverExportProgressMsg{progress: p, updates: updates, done: done}
+		}
+		return <-done
+	}
+}
+
+// exportCovers downloads every record's full-size cover into dir, named
+// "artist - album.ext". Records without an image URL are skipped.
+func exportCovers(ctx context.Context, records []db.Record, dir string, workers int, onProgress func(coverExportProgress)) (coverExportProgress, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return coverExportProgress{}, fmt.Errorf("create export dir: %w", err)
+	}
+
+	type job struct {
+		url  string
+		base string
+	}
+	var jobs []job
+	progress := coverExportProgress{Total: len(records)}
+	used := make(map[string]int)
+	for _, rec := range records {
+		url := rec.ImageURL()
+		if url == "" {
+			progress.Skipped++
+			progress.Done++
+			continue
+		}
+		base := sanitizeFilename(rec.ArtistName + " - " + rec.AlbumTitle)
+		used[strings.ToLower(base)]++
+		if n := used[strings.ToLower(base)]; n > 1 {
+			base = fmt.Sprintf("%s (%d)", base, n)
+		}
+		jobs = append(jobs, job{url: url, base: base})
+	}
+	onProgress(progress)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan job)
+	for range max(1, workers) {
+		wg.Go(func() {
+			for j := range queue {
+				err := saveCover(ctx, dir, j.base, j.url)
+				mu.Lock()
+				progress.Done++
+				if err != nil {
+					progress.Failed++
+					progress.Errors = append(progress.Errors, fmt.Sprintf("%s: %v", j.base, err))
+				} else {
+					progress.Saved++
+				}
+				snapshot := progress
+				snapshot.Errors = append([]string(nil), progress.Errors...)
+				onProgress(snapshot)
+				mu.Unlock()
+			}
+		})
+	}
+	for _, j := range jobs {
+		queue <- j
+	}
+	close(queue)
+	wg.Wait()
+
+	return progress, nil
+}
+
+func saveCover(ctx context.Context, dir, base, url string) error {
+	raw, ct, err := fetchImageBytes(ctx, url)
+	if err != nil {
+		return err
+	}
+	name := base + coverExtension(ct, url)
+	return os.WriteFile(filepath.Join(dir, name), raw, 0644)
+}
+
+func coverExtension(contentType, url string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "image/jpeg":
+			return ".jpg"
+		case "image/png":
+			return ".png"
+		case "image/gif":
+			return ".gif"
+		case "image/webp":
+			return ".webp"
+		}
+	}
+	if ext := strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0])); ext != "" && len(ext) <= 5 {
+		return ext
+	}
+	return ".jpg"
+}
+
+// sanitizeFilename replaces characters that are unsafe in file names on
+// common filesystems and keeps the result to a sane length.
+func sanitizeFilename(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			continue
+		case strings.ContainsRune(`/\:*?"<>|`, r):
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	out := strings.Trim(strings.TrimSpace(b.String()), ".")
+	if runes := []rune(out); len(runes) > 200 {
+		out = string(runes[:200])
+	}
+	if out == "" {
+		return "untitled"
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Miles Davis - Kind of Blue", "Miles Davis - Kind of Blue"},
+		{"slashes and colons", "AC/DC - Live: 1991", "AC_DC - Live_ 1991"},
+		{"reserved chars", `a*b?c"d<e>f|g\h`, "a_b_c_d_e_f_g_h"},
+		{"control chars dropped", "bad\x00\x1fname", "badname"},
+		{"leading dots trimmed", "..hidden.", "hidden"},
+		{"empty", "  ", "untitled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeFilename(tt.in); got != tt.want {
+				t.Errorf("sanitizeFilename(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoverExtension(t *testing.T) {
+	tests := []struct {
+		ct, url, want string
+	}{
+		{"image/png", "http://x/a", ".png"},
+		{"image/jpeg; charset=binary", "http://x/a.png", ".jpg"},
+		{"", "http://x/cover.gif?size=600", ".gif"},
+		{"application/octet-stream", "http://x/noext", ".jpg"},
+	}
+	for _, tt := range tests {
+		if got := coverExtension(tt.ct, tt.url); got != tt.want {
+			t.Errorf("coverExtension(%q, %q) = %q, want %q", tt.ct, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestExportCovers(t *testing.T) {
+	server := servePNG(t)
+	defer server.Close()
+
+	url := server.URL + "/cover.png"
+	records := []db.Record{
+		{ArtistName: "AC/DC", AlbumTitle: "Back in Black", CoverImageURL: &url},
+		{ArtistName: "AC/DC", AlbumTitle: "Back in Black", CoverImageURL: &url},
+		{ArtistName: "No", AlbumTitle: "Image"},
+	}
+	dir := filepath.Join(t.TempDir(), "covers")
+
+	var calls int
+	p, err := exportCovers(context.Background(), records, dir, 2, func(coverExportProgress) { calls++ })
+	if err != nil {
+		t.Fatalf("exportCovers: %v", err)
+	}
+	if p.Saved != 2 || p.Skipped != 1 || p.Failed != 0 || p.Done != 3 {
+		t.Errorf("progress = %+v, want 2 saved, 1 skipped", p)
+	}
+	if calls == 0 {
+		t.Error("onProgress should be called")
+	}
+	for _, name := range []string{"AC_DC - Back in Black.png", "AC_DC - Back in Black (2).png"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+}
+
+func TestExportCoversCountsFailures(t *testing.T) {
+	fastImageRetries(t)
+	url := "http://localhost:1/missing.jpg"
+	records := []db.Record{{ArtistName: "A", AlbumTitle: "B", CoverImageURL: &url}}
+
+	p, err := exportCovers(context.Background(), records, t.TempDir(), 1, func(coverExportProgress) {})
+	if err != nil {
+		t.Fatalf("exportCovers: %v", err)
+	}
+	if p.Failed != 1 || len(p.Errors) != 1 {
+		t.Errorf("progress = %+v, want 1 failure", p)
+	}
+}
+
+func TestCoverExportFlow(t *testing.T) {
+	server := servePNG(t)
+	defer server.Close()
+
+	url := server.URL + "/cover.png"
+	records := []db.Record{{RecordID: "1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", CoverImageURL: &url}}
+	m := newTestModel(records)
+
+	updated, _ := m.Update(keyMsg("X"))
+	m = updated.(Model)
+	if !m.coverExportPrompt {
+		t.Fatal("X should open the export prompt")
+	}
+	m.coverExportDir = t.TempDir()
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if !m.coverExporting || cmd == nil {
+		t.Fatal("enter should start the export")
+	}
+
+	var msg tea.Msg = cmd()
+	for {
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+		if _, done := msg.(coverExportDoneMsg); done {
+			break
+		}
+		msg = cmd()
+	}
+	if m.coverExporting {
+		t.Error("export should be finished")
+	}
+	if !strings.Contains(m.status, "Saved 1 covers") {
+		t.Errorf("status = %q, want saved summary", m.status)
+	}
+}
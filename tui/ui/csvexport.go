@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type csvExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+func defaultCSVExportPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return "record-collection.csv"
+	}
+	return filepath.Join(home, "record-collection.csv")
+}
+
+// writeRecordsCSV writes records in the layout the import subcommand
+// reads, replacing path only once the whole file has been written.
+func writeRecordsCSV(records []db.Record, path string) error {
+	var buf bytes.Buffer
+	if err := db.WriteCSV(&buf, records); err != nil {
+		return err
+	}
+	return replaceFile(path, buf.Bytes())
+}
+
+func exportCSV(records []db.Record, path string) tea.Cmd {
+	records = append([]db.Record(nil), records...)
+	return func() tea.Msg {
+		return csvExportedMsg{path: path, count: len(records), err: writeRecordsCSV(records, path)}
+	}
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"strings"
+
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+const (
+	// detailBoxChrome is the detail box's border and horizontal padding.
+	detailBoxChrome = 6
+
+	// detailInfoWidth is the info pane's width when there's room for it;
+	// detailInfoMinWidth is the narrowest it gets beside the cover before
+	// the panes stack.
+	detailInfoWidth    = 60
+	detailInfoMinWidth = 36
+)
+
+// detailDivider separates the cover and info panes.
+const detailDivider = " │ "
+
+// detailSideBySide reports whether the info pane sits beside the cover.
+// Only text-art protocols can share lines with other content, and they
+// stack once the terminal is too narrow for both panes.
+func (m Model) detailSideBySide() bool {
+	if !m.imgProto.textArt() {
+		return false
+	}
+	if m.width <= 0 {
+		return true
+	}
+	return m.width-detailBoxChrome-m.artSize().w-ansi.StringWidth(detailDivider) >= detailInfoMinWidth
+}
+
+// detailInfoPaneWidth is the info pane's width: detailInfoWidth, or
+// whatever is left when the terminal is narrower.
+func (m Model) detailInfoPaneWidth() int {
+	if m.width <= 0 {
+		return detailInfoWidth
+	}
+	avail := m.width - detailBoxChrome
+	if m.detailSideBySide() {
+		avail -= m.artSize().w + ansi.StringWidth(detailDivider)
+	}
+	return max(min(detailInfoWidth, avail), m.styles.label.GetWidth()+1)
+}
+
+// detailInfoLines renders rec's fields, wrapping long values under their
+// own column so the pane keeps its width.
+func (m Model) detailInfoLines(rec db.Record) []string {
+	labelWidth := m.styles.label.GetWidth()
+	valueWidth := m.detailInfoPaneWidth() - labelWidth
+	indent := strings.Repeat(" ", labelWidth)
+	focus, _ := m.focusedField()
+	var lines []string
+	for _, f := range m.detailFields(rec) {
+		for i, part := range strings.Split(ansi.Wrap(f.value, valueWidth, "-/"), "\n") {
+			label := indent
+			switch {
+			case i == 0 && f.label == focus:
+				label = m.styles.focusLabel.Render(f.label)
+			case i == 0:
+				label = m.styles.label.Render(f.label)
+			}
+			lines = append(lines, label+m.styles.value.Render(part))
+		}
+	}
+	return lines
+}
+
+// renderDetailPanes boxes the cover and info panes: side by side with a
+// divider, stacked on narrow terminals, or with the cover under the box
+// for inline-image protocols.
+func (m Model) renderDetailPanes(art, info string) string {
+	info = lipgloss.NewStyle().Width(m.detailInfoPaneWidth()).Render(info)
+	if !m.imgProto.textArt() {
+		return m.styles.detailBox.Render(info) + "\n" + art
+	}
+	if !m.detailSideBySide() {
+		return m.styles.detailBox.Render(lipgloss.JoinVertical(lipgloss.Left, art, "", info))
+	}
+	height := max(lipgloss.Height(art), lipgloss.Height(info))
+	divider := strings.TrimSuffix(strings.Repeat(detailDivider+"\n", height), "\n")
+	return m.styles.detailBox.Render(
+		lipgloss.JoinHorizontal(lipgloss.Top, art, m.styles.helpSep.Render(divider), info))
+}
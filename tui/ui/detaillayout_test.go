@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func longURIModel(width int) Model {
+	recs := testRecords()
+	uri := "https://www.discogs.com/release/" + strings.Repeat("1234567890", 8)
+	recs[0].DiscogsURI = &uri
+	m := newTestModel(recs)
+	m.view = detailView
+	m.width = width
+	return m
+}
+
+func TestDetailWrapsLongValues(t *testing.T) {
+	m := longURIModel(120)
+	out := ansi.Strip(m.renderDetail())
+	if strings.Contains(out, "…") {
+		t.Error("long values should wrap, not be cut short")
+	}
+	joined := strings.Join(strings.Fields(strings.ReplaceAll(out, "│", " ")), "")
+	if !strings.Contains(joined, strings.Repeat("1234567890", 8)) {
+		t.Error("every part of the wrapped value should be shown")
+	}
+	if got, fields := len(m.detailInfoLines(m.filtered[0])), len(m.detailFields(m.filtered[0])); got <= fields {
+		t.Errorf("info lines = %d for %d fields, want continuation lines", got, fields)
+	}
+}
+
+func TestDetailPaneLayout(t *testing.T) {
+	tests := []struct {
+		width   int
+		proto   imageProto
+		divider bool
+		stacked bool
+	}{
+		{120, protoMosaic, true, false},
+		{70, protoMosaic, false, true},
+		{120, protoKitty, false, false},
+	}
+	for _, tt := range tests {
+		m := longURIModel(tt.width)
+		m.imgProto = tt.proto
+		m.artLoading = false
+		lines := strings.Split(ansi.Strip(m.renderDetail()), "\n")
+
+		var divider bool
+		artistRow, artRow := -1, -1
+		for i, line := range lines {
+			if strings.Contains(line, "│ │ ") {
+				divider = true
+			}
+			if artRow < 0 && strings.Contains(line, "No Image") {
+				artRow = i
+			}
+			if artistRow < 0 && strings.Contains(line, "Artist") {
+				artistRow = i
+			}
+		}
+		if divider != tt.divider {
+			t.Errorf("%d cols %v: divider = %v, want %v", tt.width, tt.proto, divider, tt.divider)
+		}
+		if stacked := artRow >= 0 && artistRow > artRow && !divider; stacked != tt.stacked {
+			t.Errorf("%d cols %v: stacked = %v, want %v", tt.width, tt.proto, stacked, tt.stacked)
+		}
+	}
+}
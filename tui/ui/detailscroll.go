@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+
+	"my-record-collection-tui/db"
+)
+
+// detailChromeLines is everything in the detail view besides the info
+// rows: title and gap, box border and padding, the gap under the box, the
+// help line and one status line.
+const detailChromeLines = 9
+
+// detailInfoRows is how many info rows fit on screen. When the cover
+// isn't beside the fields (inline-image protocols, or a stacked layout) it
+// takes rows away too.
+func (m Model) detailInfoRows() int {
+	rows := m.height - detailChromeLines
+	if !m.detailSideBySide() {
+		rows -= m.artSize().h + 1
+	}
+	return max(rows, 1)
+}
+
+// detailMaxScroll is how far the info block of rec can scroll; 0 when it
+// fits or the terminal size isn't known yet.
+func (m Model) detailMaxScroll(rec db.Record) int {
+	if m.height <= 0 {
+		return 0
+	}
+	return max(0, len(m.detailInfoLines(rec))-m.detailInfoRows())
+}
+
+// scrollDetail moves the info block by delta rows, clamped to the content.
+func (m Model) scrollDetail(delta int) Model {
+	rec, ok := m.selectedRecord()
+	if !ok {
+		return m
+	}
+	m.detailOffset = max(0, min(m.detailOffset+delta, m.detailMaxScroll(rec)))
+	return m
+}
+
+// detailWindow cuts lines down to the rows that fit, starting at the
+// clamped scroll offset. The second value describes the position when
+// some rows are hidden.
+func (m Model) detailWindow(lines []string) ([]string, string) {
+	if m.height <= 0 {
+		return lines, ""
+	}
+	rows := m.detailInfoRows()
+	if len(lines) <= rows {
+		return lines, ""
+	}
+	offset := max(0, min(m.detailOffset, len(lines)-rows))
+	return lines[offset : offset+rows], fmt.Sprintf("[rows %d–%d of %d, j/k scroll]", offset+1, offset+rows, len(lines))
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDetailScrollsWhenInfoOverflows(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.height = 15
+	total := len(m.detailFields(m.filtered[0]))
+	rows := m.detailInfoRows()
+	if rows >= total {
+		t.Fatalf("test needs overflow: %d rows for %d fields", rows, total)
+	}
+
+	if !strings.Contains(m.renderDetail(), "Artist") || strings.Contains(m.renderDetail(), "Synced") {
+		t.Error("top of the info block should show first")
+	}
+	for range total {
+		updated, _ := m.Update(keyMsg("j"))
+		m = updated.(Model)
+	}
+	if m.cursor != 0 {
+		t.Fatal("j should scroll, not change record, while the info overflows")
+	}
+	if want := total - rows; m.detailOffset != want {
+		t.Errorf("detailOffset = %d, want clamped to %d", m.detailOffset, want)
+	}
+	v := m.renderDetail()
+	if !strings.Contains(v, "Synced") || strings.Contains(v, "Artist") {
+		t.Error("scrolled to the end, the last field should show and the first be hidden")
+	}
+	if !strings.Contains(v, fmt.Sprintf("of %d", total)) {
+		t.Error("the footer should say which fields are shown")
+	}
+
+	updated, _ := m.Update(keyMsg("k"))
+	if updated.(Model).detailOffset != total-rows-1 {
+		t.Error("k should scroll back up")
+	}
+}
+
+func TestDetailScrollResetsOnNextRecord(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.height = 15
+	updated, _ := m.Update(keyMsg("j"))
+	m = updated.(Model)
+	if m.detailOffset != 1 {
+		t.Fatalf("detailOffset = %d, want 1", m.detailOffset)
+	}
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m = updated.(Model)
+	if m.cursor != 1 || m.detailOffset != 0 {
+		t.Errorf("cursor = %d offset = %d, want next record from the top", m.cursor, m.detailOffset)
+	}
+}
+
+func TestDetailFitsWithoutScrolling(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	updated, _ := m.Update(keyMsg("j"))
+	m = updated.(Model)
+	if m.cursor != 1 || m.detailOffset != 0 {
+		t.Errorf("with room to spare j should move to the next record, cursor = %d", m.cursor)
+	}
+	if strings.Contains(m.renderDetail(), "j/k scroll]") {
+		t.Error("no scroll position should show when everything fits")
+	}
+}
+
+func TestDetailReflowsOnResize(t *testing.T) {
+	recs := testRecords()
+	uri := "https://www.discogs.com/release/" + strings.Repeat("1234567890", 12)
+	recs[0].DiscogsURI = &uri
+	m := newTestModel(recs)
+	m.view = detailView
+
+	for _, width := range []int{120, 80, 60} {
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 40})
+		m = updated.(Model)
+		for line := range strings.SplitSeq(m.renderDetail(), "\n") {
+			if !strings.ContainsAny(line, "│╭╰") {
+				continue
+			}
+			if got := ansi.StringWidth(line); got > width {
+				t.Errorf("width %d: box line is %d cells wide: %q", width, got, ansi.Strip(line))
+			}
+		}
+	}
+}
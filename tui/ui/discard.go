@@ -0,0 +1,90 @@
+package ui
+
+import tea "charm.land/bubbletea/v2"
+
+// manualInputs is the manual form's current text, in manualFieldLabels
+// order, to compare against manualFormValues.
+func (m Model) manualInputs() [manualFieldCount]string {
+	return [manualFieldCount]string{
+		m.manualArtist, m.manualAlbum, m.manualYear,
+		m.manualLabel, m.manualCatalog, m.manualGenres,
+		m.manualTags, m.manualSize, m.manualColor,
+	}
+}
+
+// formDirty reports whether the open add or edit form holds typing that
+// leaving would throw away: anything in an add form, or an edit form,
+// inline field or notes prompt that no longer matches its record.
+func (m Model) formDirty() bool {
+	switch {
+	case m.noteEditing:
+		return !m.noteSaving && m.noteInput != m.noteOriginal
+	case m.fieldEditing:
+		return !m.fieldSaving && m.fieldInput != m.fieldOriginal
+	}
+	switch m.view {
+	case addManualView:
+		if m.manualSaving {
+			return false
+		}
+		var initial [manualFieldCount]string
+		if m.manualEditing != nil {
+			initial = manualFormValues(*m.manualEditing)
+		}
+		return m.manualInputs() != initial
+	case addDiscogsView:
+		return !m.discogsSaving && m.discogsArtist+m.discogsTitle+m.discogsCatalogNumber+m.discogsUPC != ""
+	}
+	return false
+}
+
+// guardDiscard opens the "Discard changes?" prompt when the form is
+// dirty, reporting whether it did; quit says whether yes should quit
+// rather than just close the form.
+func (m Model) guardDiscard(quit bool) (Model, bool) {
+	if !m.formDirty() {
+		return m, false
+	}
+	m.discardPrompt = true
+	m.discardQuits = quit
+	return m, true
+}
+
+func (m Model) handleDiscardKey(key string) (tea.Model, tea.Cmd) {
+	m.discardPrompt = false
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "y", "Y":
+		if m.discardQuits {
+			return m.quit()
+		}
+		return m.leaveForm(), nil
+	}
+	return m, nil
+}
+
+// leaveForm closes the add or edit form without saving.
+func (m Model) leaveForm() Model {
+	switch m.view {
+	case addManualView:
+		m.view = listView
+		m.manualErr = ""
+		m.manualSaving = false
+		m.manualEditing = nil
+	case addDiscogsView:
+		m.view = listView
+		m.discogsErr = ""
+		m.discogsSearching = false
+		m.discogsSaving = false
+	}
+	return m
+}
+
+func (m Model) renderDiscardPrompt() string {
+	action := "Discard changes"
+	if m.discardQuits {
+		action = "Discard changes and quit"
+	}
+	return m.styles.error.Render("  "+action+"? y/n") + "\n"
+}
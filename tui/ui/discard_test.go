@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+func TestDiscardPromptOnlyWhenDirty(t *testing.T) {
+	tests := []struct {
+		name   string
+		open   string
+		typed  string
+		key    string
+		prompt bool
+	}{
+		{"untouched add", "m", "", "esc", false},
+		{"typed add", "m", "x", "esc", true},
+		{"untouched edit", "e", "", "esc", false},
+		{"changed edit", "e", "x", "esc", true},
+		{"changed edit quit", "e", "x", "ctrl+c", true},
+		{"untouched discogs", "a", "", "esc", false},
+		{"typed discogs", "a", "x", "esc", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel([]db.Record{editTestRecord()})
+			m, _ = pressKeys(m, keyMsg(tt.open))
+			form := m.view
+			if tt.typed != "" {
+				m, _ = pressKeys(m, keyMsg(tt.typed))
+			}
+			m, cmd := pressKeys(m, keyMsg(tt.key))
+			if m.discardPrompt != tt.prompt {
+				t.Fatalf("prompt = %v, want %v", m.discardPrompt, tt.prompt)
+			}
+			if !tt.prompt {
+				if tt.key == "esc" && m.view != listView {
+					t.Errorf("esc on an untouched form should go back to the list, view %d", m.view)
+				}
+				return
+			}
+			if cmd != nil || m.view != form {
+				t.Error("a dirty form should stay open while asking")
+			}
+			if !strings.Contains(m.View().Content, "Discard changes") {
+				t.Error("the form should show the discard prompt")
+			}
+		})
+	}
+}
+
+func TestDiscardPromptAnswers(t *testing.T) {
+	m := newTestModel(nil)
+	m, _ = pressKeys(m, keyMsg("m"), keyMsg("x"), keyMsg("esc"), keyMsg("n"))
+	if m.discardPrompt || m.view != addManualView || m.manualArtist != "x" {
+		t.Fatalf("n should keep the form and its input, got view %d artist %q", m.view, m.manualArtist)
+	}
+
+	m, _ = pressKeys(m, keyMsg("esc"), keyMsg("y"))
+	if m.discardPrompt || m.view != listView {
+		t.Errorf("y should close the form, got view %d", m.view)
+	}
+
+	m = newTestModel(nil)
+	m, _ = pressKeys(m, keyMsg("m"), keyMsg("x"), keyMsg("ctrl+c"))
+	if !strings.Contains(m.View().Content, "Discard changes and quit? y/n") {
+		t.Errorf("ctrl+c should ask before quitting:\n%s", m.View().Content)
+	}
+	m, cmd := pressKeys(m, keyMsg("y"))
+	if cmd == nil {
+		t.Fatal("y after ctrl+c should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok || m.ctx.Err() == nil {
+		t.Error("y after ctrl+c should quit and cancel in-flight work")
+	}
+}
+
+func TestDiscardPromptInDetailEditors(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []tea.KeyPressMsg
+		prompt bool
+	}{
+		{"untouched notes", []tea.KeyPressMsg{keyMsg("n")}, false},
+		{"typed notes", []tea.KeyPressMsg{keyMsg("n"), keyMsg("x")}, true},
+		{"notes typed back", []tea.KeyPressMsg{keyMsg("n"), keyMsg("x"), keyMsg("backspace")}, false},
+		{"untouched field", []tea.KeyPressMsg{keyMsg("tab"), keyMsg("e")}, false},
+		{"typed field", []tea.KeyPressMsg{keyMsg("tab"), keyMsg("e"), keyMsg("x")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := inlineEditModel()
+			m, _ = pressKeys(m, tt.keys...)
+			m, cmd := pressKeys(m, keyMsg("ctrl+c"))
+			if !tt.prompt {
+				if cmd == nil {
+					t.Fatal("ctrl+c in an untouched editor should quit")
+				}
+				if _, ok := cmd().(tea.QuitMsg); !ok {
+					t.Error("ctrl+c in an untouched editor should quit")
+				}
+				return
+			}
+			if !m.discardPrompt || cmd != nil {
+				t.Fatalf("ctrl+c with edits should ask first, prompt=%v", m.discardPrompt)
+			}
+			if !strings.Contains(m.View().Content, "Discard changes and quit? y/n") {
+				t.Errorf("the detail view should show the discard prompt:\n%s", m.View().Content)
+			}
+
+			kept, _ := pressKeys(m, keyMsg("n"))
+			if kept.discardPrompt || !(kept.noteEditing || kept.fieldEditing) || !strings.HasSuffix(kept.noteInput+kept.fieldInput, "x") {
+				t.Error("n should go back to the editor with its input")
+			}
+			_, cmd = pressKeys(m, keyMsg("y"))
+			if cmd == nil {
+				t.Fatal("y should quit")
+			}
+			if _, ok := cmd().(tea.QuitMsg); !ok {
+				t.Error("y should quit")
+			}
+		})
+	}
+}
@@ -111,6 +111,10 @@ type discogsRelease struct {
 	} `json:"identifiers"`
 }
 
+// errDiscogsNoToken explains why Discogs search is unavailable; the search
+// endpoint rejects unauthenticated requests.
+const errDiscogsNoToken = "Discogs search needs a personal access token: set discogs_token in the config or DISCOGS_TOKEN."
+
 type discogsConfig struct {
 	token     string
 	userAgent string
@@ -128,7 +132,7 @@ func (e discogsHTTPError) Error() string {
 	return fmt.Sprintf("discogs request failed with status %d", e.status)
 }
 
-func searchDiscogs(dcfg discogsConfig, query discogsSearchQuery) ([]discogsSearchResult, error) {
+func searchDiscogs(ctx context.Context, dcfg discogsConfig, query discogsSearchQuery) ([]discogsSearchResult, error) {
 	baseURL := os.Getenv("DISCOGS_BASE_URL")
 	if strings.TrimSpace(baseURL) == "" {
 		baseURL = "https://api.discogs.com"
@@ -158,7 +162,7 @@ func searchDiscogs(dcfg discogsConfig, query discogsSearchQuery) ([]discogsSearc
 	}
 
 	var searchResp discogsSearchResponse
-	if err := discogsGetJSON(dcfg, baseURL, "/database/search?"+params.Encode(), &searchResp); err != nil {
+	if err := discogsGetJSON(ctx, dcfg, baseURL, "/database/search?"+params.Encode(), &searchResp); err != nil {
 		return nil, err
 	}
 
@@ -172,7 +176,7 @@ func searchDiscogs(dcfg discogsConfig, query discogsSearchQuery) ([]discogsSearc
 			CatNo: strings.TrimSpace(item.CatNo),
 		}
 
-		release, err := fetchDiscogsRelease(dcfg, item.ID)
+		release, err := fetchDiscogsRelease(ctx, dcfg, item.ID)
 		if err == nil {
 			result.RecordSize = extractRecordSize(release)
 			result.VinylColor = extractVinylColor(release)
@@ -185,8 +189,8 @@ func searchDiscogs(dcfg discogsConfig, query discogsSearchQuery) ([]discogsSearc
 	return results, nil
 }
 
-func addDiscogsReleaseToStore(store db.Store, releaseID int, username string, dcfg discogsConfig) error {
-	release, err := fetchDiscogsRelease(dcfg, releaseID)
+func addDiscogsReleaseToStore(ctx context.Context, store db.Store, releaseID int, username string, dcfg discogsConfig) error {
+	release, err := fetchDiscogsRelease(ctx, dcfg, releaseID)
 	if err != nil {
 		return err
 	}
@@ -212,7 +216,7 @@ func addDiscogsReleaseToStore(store db.Store, releaseID int, username string, dc
 	}
 
 	if username != "" {
-		err = addToDiscogsCollection(dcfg, username, releaseID)
+		err = addToDiscogsCollection(ctx, dcfg, username, releaseID)
 		if err == nil {
 			rec.IsSyncedWithDiscogs = true
 		} else {
@@ -222,30 +226,30 @@ func addDiscogsReleaseToStore(store db.Store, releaseID int, username string, dc
 		}
 	}
 
-	if err := store.Create(context.Background(), rec); err != nil {
+	if _, err := store.Create(ctx, rec); err != nil {
 		return err
 	}
 	return nil
 }
 
-func fetchDiscogsRelease(dcfg discogsConfig, releaseID int) (discogsRelease, error) {
+func fetchDiscogsRelease(ctx context.Context, dcfg discogsConfig, releaseID int) (discogsRelease, error) {
 	baseURL := os.Getenv("DISCOGS_BASE_URL")
 	if strings.TrimSpace(baseURL) == "" {
 		baseURL = "https://api.discogs.com"
 	}
 	var release discogsRelease
-	err := discogsGetJSON(dcfg, baseURL, "/releases/"+strconv.Itoa(releaseID), &release)
+	err := discogsGetJSON(ctx, dcfg, baseURL, "/releases/"+strconv.Itoa(releaseID), &release)
 	return release, err
 }
 
-func addToDiscogsCollection(dcfg discogsConfig, username string, releaseID int) error {
+func addToDiscogsCollection(ctx context.Context, dcfg discogsConfig, username string, releaseID int) error {
 	baseURL := os.Getenv("DISCOGS_BASE_URL")
 	if strings.TrimSpace(baseURL) == "" {
 		baseURL = "https://api.discogs.com"
 	}
 
 	endpoint := "/users/" + url.PathEscape(username) + "/collection/folders/1/releases/" + strconv.Itoa(releaseID)
-	_, err := discogsRequest(dcfg, http.MethodPost, baseURL, endpoint)
+	_, err := discogsRequest(ctx, dcfg, http.MethodPost, baseURL, endpoint)
 	return err
 }
 
@@ -292,10 +296,11 @@ type syncProgress struct {
 	Pushed            int
 	Skipped           int
 	Errors            []string
+	Results           []syncResult
 	TotalDiscogsItems int
 }
 
-func getUserCollection(dcfg discogsConfig, username string, page int) (discogsCollectionResponse, error) {
+func getUserCollection(ctx context.Context, dcfg discogsConfig, username string, page int) (discogsCollectionResponse, error) {
 	baseURL := os.Getenv("DISCOGS_BASE_URL")
 	if strings.TrimSpace(baseURL) == "" {
 		baseURL = "https://api.discogs.com"
@@ -307,7 +312,7 @@ func getUserCollection(dcfg discogsConfig, username string, page int) (discogsCo
 	)
 
 	var response discogsCollectionResponse
-	if err := discogsGetJSON(dcfg, baseURL, endpoint, &response); err != nil {
+	if err := discogsGetJSON(ctx, dcfg, baseURL, endpoint, &response); err != nil {
 		return discogsCollectionResponse{}, err
 	}
 	return response, nil
@@ -364,7 +369,7 @@ func collectionReleaseToRecord(info discogsCollectionBasicInfo) db.Record {
 	}
 }
 
-func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress func(syncProgress)) error {
+func executeSync(ctx context.Context, store db.Store, username string, dcfg discogsConfig, onProgress func(syncProgress)) error {
 	if username == "" {
 		return fmt.Errorf("discogs_username is required for sync")
 	}
@@ -372,7 +377,6 @@ func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress
 		return fmt.Errorf("discogs_token is required for sync")
 	}
 
-	ctx := context.Background()
 	progress := syncProgress{Phase: "pull"}
 	onProgress(progress)
 
@@ -386,7 +390,7 @@ func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress
 	totalPages := 1
 
 	for page <= totalPages {
-		response, err := getUserCollection(dcfg, username, page)
+		response, err := getUserCollection(ctx, dcfg, username, page)
 		if err != nil {
 			return fmt.Errorf("fetch discogs collection page %d: %w", page, err)
 		}
@@ -404,15 +408,15 @@ func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress
 			}
 
 			rec := collectionReleaseToRecord(info)
-			if createErr := store.Create(ctx, rec); createErr != nil {
+			if _, createErr := store.Create(ctx, rec); createErr != nil {
 				msg := createErr.Error()
 				if strings.Contains(msg, "unique") || strings.Contains(msg, "duplicate") {
 					progress.Skipped++
 				} else {
-					progress.Errors = append(progress.Errors, fmt.Sprintf("pull %s: %s", discogsID, msg))
+					progress.add(newSyncResult(rec, syncOutcomeError, "pull: "+msg))
 				}
 			} else {
-				progress.Pulled++
+				progress.add(newSyncResult(rec, syncOutcomePulled, ""))
 				existingIDs[discogsID] = struct{}{}
 			}
 		}
@@ -427,7 +431,7 @@ func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress
 	}
 	if len(idsToMark) > 0 {
 		if markErr := store.MarkSyncedWithDiscogs(ctx, idsToMark); markErr != nil {
-			progress.Errors = append(progress.Errors, fmt.Sprintf("mark synced: %s", markErr.Error()))
+			progress.add(syncResult{Outcome: syncOutcomeError, Detail: "mark synced: " + markErr.Error()})
 		}
 	}
 
@@ -444,40 +448,36 @@ func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress
 			continue
 		}
 		discogsID := *rec.DiscogsID
-		if _, inCollection := discogsCollectionIDs[discogsID]; inCollection {
+		markPushed := func() {
 			if markErr := store.MarkSyncedWithDiscogs(ctx, []string{discogsID}); markErr != nil {
-				progress.Errors = append(progress.Errors, fmt.Sprintf("mark synced %s: %s", discogsID, markErr.Error()))
+				progress.add(newSyncResult(rec, syncOutcomeError, "mark synced: "+markErr.Error()))
 			} else {
-				progress.Pushed++
+				progress.add(newSyncResult(rec, syncOutcomePushed, ""))
 			}
+		}
+
+		if _, inCollection := discogsCollectionIDs[discogsID]; inCollection {
+			markPushed()
 			onProgress(progress)
 			continue
 		}
 
 		releaseID, parseErr := strconv.Atoi(discogsID)
 		if parseErr != nil || releaseID <= 0 {
-			progress.Errors = append(progress.Errors, fmt.Sprintf("push %s: invalid discogs id", discogsID))
+			progress.add(newSyncResult(rec, syncOutcomeError, "push: invalid discogs id"))
 			onProgress(progress)
 			continue
 		}
 
-		pushErr := addToDiscogsCollection(dcfg, username, releaseID)
-		if pushErr == nil {
-			if markErr := store.MarkSyncedWithDiscogs(ctx, []string{discogsID}); markErr != nil {
-				progress.Errors = append(progress.Errors, fmt.Sprintf("mark synced %s: %s", discogsID, markErr.Error()))
-			} else {
-				progress.Pushed++
-			}
-		} else {
-			if statusErr, ok := errors.AsType[discogsHTTPError](pushErr); ok && statusErr.status == http.StatusConflict { //nolint
-				if markErr := store.MarkSyncedWithDiscogs(ctx, []string{discogsID}); markErr != nil {
-					progress.Errors = append(progress.Errors, fmt.Sprintf("mark synced %s: %s", discogsID, markErr.Error()))
-				} else {
-					progress.Pushed++
-				}
-			} else {
-				progress.Errors = append(progress.Errors, fmt.Sprintf("push %s: %s", discogsID, pushErr.Error()))
-			}
+		pushErr := addToDiscogsCollection(ctx, dcfg, username, releaseID)
+		statusErr, isHTTP := errors.AsType[discogsHTTPError](pushErr) //nolint
+		switch {
+		case pushErr == nil, isHTTP && statusErr.status == http.StatusConflict:
+			markPushed()
+		case isHTTP && statusErr.status == http.StatusNotFound:
+			progress.add(newSyncResult(rec, syncOutcomeNotFound, "release not on Discogs"))
+		default:
+			progress.add(newSyncResult(rec, syncOutcomeError, "push: "+pushErr.Error()))
 		}
 		onProgress(progress)
 	}
@@ -487,8 +487,8 @@ func executeSync(store db.Store, username string, dcfg discogsConfig, onProgress
 	return nil
 }
 
-func discogsGetJSON(dcfg discogsConfig, baseURL, endpoint string, dst any) error {
-	body, err := discogsRequest(dcfg, http.MethodGet, baseURL, endpoint)
+func discogsGetJSON(ctx context.Context, dcfg discogsConfig, baseURL, endpoint string, dst any) error {
+	body, err := discogsRequest(ctx, dcfg, http.MethodGet, baseURL, endpoint)
 	if err != nil {
 		return err
 	}
@@ -498,11 +498,48 @@ func discogsGetJSON(dcfg discogsConfig, baseURL, endpoint string, dst any) error
 	return nil
 }
 
-func discogsRequest(dcfg discogsConfig, method, baseURL, endpoint string) ([]byte, error) {
+// discogsRateLimitRetries is how many times a 429 from Discogs is retried
+// before the request fails.
+const discogsRateLimitRetries = 3
+
+// discogsRateLimitDelay is the first wait after a 429 without a usable
+// Retry-After header; it doubles on each retry.
+var discogsRateLimitDelay = 2 * time.Second
+
+func discogsRequest(ctx context.Context, dcfg discogsConfig, method, baseURL, endpoint string) ([]byte, error) {
+	delay := discogsRateLimitDelay
+	for attempt := 0; ; attempt++ {
+		body, retryAfter, err := discogsRequestOnce(ctx, dcfg, method, baseURL, endpoint)
+		statusErr, ok := errors.AsType[discogsHTTPError](err)
+		if !ok || statusErr.status != http.StatusTooManyRequests {
+			return body, err
+		}
+		if attempt == discogsRateLimitRetries {
+			return nil, discogsHTTPError{
+				status: http.StatusTooManyRequests,
+				err:    errors.New("discogs rate limit exceeded; try again in a minute"),
+			}
+		}
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// discogsRequestOnce makes a single request. On a 429 it also returns the
+// wait the server asked for via Retry-After, capped at a minute.
+func discogsRequestOnce(ctx context.Context, dcfg discogsConfig, method, baseURL, endpoint string) ([]byte, time.Duration, error) {
 	client := &http.Client{Timeout: 15 * time.Second}
-	request, err := http.NewRequest(method, strings.TrimRight(baseURL, "/")+endpoint, nil)
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(baseURL, "/")+endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("build discogs request: %w", err)
+		return nil, 0, fmt.Errorf("build discogs request: %w", err)
 	}
 
 	userAgent := strings.TrimSpace(dcfg.userAgent)
@@ -517,23 +554,33 @@ func discogsRequest(dcfg discogsConfig, method, baseURL, endpoint string) ([]byt
 
 	response, err := client.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("discogs request failed: %w", err)
+		return nil, 0, fmt.Errorf("discogs request failed: %w", err)
 	}
 	defer func() { _ = response.Body.Close() }()
 
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read discogs response: %w", err)
+		return nil, 0, fmt.Errorf("read discogs response: %w", err)
 	}
 
 	if response.StatusCode < 200 || response.StatusCode > 299 {
-		return nil, discogsHTTPError{
+		var retryAfter time.Duration
+		if secs, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && secs > 0 {
+			retryAfter = min(time.Duration(secs)*time.Second, time.Minute)
+		}
+		if response.StatusCode == http.StatusUnauthorized {
+			return nil, 0, discogsHTTPError{
+				status: response.StatusCode,
+				err:    errors.New("discogs rejected the token (401 Unauthorized); check discogs_token"),
+			}
+		}
+		return nil, retryAfter, discogsHTTPError{
 			status: response.StatusCode,
 			err:    fmt.Errorf("discogs request failed: %s", response.Status),
 		}
 	}
 
-	return body, nil
+	return body, 0, nil
 }
 
 func extractRecordSize(release discogsRelease) string {
@@ -1,8 +1,15 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestDiscogsYearUnmarshalAcceptsString(t *testing.T) {
@@ -42,3 +49,74 @@ func TestDiscogsSearchResponseUnmarshalMixedYearTypes(t *testing.T) {
 		t.Fatalf("second year = %d, want 1985", int(response.Results[1].Year))
 	}
 }
+
+func TestDiscogsRequestRetriesRateLimit(t *testing.T) {
+	saved := discogsRateLimitDelay
+	discogsRateLimitDelay = time.Millisecond
+	t.Cleanup(func() { discogsRateLimitDelay = saved })
+
+	tests := []struct {
+		name     string
+		limited  int
+		wantErr  string
+		wantHits int
+	}{
+		{"recovers", 2, "", 3},
+		{"gives up", discogsRateLimitRetries + 1, "rate limit exceeded", discogsRateLimitRetries + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(hits.Add(1)) <= tt.limited {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			_, err := discogsRequest(context.Background(), discogsConfig{}, http.MethodGet, srv.URL, "/releases/1")
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("discogsRequest: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if got := int(hits.Load()); got != tt.wantHits {
+				t.Errorf("requests = %d, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestDiscogsRequestRateLimitWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		cancel()
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	_, err := discogsRequest(ctx, discogsConfig{}, http.MethodGet, srv.URL, "/releases/1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("discogsRequest took %v after cancel, want it to stop waiting", elapsed)
+	}
+}
+
+func TestDiscogsRequestUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	_, err := discogsRequest(context.Background(), discogsConfig{token: "bad"}, http.MethodGet, srv.URL, "/database/search")
+	if err == nil || !strings.Contains(err.Error(), "check discogs_token") {
+		t.Errorf("err = %v, want token hint", err)
+	}
+}
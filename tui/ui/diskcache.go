@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// diskCache keeps raw cover image bytes between runs, one file per URL
+// named by the URL's SHA-256. Entries older than ttl are ignored, and the
+// oldest files are removed once the directory exceeds maxBytes.
+type diskCache struct {
+	dir      string
+	maxBytes int64
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+func newDiskCache(dir string, maxBytes int64, ttl time.Duration) *diskCache {
+	return &diskCache{dir: dir, maxBytes: maxBytes, ttl: ttl, now: time.Now}
+}
+
+// defaultImageCacheDir is $XDG_CACHE_HOME/myrecords/images, falling back
+// to ~/.cache/myrecords/images.
+func defaultImageCacheDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil || base == "" {
+		return ""
+	}
+	return filepath.Join(base, "myrecords", "images")
+}
+
+func (c *diskCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// isCacheEntry reports whether name is one the cache writes: a lowercase
+// hex SHA-256. prune leaves everything else alone, so a cache dir pointed
+// at a shared directory never loses unrelated files or another fetch's
+// in-flight temp file.
+func isCacheEntry(name string) bool {
+	if len(name) != 2*sha256.Size {
+		return false
+	}
+	for _, r := range name {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *diskCache) expired(mod time.Time) bool {
+	return c.ttl > 0 && c.now().Sub(mod) > c.ttl
+}
+
+// get returns the cached bytes for url and their sniffed content type.
+func (c *diskCache) get(url string) ([]byte, string, bool) {
+	if c == nil {
+		return nil, "", false
+	}
+	p := c.path(url)
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, "", false
+	}
+	if c.expired(info.ModTime()) {
+		_ = os.Remove(p)
+		return nil, "", false
+	}
+	raw, err := os.ReadFile(p)
+	if err != nil || len(raw) == 0 {
+		return nil, "", false
+	}
+	return raw, http.DetectContentType(raw), true
+}
+
+// put stores raw for url and trims the cache back under its limits. Cache
+// failures are not worth surfacing; the image was already fetched.
+func (c *diskCache) put(url string, raw []byte) {
+	if c == nil || len(raw) == 0 {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(raw)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmp.Name(), c.path(url)) != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	c.prune()
+}
+
+func (c *diskCache) prune() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		path string
+		size int64
+		mod  time.Time
+	}
+	var files []file
+	var total int64
+	for _, e := range entries {
+		if !isCacheEntry(e.Name()) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		p := filepath.Join(c.dir, e.Name())
+		if c.expired(info.ModTime()) {
+			_ = os.Remove(p)
+			continue
+		}
+		files = append(files, file{path: p, size: info.Size(), mod: info.ModTime()})
+		total += info.Size()
+	}
+	if c.maxBytes <= 0 || total <= c.maxBytes {
+		return
+	}
+	slices.SortFunc(files, func(a, b file) int { return cmp.Compare(a.mod.UnixNano(), b.mod.UnixNano()) })
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+}
+
+// fetch returns url's bytes from the cache, or downloads and caches them.
+func (c *diskCache) fetch(ctx context.Context, url string) ([]byte, string, error) {
+	if raw, ct, ok := c.get(url); ok {
+		return raw, ct, nil
+	}
+	raw, ct, err := fetchImageBytes(ctx, url)
+	if err != nil {
+		return nil, "", err
+	}
+	c.put(url, raw)
+	return raw, ct, nil
+}
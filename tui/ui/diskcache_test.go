@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheServesRepeatFetches(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		_ = png.Encode(w, testImage())
+	}))
+	defer server.Close()
+
+	c := newDiskCache(t.TempDir(), 1<<20, time.Hour)
+	url := server.URL + "/cover.png"
+	for range 2 {
+		img, _, err := fetchImage(context.Background(), c, url)
+		if err != nil || img == nil {
+			t.Fatalf("fetchImage: %v", err)
+		}
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("HTTP hits = %d, want 1 (second fetch from disk)", got)
+	}
+	if _, err := os.Stat(c.path(url)); err != nil {
+		t.Errorf("cache file missing: %v", err)
+	}
+	if _, ct, ok := c.get(url); !ok || ct != "image/png" {
+		t.Errorf("get = %q, %v; want image/png hit", ct, ok)
+	}
+}
+
+func TestDiskCacheExpiresAfterTTL(t *testing.T) {
+	c := newDiskCache(t.TempDir(), 0, time.Hour)
+	c.put("http://example.com/a.jpg", []byte("jpegdata"))
+
+	c.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, _, ok := c.get("http://example.com/a.jpg"); ok {
+		t.Error("entry older than the TTL should miss")
+	}
+	if _, err := os.Stat(c.path("http://example.com/a.jpg")); !os.IsNotExist(err) {
+		t.Error("expired entry should be removed")
+	}
+}
+
+func TestDiskCachePrunesOldestOverLimit(t *testing.T) {
+	dir := t.TempDir()
+	c := newDiskCache(dir, 25, 0)
+	base := time.Now().Add(-time.Hour)
+	for i, url := range []string{"http://x/1", "http://x/2", "http://x/3"} {
+		c.put(url, []byte("0123456789"))
+		mod := base.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(c.path(url), mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.prune()
+
+	if _, _, ok := c.get("http://x/1"); ok {
+		t.Error("oldest entry should be evicted once over the size limit")
+	}
+	for _, url := range []string{"http://x/2", "http://x/3"} {
+		if _, _, ok := c.get(url); !ok {
+			t.Errorf("%s should still be cached", url)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".tmp-") {
+			t.Errorf("temp file left behind: %s", e.Name())
+		}
+	}
+}
+
+func TestDiskCachePruneSkipsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	c := newDiskCache(dir, 5, time.Hour)
+	old := time.Now().Add(-24 * time.Hour)
+	foreign := []string{"notes.txt", ".tmp-123", strings.Repeat("A", 64), strings.Repeat("0", 63)}
+	for _, name := range foreign {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("0123456789"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.put("http://x/1", []byte("0123456789"))
+
+	for _, name := range foreign {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should be left alone: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(c.path("http://x/1")); !os.IsNotExist(err) {
+		t.Error("the cache's own entry over the limit should still be pruned")
+	}
+}
+
+func TestIsCacheEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{filepath.Base(newDiskCache("", 0, 0).path("http://x/1")), true},
+		{strings.Repeat("f", 64), true},
+		{strings.Repeat("F", 64), false},
+		{strings.Repeat("f", 65), false},
+		{".tmp-123", false},
+		{"cover.jpg", false},
+	}
+	for _, tt := range tests {
+		if got := isCacheEntry(tt.name); got != tt.want {
+			t.Errorf("isCacheEntry(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNilDiskCache(t *testing.T) {
+	var c *diskCache
+	if _, _, ok := c.get("http://x/1"); ok {
+		t.Error("nil cache should always miss")
+	}
+	c.put("http://x/1", []byte("data"))
+}
@@ -0,0 +1,248 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"my-record-collection-tui/db"
+)
+
+type duplicatesFoundMsg struct {
+	groups []db.DuplicateGroup
+	err    error
+}
+
+type duplicateDeletedMsg struct {
+	id  string
+	err error
+}
+
+// findDuplicates scans the whole collection rather than m.records, so a
+// search or filter never hides a duplicate.
+func findDuplicates(ctx context.Context, store db.Store) tea.Cmd {
+	return func() tea.Msg {
+		records, err := store.List(ctx)
+		if err != nil {
+			return duplicatesFoundMsg{err: err}
+		}
+		return duplicatesFoundMsg{groups: db.FindDuplicates(records)}
+	}
+}
+
+func deleteDuplicate(ctx context.Context, store db.Store, id string) tea.Cmd {
+	return func() tea.Msg {
+		return duplicateDeletedMsg{id: id, err: store.Delete(ctx, id)}
+	}
+}
+
+// duplicateRecords flattens the groups in display order; dupCursor indexes
+// into it.
+func duplicateRecords(groups []db.DuplicateGroup) []db.Record {
+	var recs []db.Record
+	for _, g := range groups {
+		recs = append(recs, g.Records...)
+	}
+	return recs
+}
+
+// duplicateRow is one line of the view; record is the index into
+// duplicateRecords, or -1 for headings and spacers.
+type duplicateRow struct {
+	text   string
+	record int
+}
+
+func duplicateLine(r db.Record) string {
+	catalog := "—"
+	if r.CatalogNumber != nil && *r.CatalogNumber != "" {
+		catalog = *r.CatalogNumber
+	}
+	source := r.DataSource
+	if source == "" {
+		source = "—"
+	}
+	return fmt.Sprintf("%-6s %s  %s  %-8s added %s",
+		r.YearString(), truncPad(r.LabelString(), 20), truncPad(catalog, 14), source,
+		r.CreatedAt.Local().Format("2006-01-02"))
+}
+
+func duplicateRows(groups []db.DuplicateGroup) []duplicateRow {
+	var rows []duplicateRow
+	idx := 0
+	for _, g := range groups {
+		first := g.Records[0]
+		heading := fmt.Sprintf("%s — %s (%s)", first.ArtistName, first.AlbumTitle, strings.Join(g.Reasons, ", "))
+		rows = append(rows, duplicateRow{text: heading, record: -1})
+		for _, r := range g.Records {
+			rows = append(rows, duplicateRow{text: duplicateLine(r), record: idx})
+			idx++
+		}
+		rows = append(rows, duplicateRow{record: -1})
+	}
+	return rows
+}
+
+// withoutDuplicate drops the record with id from groups, along with any
+// group it leaves with a single record.
+func withoutDuplicate(groups []db.DuplicateGroup, id string) []db.DuplicateGroup {
+	var out []db.DuplicateGroup
+	for _, g := range groups {
+		var recs []db.Record
+		for _, r := range g.Records {
+			if r.RecordID != id {
+				recs = append(recs, r)
+			}
+		}
+		if len(recs) > 1 {
+			out = append(out, db.DuplicateGroup{Reasons: g.Reasons, Records: recs})
+		}
+	}
+	return out
+}
+
+func (m Model) openDuplicates() (tea.Model, tea.Cmd) {
+	m.view = duplicatesView
+	m.dupCursor = 0
+	m.dupOffset = 0
+	m.dupLoading = true
+	m.dupErr = ""
+	m.dupDeleteConfirm = false
+	return m, findDuplicates(m.ctx, m.store)
+}
+
+func (m Model) handleDuplicatesFound(msg duplicatesFoundMsg) (tea.Model, tea.Cmd) {
+	m.dupLoading = false
+	if msg.err != nil {
+		m.dupErr = msg.err.Error()
+		return m, nil
+	}
+	m.dupErr = ""
+	m.dupGroups = msg.groups
+	m.dupCursor = min(m.dupCursor, max(0, len(duplicateRecords(m.dupGroups))-1))
+	m.dupOffset = m.duplicatesScroll()
+	return m, nil
+}
+
+func (m Model) handleDuplicateDeleted(msg duplicateDeletedMsg) (tea.Model, tea.Cmd) {
+	m.dupDeleting = false
+	if msg.err != nil {
+		m.dupErr = msg.err.Error()
+		return m, nil
+	}
+	m.dupErr = ""
+	m.dupGroups = withoutDuplicate(m.dupGroups, msg.id)
+	m.dupCursor = min(m.dupCursor, max(0, len(duplicateRecords(m.dupGroups))-1))
+	m.dupOffset = m.duplicatesScroll()
+	status := m.setStatus("Duplicate deleted.")
+	m.loading = true
+	return m, tea.Batch(status, m.fetchRecords(true))
+}
+
+func (m Model) handleDuplicatesKey(key string) (tea.Model, tea.Cmd) {
+	if m.keyDisabled(duplicatesKeys, key) {
+		return m, m.setStatus(readOnlyStatus)
+	}
+	recs := duplicateRecords(m.dupGroups)
+	if m.dupDeleteConfirm {
+		m.dupDeleteConfirm = false
+		if (key == "y" || key == "d") && m.dupCursor < len(recs) {
+			m.dupDeleting = true
+			return m, deleteDuplicate(m.ctx, m.store, recs[m.dupCursor].RecordID)
+		}
+		return m, nil
+	}
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "q", "esc", "D":
+		m.view = listView
+	case "up":
+		if m.dupCursor > 0 {
+			m.dupCursor--
+		}
+	case "down":
+		if m.dupCursor < len(recs)-1 {
+			m.dupCursor++
+		}
+	case "enter":
+		if m.dupCursor < len(recs) {
+			id := recs[m.dupCursor].RecordID
+			return m.openMatching(func(r db.Record) bool { return r.RecordID == id })
+		}
+	case "d":
+		if m.dupCursor < len(recs) && !m.dupDeleting {
+			m.dupDeleteConfirm = true
+		}
+	case "r":
+		return m.openDuplicates()
+	}
+	m.dupOffset = m.duplicatesScroll()
+	return m, nil
+}
+
+// duplicatesScroll returns an offset that keeps the cursor's row visible,
+// along with its group heading where there's room.
+func (m Model) duplicatesScroll() int {
+	rows := duplicateRows(m.dupGroups)
+	line := 0
+	for i, r := range rows {
+		if r.record == m.dupCursor {
+			line = i
+			break
+		}
+	}
+	visible := m.listVisibleRows()
+	offset := m.dupOffset
+	if line < offset+1 {
+		offset = max(0, line-1)
+	} else if line >= offset+visible {
+		offset = line - visible + 1
+	}
+	return offset
+}
+
+func (m Model) renderDuplicates() string {
+	var b strings.Builder
+	title := m.styles.title.Render("♫ Duplicates")
+	counts := fmt.Sprintf("%d groups · %d records", len(m.dupGroups), len(duplicateRecords(m.dupGroups)))
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", m.styles.statusBar.Render(counts)))
+	b.WriteString("\n\n")
+
+	rows := duplicateRows(m.dupGroups)
+	switch {
+	case m.dupLoading:
+		b.WriteString("  Loading...\n")
+	case m.dupErr != "":
+		b.WriteString(m.styles.error.Render("  "+m.dupErr) + "\n")
+	case len(rows) == 0:
+		b.WriteString("  No duplicates found.\n")
+	}
+	if !m.dupLoading {
+		visible := m.listVisibleRows()
+		start := min(m.dupOffset, max(0, len(rows)-1))
+		end := min(start+visible, len(rows))
+		for _, row := range rows[start:end] {
+			switch {
+			case row.record < 0 && row.text != "":
+				b.WriteString(m.styles.label.Width(0).Render("  " + row.text))
+			case row.record == m.dupCursor:
+				b.WriteString(m.styles.selectedRow.Render("    " + truncPad(row.text, max(m.width-6, 20))))
+			case row.record >= 0:
+				b.WriteString(m.styles.normalRow.Render("    " + row.text))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if recs := duplicateRecords(m.dupGroups); m.dupDeleteConfirm && m.dupCursor < len(recs) {
+		rec := recs[m.dupCursor]
+		b.WriteString(m.styles.error.Render(fmt.Sprintf("  Delete %q added %s? y/n",
+			rec.AlbumTitle, rec.CreatedAt.Local().Format("2006-01-02"))))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.helpLine(duplicatesKeys))
+	return b.String()
+}
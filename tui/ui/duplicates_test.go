@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+func duplicateTestRecords() []db.Record {
+	recs := testRecords()
+	dup := recs[0]
+	dup.RecordID = "dup"
+	dup.DataSource = "manual"
+	dup.CreatedAt = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return append(recs, dup)
+}
+
+func TestDuplicatesViewLoadsAndOpens(t *testing.T) {
+	recs := duplicateTestRecords()
+	m := newTestModel(recs)
+
+	updated, cmd := m.Update(keyMsg("D"))
+	m = updated.(Model)
+	if m.view != duplicatesView || !m.dupLoading || cmd == nil {
+		t.Fatal("D should open the duplicates view and scan the collection")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	body := m.View().Content
+	for _, want := range []string{"1 groups · 2 records", recs[0].ArtistName, "same artist and album", "manual", "2026-03-01"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("duplicates view should contain %q:\n%s", want, body)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	updated, _ = updated.(Model).Update(keyMsg("enter"))
+	m = updated.(Model)
+	rec, _ := m.selectedRecord()
+	if m.view != detailView || rec.RecordID != "dup" {
+		t.Errorf("enter opened %q in view %d, want the dup record's detail", rec.RecordID, m.view)
+	}
+}
+
+func TestDuplicatesDelete(t *testing.T) {
+	m := newTestModel(duplicateTestRecords())
+	updated, cmd := m.Update(keyMsg("D"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+
+	updated, _ = m.Update(keyMsg("d"))
+	m = updated.(Model)
+	if !m.dupDeleteConfirm || !strings.Contains(m.View().Content, "y/n") {
+		t.Fatal("d should ask before deleting")
+	}
+	updated, cmd = m.Update(keyMsg("y"))
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("y should delete the record under the cursor")
+	}
+	msg := cmd().(duplicateDeletedMsg)
+	if msg.id != m.dupGroups[0].Records[0].RecordID {
+		t.Errorf("deleted %q, want the first record of the group", msg.id)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(m.dupGroups) != 0 || !strings.Contains(m.View().Content, "No duplicates found.") {
+		t.Error("a group left with one record should disappear")
+	}
+}
+
+func TestDuplicatesReadOnly(t *testing.T) {
+	m := newTestModel(duplicateTestRecords()).WithReadOnly(true)
+	updated, cmd := m.Update(keyMsg("D"))
+	updated, _ = updated.(Model).Update(cmd())
+	updated, _ = updated.(Model).Update(keyMsg("d"))
+	if updated.(Model).dupDeleteConfirm {
+		t.Error("d should be disabled in read-only mode")
+	}
+}
+
+func TestDuplicatesError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = duplicatesView
+	updated, _ := m.Update(duplicatesFoundMsg{err: errors.New("query failed")})
+	if !strings.Contains(updated.(Model).View().Content, "query failed") {
+		t.Error("duplicates view should show the load error")
+	}
+}
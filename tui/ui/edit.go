@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type recordUpdatedMsg struct {
+	err error
+}
+
+func updateRecord(ctx context.Context, store db.Store, r db.Record) tea.Cmd {
+	return func() tea.Msg {
+		return recordUpdatedMsg{err: store.Update(ctx, r)}
+	}
+}
+
+// manualFormValues renders r as the manual form's field text, in
+// manualFieldLabels order.
+func manualFormValues(r db.Record) [manualFieldCount]string {
+	var year string
+	if r.YearReleased != nil {
+		year = strconv.Itoa(*r.YearReleased)
+	}
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return [manualFieldCount]string{
+		r.ArtistName,
+		r.AlbumTitle,
+		year,
+		deref(r.LabelName),
+		deref(r.CatalogNumber),
+		strings.Join(r.Genres, ", "),
+		strings.Join(r.Tags, ", "),
+		deref(r.RecordSize),
+		deref(r.VinylColor),
+	}
+}
+
+// startManualEdit opens the manual form pre-filled with rec; saving it
+// updates rec instead of creating a new record.
+func (m *Model) startManualEdit(rec db.Record) {
+	m.resetManualAddState()
+	v := manualFormValues(rec)
+	m.manualArtist, m.manualAlbum, m.manualYear = v[0], v[1], v[2]
+	m.manualLabel, m.manualCatalog, m.manualGenres = v[3], v[4], v[5]
+	m.manualTags, m.manualSize, m.manualColor = v[6], v[7], v[8]
+	m.manualEditing = &rec
+	m.view = addManualView
+}
+
+// manualUnchanged reports whether rec, built from the edit form, still
+// matches the record being edited.
+func (m Model) manualUnchanged(rec db.Record) bool {
+	return m.manualEditing != nil && manualFormValues(rec) == manualFormValues(*m.manualEditing)
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func editTestRecord() db.Record {
+	return db.Record{
+		RecordID:      "1",
+		ArtistName:    "Miles Davis",
+		AlbumTitle:    "Kind of Blue",
+		YearReleased:  new(1959),
+		LabelName:     new("Columbia"),
+		CoverImageURL: new("https://example.com/kob.jpg"),
+		Genres:        []string{"Jazz", "Modal"},
+		DataSource:    "discogs",
+	}
+}
+
+func TestEditPrefillsForm(t *testing.T) {
+	m := newTestModel([]db.Record{editTestRecord()})
+	updated, _ := m.Update(keyMsg("e"))
+	m = updated.(Model)
+	if m.view != addManualView || m.manualEditing == nil {
+		t.Fatal("e should open the form in edit mode")
+	}
+	if m.manualArtist != "Miles Davis" || m.manualYear != "1959" || m.manualLabel != "Columbia" || m.manualGenres != "Jazz, Modal" {
+		t.Errorf("form not pre-filled: artist=%q year=%q label=%q genres=%q", m.manualArtist, m.manualYear, m.manualLabel, m.manualGenres)
+	}
+	if !strings.Contains(m.renderAddManual(), "Edit Record") {
+		t.Error("form title should say it is editing")
+	}
+}
+
+func TestEditUnchangedSkipsSave(t *testing.T) {
+	m := newTestModel([]db.Record{editTestRecord()})
+	updated, _ := m.Update(keyMsg("e"))
+	updated, cmd := updated.(Model).Update(keyMsg("enter"))
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("saving an unchanged record should not hit the store")
+	}
+	if m.view != listView || m.manualEditing != nil {
+		t.Error("unchanged save should return to the list")
+	}
+}
+
+func TestEditSavesChanges(t *testing.T) {
+	m := newTestModel([]db.Record{editTestRecord()})
+	updated, _ := m.Update(keyMsg("e"))
+	m = updated.(Model)
+	m.manualLabel = ""
+	m.manualColor = "Blue"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if cmd == nil || !m.manualSaving {
+		t.Fatal("changed form should start saving")
+	}
+	store := m.store.(*mockStore)
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(store.created) != 0 || len(store.updated) != 1 {
+		t.Fatalf("created = %d, updated = %d; want 0, 1", len(store.created), len(store.updated))
+	}
+	got := store.updated[0]
+	if got.RecordID != "1" || got.LabelName != nil || got.VinylColor == nil || *got.VinylColor != "Blue" {
+		t.Errorf("updated record = %+v", got)
+	}
+	if got.CoverImageURL == nil || got.DataSource != "discogs" {
+		t.Error("fields outside the form should be carried over")
+	}
+	if m.view != listView || m.status != "Record updated." || cmd == nil {
+		t.Error("successful update should return to the list and reload")
+	}
+}
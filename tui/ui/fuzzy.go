@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+// fuzzyMatch reports whether the runes of pattern appear in text in order,
+// ignoring case. The score rewards consecutive runs and matches at word
+// starts and penalizes gaps; positions are the matched rune indexes in text.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	bestScore, best := 0, []int(nil)
+	// Greedy from every possible start so "blue" in "Kind of Blue" isn't
+	// pinned to an early stray 'b'.
+	for start, r := range t {
+		if r != p[0] {
+			continue
+		}
+		score, positions := 0, make([]int, 0, len(p))
+		for i := start; i < len(t) && len(positions) < len(p); i++ {
+			if t[i] != p[len(positions)] {
+				continue
+			}
+			score++
+			if n := len(positions); n > 0 && positions[n-1] == i-1 {
+				score += 5
+			}
+			if i == 0 || !isWordRune(t[i-1]) {
+				score += 3
+			}
+			positions = append(positions, i)
+		}
+		if len(positions) < len(p) {
+			break
+		}
+		score -= positions[len(positions)-1] - positions[0] + 1 - len(p)
+		if best == nil || score > bestScore {
+			bestScore, best = score, positions
+		}
+	}
+	return bestScore, best, best != nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// fuzzyText is what live search matches against: artist, a space, album.
+func fuzzyText(r db.Record) string {
+	return r.ArtistName + " " + r.AlbumTitle
+}
+
+// fuzzyFilter keeps the records matching query's free text as a fuzzy
+// pattern (tag: filters still match exactly), best match first. Ties keep
+// the current column sort. The returned map holds each hit's matched rune
+// positions in fuzzyText, keyed by record ID.
+func fuzzyFilter(recs []db.Record, query string, col sortColumn, desc, ignoreArticles bool) ([]db.Record, map[string][]int) {
+	q := db.ParseSearchQuery(query)
+	pattern := strings.Join(q.Terms, "")
+	type hit struct {
+		rec   db.Record
+		score int
+	}
+	var hits []hit
+	positions := make(map[string][]int)
+	for _, r := range recs {
+		if !(db.SearchQuery{Tags: q.Tags}).Matches(r) {
+			continue
+		}
+		score, pos, ok := fuzzyMatch(pattern, fuzzyText(r))
+		if !ok {
+			continue
+		}
+		hits = append(hits, hit{r, score})
+		positions[r.RecordID] = pos
+	}
+	out := make([]db.Record, len(hits))
+	for i, h := range hits {
+		out[i] = h.rec
+	}
+	sortRecords(out, col, desc, ignoreArticles)
+	scores := make(map[string]int, len(hits))
+	for _, h := range hits {
+		scores[h.rec.RecordID] = h.score
+	}
+	slices.SortStableFunc(out, func(a, b db.Record) int { return scores[b.RecordID] - scores[a.RecordID] })
+	return out, positions
+}
+
+// highlightCell pads text to width like truncPad, rendering the runes at
+// positions with hl and the rest with base.
+func highlightCell(text string, positions []int, width int, base, hl lipgloss.Style) string {
+	cell := []rune(truncPad(text, width))
+	if len(positions) == 0 {
+		return base.Render(string(cell))
+	}
+	// The ellipsis truncPad adds isn't part of the match.
+	limit := len(cell)
+	if ansi.StringWidth(text) > width && width > 1 {
+		limit = len([]rune(ansi.Truncate(text, width, "…"))) - 1
+	}
+	var b strings.Builder
+	start := 0
+	for start < len(cell) {
+		matched := start < limit && slices.Contains(positions, start)
+		end := start + 1
+		for end < len(cell) && (end < limit && slices.Contains(positions, end)) == matched {
+			end++
+		}
+		style := base
+		if matched {
+			style = hl
+		}
+		b.WriteString(style.Render(string(cell[start:end])))
+		start = end
+	}
+	return b.String()
+}
+
+// highlightedRow renders a list row with the live-search match positions
+// (indexes into fuzzyText) picked out in the artist and album cells.
+func (m Model) highlightedRow(i int, rec db.Record, positions []int, colW []int, base lipgloss.Style) string {
+	hl := m.styles.match.Inherit(base)
+	albumStart := len([]rune(rec.ArtistName)) + 1
+	var artistPos, albumPos []int
+	for _, p := range positions {
+		switch {
+		case p < albumStart-1:
+			artistPos = append(artistPos, p)
+		case p >= albumStart:
+			albumPos = append(albumPos, p-albumStart)
+		}
+	}
+	cols := m.shownColumns()
+	cells := make([]string, len(cols))
+	for j, c := range cols {
+		switch c.name {
+		case "artist":
+			cells[j] = highlightCell(c.value(rec), artistPos, colW[j], base, hl)
+		case "album":
+			cells[j] = highlightCell(c.value(rec), albumPos, colW[j], base, hl)
+		case artistAlbumColumn.name:
+			// The album starts after " — " rather than a single space.
+			combined := slices.Clone(artistPos)
+			for _, p := range albumPos {
+				combined = append(combined, p+albumStart+2)
+			}
+			cells[j] = highlightCell(c.value(rec), combined, colW[j], base, hl)
+		default:
+			cells[j] = base.Render(truncPad(c.value(rec), colW[j]))
+		}
+	}
+	return base.Render(m.selectionCell(rec.RecordID)+m.lineNumberCell(i)) +
+		strings.Join(cells, base.Render(" "))
+}
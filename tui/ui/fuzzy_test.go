@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		ok        bool
+		positions []int
+	}{
+		{"", "anything", true, nil},
+		{"kob", "Kind of Blue", true, []int{0, 5, 8}},
+		{"blue", "Big Kind of Blue", true, []int{12, 13, 14, 15}},
+		{"MD", "miles davis", true, []int{0, 6}},
+		{"xyz", "Kind of Blue", false, nil},
+		{"eulb", "Blue", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.text, func(t *testing.T) {
+			_, pos, ok := fuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !slices.Equal(pos, tt.positions) {
+				t.Errorf("positions = %v, want %v", pos, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchRanksConsecutiveHigher(t *testing.T) {
+	tight, _, _ := fuzzyMatch("blue", "Blue Train")
+	loose, _, _ := fuzzyMatch("blue", "Ballads Like Untold Echoes")
+	if tight <= loose {
+		t.Errorf("consecutive score %d should beat scattered %d", tight, loose)
+	}
+}
+
+func TestFuzzyFilterRanksAndKeepsTags(t *testing.T) {
+	recs := []db.Record{
+		{RecordID: "a", ArtistName: "Bill Evans", AlbumTitle: "Sunday at the Village Vanguard"},
+		{RecordID: "b", ArtistName: "John Coltrane", AlbumTitle: "Blue Train", Tags: []string{"gift"}},
+		{RecordID: "c", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue"},
+		{RecordID: "d", ArtistName: "Sonny Rollins", AlbumTitle: "Saxophone Colossus"},
+	}
+	got, positions := fuzzyFilter(recs, "blue", sortArtist, false, false)
+	// Bill Evans matches as a scattered subsequence, so it ranks last;
+	// the two exact "Blue" hits tie and keep artist order.
+	if ids := recordIDs(got); ids != "b,c,a" {
+		t.Errorf("ids = %s, want b,c,a", ids)
+	}
+	if len(positions["c"]) != 4 {
+		t.Errorf("positions[c] = %v, want 4 matches", positions["c"])
+	}
+
+	got, _ = fuzzyFilter(recs, "blue tag:gift", sortArtist, false, false)
+	if ids := recordIDs(got); ids != "b" {
+		t.Errorf("tag filter ids = %s, want b", ids)
+	}
+}
+
+func TestSearchFiltersWhileTyping(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.cursor = 2
+	updated, _ := m.Update(keyMsg("/"))
+	m = updated.(Model)
+	for _, k := range "klue" {
+		updated, _ = m.Update(keyMsg(string(k)))
+		m = updated.(Model)
+	}
+	if len(m.filtered) != 1 || m.filtered[0].AlbumTitle != "Kind of Blue" {
+		t.Fatalf("filtered = %s, want only Kind of Blue", recordIDs(m.filtered))
+	}
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+
+	updated, _ = m.Update(keyMsg("backspace"))
+	m = updated.(Model)
+	if m.search != "klu" {
+		t.Errorf("search = %q, want klu", m.search)
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if len(m.filtered) != len(testRecords()) || m.searchMatches != nil {
+		t.Errorf("esc should restore the full list, got %d", len(m.filtered))
+	}
+}
+
+func TestLiveSearchHighlightsMatches(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.searching = true
+	m.search = "kob"
+	m = m.liveSearch()
+
+	view := m.renderList()
+	if !strings.Contains(ansi.Strip(view), "Kind of Blue") {
+		t.Fatal("row text should be intact after highlighting")
+	}
+	hl := m.styles.match.Inherit(m.styles.selectedRow)
+	if !strings.Contains(view, hl.Render("K")) {
+		t.Error("matched rune should use the match style")
+	}
+}
+
+func TestHighlightCellTruncates(t *testing.T) {
+	base := newTestModel(nil).styles.normalRow
+	got := ansi.Strip(highlightCell("Thelonious Monk", []int{0, 14}, 8, base, base.Bold(true)))
+	if got != "Theloni…" {
+		t.Errorf("cell = %q, want %q", got, "Theloni…")
+	}
+}
+
+func TestHighlightCellWideCharacters(t *testing.T) {
+	base := newTestModel(nil).styles.normalRow
+	got := ansi.Strip(highlightCell("坂本龍一", []int{0, 3}, 6, base, base.Bold(true)))
+	if got != "坂本… " || ansi.StringWidth(got) != 6 {
+		t.Errorf("cell = %q (width %d), want %q", got, ansi.StringWidth(got), "坂本… ")
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type genresLoadedMsg struct {
+	genres []db.Count
+	err    error
+}
+
+func loadGenres(ctx context.Context, store db.Store) tea.Cmd {
+	return func() tea.Msg {
+		genres, err := store.Genres(ctx)
+		return genresLoadedMsg{genres: genres, err: err}
+	}
+}
+
+// sortCountsBySize orders counts biggest first, ties by name, the order
+// Store.Genres returns.
+func sortCountsBySize(counts []db.Count) {
+	slices.SortStableFunc(counts, func(a, b db.Count) int {
+		return cmp.Or(cmp.Compare(b.N, a.N), strings.Compare(a.Name, b.Name))
+	})
+}
+
+func sortCountsByName(counts []db.Count) {
+	slices.SortStableFunc(counts, func(a, b db.Count) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), strings.Compare(a.Name, b.Name))
+	})
+}
+
+func sortGenres(counts []db.Count, byName bool) {
+	if byName {
+		sortCountsByName(counts)
+	} else {
+		sortCountsBySize(counts)
+	}
+}
+
+// genreFilterRef is the genre filter in the form countBrowser.loaded
+// takes.
+func (m Model) genreFilterRef() *string {
+	if m.genreFilter == "" {
+		return nil
+	}
+	return &m.genreFilter
+}
+
+func (m Model) openGenres() (tea.Model, tea.Cmd) {
+	m.view = genresView
+	m.genreBrowser.load()
+	m.deleteConfirm = false
+	return m, loadGenres(m.ctx, m.store)
+}
+
+func (m Model) handleGenresLoaded(msg genresLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil {
+		sortGenres(msg.genres, m.genresByName)
+	}
+	m.genreBrowser.loaded(msg.genres, msg.err, m.genreFilterRef(), m.listVisibleRows())
+	return m, nil
+}
+
+func (m Model) handleGenresKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "q", "esc", "b":
+		m.view = listView
+	case "up":
+		m.genreBrowser.move(-1, m.listVisibleRows())
+	case "down":
+		m.genreBrowser.move(1, m.listVisibleRows())
+	case "o":
+		// Re-sort in place, keeping the cursor on the same genre.
+		current, ok := m.genreBrowser.selected()
+		m.genresByName = !m.genresByName
+		sortGenres(m.genreBrowser.counts, m.genresByName)
+		if ok {
+			m.genreBrowser.loaded(m.genreBrowser.counts, nil, &current.Name, m.listVisibleRows())
+		}
+	case "enter":
+		if c, ok := m.genreBrowser.selected(); ok {
+			m.genreFilter = c.Name
+			m.view = listView
+			m.loading = true
+			return m, m.fetchRecords(false)
+		}
+	case "r":
+		return m.openGenres()
+	}
+	return m, nil
+}
+
+func (m Model) renderGenres() string {
+	noun := "genres · by count"
+	if m.genresByName {
+		noun = "genres · by name"
+	}
+	return m.renderCountBrowser(m.genreBrowser, "Genres", noun, func(g string) string { return g }, genresKeys)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+func genreNames(counts []db.Count) string {
+	var names []string
+	for _, c := range counts {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSortCounts(t *testing.T) {
+	counts := []db.Count{{Name: "rock", N: 2}, {Name: "Jazz", N: 3}, {Name: "Blues", N: 2}}
+	sortCountsBySize(counts)
+	if got := genreNames(counts); got != "Jazz,Blues,rock" {
+		t.Errorf("by size = %s, want Jazz,Blues,rock", got)
+	}
+	sortCountsByName(counts)
+	if got := genreNames(counts); got != "Blues,Jazz,rock" {
+		t.Errorf("by name = %s, want Blues,Jazz,rock", got)
+	}
+}
+
+func TestGenresViewFiltersList(t *testing.T) {
+	m := newTestModel(genreRecords())
+
+	updated, cmd := m.Update(keyMsg("b"))
+	m = updated.(Model)
+	if m.view != genresView || cmd == nil {
+		t.Fatal("b should open the genre browser and load the counts")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if got := genreNames(m.genreBrowser.counts); got != "Jazz,Rock" {
+		t.Fatalf("genres = %s, want Jazz,Rock biggest first", got)
+	}
+	if view := m.View().Content; !strings.Contains(view, "2 genres · by count") {
+		t.Errorf("view should show the genre count and order:\n%s", view)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	updated, cmd = updated.(Model).Update(keyMsg("enter"))
+	m = updated.(Model)
+	if m.view != listView || m.genreFilter != "Rock" || cmd == nil {
+		t.Fatalf("enter should filter the list to Rock, got view %d genre %q", m.view, m.genreFilter)
+	}
+	updated, _ = m.Update(cmd())
+	if n := len(updated.(Model).filtered); n != 2 {
+		t.Errorf("filtered = %d records, want the 2 Rock records", n)
+	}
+}
+
+func TestGenresSortToggleKeepsCursor(t *testing.T) {
+	recs := append(genreRecords(), db.Record{RecordID: "5", ArtistName: "B.B. King", Genres: []string{"Blues"}})
+	m := newTestModel(recs)
+	m.genreFilter = "Rock"
+	updated, cmd := m.Update(keyMsg("b"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if c, _ := m.genreBrowser.selected(); c.Name != "Rock" {
+		t.Fatalf("cursor on %q, want the filtered genre Rock", c.Name)
+	}
+
+	updated, _ = m.Update(keyMsg("o"))
+	m = updated.(Model)
+	if got := genreNames(m.genreBrowser.counts); got != "Blues,Jazz,Rock" {
+		t.Errorf("by name = %s, want Blues,Jazz,Rock", got)
+	}
+	if c, _ := m.genreBrowser.selected(); c.Name != "Rock" {
+		t.Errorf("cursor moved to %q after sorting, want Rock", c.Name)
+	}
+
+	updated, _ = m.Update(keyMsg("o"))
+	if got := genreNames(updated.(Model).genreBrowser.counts); got != "Jazz,Rock,Blues" {
+		t.Errorf("by count = %s, want Jazz,Rock,Blues", got)
+	}
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+const (
+	defaultHealthInterval = 30 * time.Second
+	healthPingTimeout     = 5 * time.Second
+)
+
+// healthTickMsg starts the next background ping.
+type healthTickMsg struct{}
+
+type healthCheckedMsg struct {
+	err error
+}
+
+// WithHealthCheck sets how often the database is pinged in the background;
+// d <= 0 turns the check off.
+func (m Model) WithHealthCheck(d time.Duration) Model {
+	m.healthInterval = d
+	return m
+}
+
+func healthTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return healthTickMsg{} })
+}
+
+func pingStore(ctx context.Context, store db.Store) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+		defer cancel()
+		return healthCheckedMsg{err: store.Ping(ctx)}
+	}
+}
+
+// startHealthCheck begins the ping loop once a store is connected. Later
+// calls are no-ops, so reconnecting doesn't start a second loop.
+func (m Model) startHealthCheck() (Model, tea.Cmd) {
+	if m.healthChecking || m.healthInterval <= 0 || m.store == nil {
+		return m, nil
+	}
+	m.healthChecking = true
+	return m, healthTick(m.healthInterval)
+}
+
+// handleHealthChecked records a lost connection, or reloads the list once
+// a lost connection answers again, then schedules the next ping. A ping
+// that times out counts as lost: a stalled connection never answers.
+func (m Model) handleHealthChecked(msg healthCheckedMsg) (tea.Model, tea.Cmd) {
+	next := healthTick(m.healthInterval)
+	switch {
+	case db.IsConnectionError(msg.err) || errors.Is(msg.err, db.ErrQueryTimeout):
+		m.connLost = msg.err
+		return m, next
+	case msg.err == nil && m.connLost != nil:
+		m.connLost = nil
+		m.err = nil
+		status := m.setStatus("Reconnected to the database.")
+		m.loading = true
+		return m, tea.Batch(status, m.fetchRecords(true), next)
+	}
+	return m, next
+}
+
+func (m Model) renderConnLost() string {
+	if m.connLost == nil {
+		return ""
+	}
+	return m.styles.error.Render("  ⚠ Database connection lost; showing the last loaded records. Retrying in the background, or press r in the list") + "\n"
+}
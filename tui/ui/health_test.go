@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+var errConnRefused = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func TestConnectionLossKeepsList(t *testing.T) {
+	m := newTestModel(testRecords())
+
+	updated, _ := m.Update(recordsLoadedMsg{err: errConnRefused})
+	m = updated.(Model)
+	if m.err != nil || len(m.filtered) != 3 {
+		t.Fatalf("err = %v, rows = %d; a dropped connection should keep the list", m.err, len(m.filtered))
+	}
+	if out := ansi.Strip(m.renderList()); !strings.Contains(out, "Database connection lost") {
+		t.Errorf("list should warn about the lost connection:\n%s", out)
+	}
+
+	updated, _ = m.Update(recordsLoadedMsg{records: testRecords(), total: 3})
+	if updated.(Model).connLost != nil {
+		t.Error("a successful load should clear the warning")
+	}
+}
+
+func TestConnectionLossWithNothingLoaded(t *testing.T) {
+	m := newTestModel(nil)
+	updated, _ := m.Update(recordsLoadedMsg{err: errConnRefused})
+	if updated.(Model).err == nil {
+		t.Error("with no records to show, a dropped connection is an error")
+	}
+}
+
+func TestQueryErrorIsNotConnectionLoss(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(recordsLoadedMsg{err: errors.New("syntax error")})
+	m = updated.(Model)
+	if m.connLost != nil || m.err == nil {
+		t.Errorf("connLost = %v, err = %v; want a plain error", m.connLost, m.err)
+	}
+}
+
+func TestHealthCheckReconnects(t *testing.T) {
+	m := newTestModel(testRecords()).WithHealthCheck(time.Minute)
+
+	updated, cmd := m.Update(healthCheckedMsg{err: errConnRefused})
+	m = updated.(Model)
+	if m.connLost == nil || cmd == nil {
+		t.Fatal("a failed ping should mark the connection lost and keep checking")
+	}
+
+	updated, cmd = m.Update(healthCheckedMsg{})
+	m = updated.(Model)
+	if m.connLost != nil || !m.loading || cmd == nil {
+		t.Errorf("connLost = %v, loading = %v; a good ping should reload", m.connLost, m.loading)
+	}
+	if m.status != "Reconnected to the database." {
+		t.Errorf("status = %q, want reconnect notice", m.status)
+	}
+}
+
+func TestHealthCheckStartsOnce(t *testing.T) {
+	m := newTestModel(testRecords()).WithHealthCheck(time.Minute)
+	m, cmd := m.startHealthCheck()
+	if cmd == nil || !m.healthChecking {
+		t.Fatal("first load should start the health check")
+	}
+	if _, cmd := m.startHealthCheck(); cmd != nil {
+		t.Error("a second start should not add another loop")
+	}
+	if _, cmd := newTestModel(testRecords()).WithHealthCheck(0).startHealthCheck(); cmd != nil {
+		t.Error("a zero interval disables the check")
+	}
+}
+
+func TestHealthTickPings(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.store = &mockStore{pingErr: errConnRefused}
+	_, cmd := m.Update(healthTickMsg{})
+	if msg, ok := cmd().(healthCheckedMsg); !ok || msg.err != errConnRefused {
+		t.Errorf("tick should ping the store, got %#v", cmd())
+	}
+}
+
+// stalledPingStore's Ping never answers, like a connection dropped by a
+// sleeping laptop or a VPN going down.
+type stalledPingStore struct {
+	mockStore
+}
+
+func (*stalledPingStore) Ping(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestHealthCheckTimedOutPingIsConnectionLoss(t *testing.T) {
+	m := newTestModel(testRecords()).WithHealthCheck(time.Minute).WithQueryTimeout(30 * time.Second)
+	m.attachStore(&stalledPingStore{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := m.store.Ping(ctx)
+	if !errors.Is(err, db.ErrQueryTimeout) || strings.Contains(err.Error(), "30s") {
+		t.Errorf("Ping err = %v; want a timeout not blamed on the 30s query limit", err)
+	}
+
+	updated, _ := m.Update(healthCheckedMsg{err: err})
+	if updated.(Model).connLost == nil {
+		t.Error("a ping that times out should mark the connection lost")
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+)
+
+// helpOverlayAvailable reports whether "?" opens the overlay: only in views
+// whose keys aren't text input.
+func (m Model) helpOverlayAvailable() bool {
+	switch m.view {
+	case listView, detailView, changesView, syncReportView, statsView, duplicatesView, labelsView, genresView:
+		return !m.searching && !m.tagEditing && !m.noteEditing && !m.fieldEditing && !m.coverExportPrompt && !m.jsonExportPrompt && !m.yearPrompt && !m.commandMode
+	}
+	return false
+}
+
+// handleHelpOverlayKey swallows every key while the overlay is open so the
+// view underneath is left exactly as it was.
+func (m Model) handleHelpOverlayKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "?", "esc", "q":
+		m.showHelp = false
+	}
+	return m, nil
+}
+
+func (m Model) renderHelpGroup(g keyGroup) string {
+	keyWidth := 0
+	for _, b := range g.bindings {
+		keyWidth = max(keyWidth, lipgloss.Width(b.help))
+	}
+	lines := []string{m.styles.label.Render(g.title)}
+	for _, b := range g.bindings {
+		if !m.bindingEnabled(b) {
+			continue
+		}
+		lines = append(lines, m.styles.helpKey.Render(fmt.Sprintf("%-*s", keyWidth, b.help))+"  "+m.styles.helpDesc.Render(b.desc))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderHelpOverlay lays the key groups out in as many columns as fit and
+// centres the box on screen.
+func (m Model) renderHelpOverlay() string {
+	const gap = 4
+	maxWidth := max(m.width-6, 20)
+
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, g := range helpGroups {
+		block := m.renderHelpGroup(g)
+		w := lipgloss.Width(block)
+		if len(row) > 0 && rowWidth+gap+w > maxWidth {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		if len(row) > 0 {
+			row = append(row, strings.Repeat(" ", gap))
+			rowWidth += gap
+		}
+		row = append(row, block)
+		rowWidth += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	body := m.styles.title.Render("♫ Keys") + "\n\n" +
+		strings.Join(rows, "\n\n") + "\n\n" +
+		m.helpLine([]keyBinding{{help: "?/esc", desc: "close"}})
+	box := m.styles.detailBox.Render(body)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
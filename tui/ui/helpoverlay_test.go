@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHelpOverlayToggles(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.cursor = 1
+
+	updated, _ := m.Update(keyMsg("?"))
+	m = updated.(Model)
+	if !m.showHelp {
+		t.Fatal("? should open the help overlay")
+	}
+	body := m.View().Content
+	for _, want := range []string{"Keys", "List", "Detail", "Search", "export json", "image protocol"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("overlay should contain %q", want)
+		}
+	}
+
+	updated, _ = m.Update(keyMsg("j"))
+	updated, _ = updated.(Model).Update(keyMsg("d"))
+	m = updated.(Model)
+	if m.cursor != 1 || m.deleteConfirm {
+		t.Error("keys pressed under the overlay should not reach the list")
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.showHelp || m.view != listView {
+		t.Error("esc should close the overlay and leave the list as it was")
+	}
+	updated, _ = m.Update(keyMsg("?"))
+	updated, _ = updated.(Model).Update(keyMsg("?"))
+	if updated.(Model).showHelp {
+		t.Error("? should also close the overlay")
+	}
+}
+
+func TestHelpOverlayNotDuringTextInput(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.searching = true
+	updated, _ := m.Update(keyMsg("?"))
+	m = updated.(Model)
+	if m.showHelp || m.search != "?" {
+		t.Errorf("? while searching should be typed, showHelp=%v search=%q", m.showHelp, m.search)
+	}
+}
+
+func TestHelpOverlayHidesMutatingKeysWhenReadOnly(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.readOnly = true
+	m.showHelp = true
+	if strings.Contains(m.View().Content, "add manual") {
+		t.Error("read-only overlay should omit mutating bindings")
+	}
+}
+
+func TestStatusHelpFitsAndKeepsHint(t *testing.T) {
+	for width := 12; width <= 80; width++ {
+		m := newTestModel(testRecords())
+		m.width = width
+		list := ansi.Strip(m.renderHelp())
+		if w := ansi.StringWidth(list); w > width {
+			t.Errorf("width %d: list help is %d wide: %q", width, w, list)
+		}
+		m.view = detailView
+		for _, line := range strings.Split(m.renderDetail(), "\n") {
+			if w := ansi.StringWidth(line); strings.Contains(line, "·") && w > width {
+				t.Errorf("width %d: detail help is %d wide: %q", width, w, ansi.Strip(line))
+			}
+		}
+	}
+
+	m := newTestModel(testRecords())
+	m.width = 80
+	help := ansi.Strip(m.renderHelp())
+	if !strings.Contains(help, "? all keys") || strings.Contains(help, "export json") {
+		t.Errorf("list help = %q, want the essentials and the ? hint only", help)
+	}
+}
@@ -2,15 +2,23 @@ package ui
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"image"
+	"image/color"
 	_ "image/gif"
 	"image/jpeg"
 	"image/png"
+	"io"
+	"net"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/x/ansi"
@@ -18,6 +26,8 @@ import (
 	"github.com/charmbracelet/x/ansi/kitty"
 	"github.com/charmbracelet/x/ansi/sixel"
 	"github.com/charmbracelet/x/mosaic"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
 )
 
 type imageProto int
@@ -27,6 +37,7 @@ const (
 	protoKitty
 	protoITerm2
 	protoSixel
+	protoHalfBlock
 )
 
 func (p imageProto) String() string {
@@ -37,11 +48,32 @@ func (p imageProto) String() string {
 		return "iterm2"
 	case protoSixel:
 		return "sixel"
+	case protoHalfBlock:
+		return "halfblock"
 	default:
 		return "mosaic"
 	}
 }
 
+// imageProtoCycle is the order the detail view's protocol key steps through.
+var imageProtoCycle = []imageProto{protoMosaic, protoKitty, protoITerm2, protoSixel, protoHalfBlock}
+
+func nextImageProto(p imageProto) imageProto {
+	i := slices.Index(imageProtoCycle, p)
+	return imageProtoCycle[(i+1)%len(imageProtoCycle)]
+}
+
+// parseImageProto maps a config value like "sixel" to its protocol.
+func parseImageProto(name string) (imageProto, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, p := range imageProtoCycle {
+		if p.String() == name {
+			return p, true
+		}
+	}
+	return protoMosaic, false
+}
+
 func detectImageProto() imageProto {
 	term := os.Getenv("TERM_PROGRAM")
 	termName := strings.ToLower(term)
@@ -62,66 +94,225 @@ func detectImageProto() imageProto {
 		return protoKitty
 	}
 
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return protoHalfBlock
+	}
+
 	return protoMosaic
 }
 
+// textArt reports whether the protocol renders as plain styled text that
+// can be laid out next to other content.
+func (p imageProto) textArt() bool {
+	return p == protoMosaic || p == protoHalfBlock
+}
+
 type cachedImage struct {
 	render   string
 	transmit string
 }
 
+// imageCacheKey includes the protocol and box size since each renders
+// differently.
+type imageCacheKey struct {
+	url   string
+	proto imageProto
+	size  artSize
+}
+
 type imageCache struct {
-	cache map[string]cachedImage
+	cache   map[imageCacheKey]cachedImage
+	pending map[imageCacheKey]bool
 }
 
 func newImageCache() *imageCache {
 	return &imageCache{
-		cache: make(map[string]cachedImage),
+		cache:   make(map[imageCacheKey]cachedImage),
+		pending: make(map[imageCacheKey]bool),
 	}
 }
 
-func (c *imageCache) get(url string) (cachedImage, bool) {
-	v, ok := c.cache[url]
+func (c *imageCache) get(url string, proto imageProto, size artSize) (cachedImage, bool) {
+	v, ok := c.cache[imageCacheKey{url, proto, size}]
 	return v, ok
 }
 
-func (c *imageCache) set(url string, entry cachedImage) {
-	c.cache[url] = entry
+func (c *imageCache) set(url string, proto imageProto, size artSize, entry cachedImage) {
+	key := imageCacheKey{url, proto, size}
+	c.cache[key] = entry
+	delete(c.pending, key)
 }
 
-func fetchImage(url string) (image.Image, []byte, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
+// claim marks url as being prefetched. It reports false if the image is
+// already cached or another prefetch has it.
+func (c *imageCache) claim(url string, proto imageProto, size artSize) bool {
+	key := imageCacheKey{url, proto, size}
+	if _, ok := c.cache[key]; ok || c.pending[key] {
+		return false
+	}
+	c.pending[key] = true
+	return true
+}
+
+func fetchImage(ctx context.Context, cache *diskCache, url string) (image.Image, []byte, error) {
+	raw, ct, err := cache.fetch(ctx, url)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	img, err := decodeImage(raw, ct)
+	if err != nil {
+		return nil, nil, err
 	}
+	return img, raw, nil
+}
 
-	var buf bytes.Buffer
-	_, _ = buf.ReadFrom(resp.Body)
-	raw := buf.Bytes()
-
-	ct := resp.Header.Get("Content-Type")
+// decodeImage picks a decoder from the magic bytes or content type, and
+// falls back to format sniffing when a server mislabels the body.
+func decodeImage(raw []byte, ct string) (image.Image, error) {
 	var img image.Image
-
-	reader := bytes.NewReader(raw)
+	var err error
 	switch {
+	case isWebP(raw), strings.Contains(ct, "webp"):
+		img, err = webp.Decode(bytes.NewReader(raw))
 	case strings.Contains(ct, "jpeg"), strings.Contains(ct, "jpg"):
-		img, err = jpeg.Decode(reader)
+		img, err = jpeg.Decode(bytes.NewReader(raw))
 	case strings.Contains(ct, "png"):
-		img, err = png.Decode(reader)
+		img, err = png.Decode(bytes.NewReader(raw))
 	default:
-		img, _, err = image.Decode(reader)
+		img, _, err = image.Decode(bytes.NewReader(raw))
+		return img, err
 	}
 	if err != nil {
-		return nil, nil, err
+		if sniffed, _, serr := image.Decode(bytes.NewReader(raw)); serr == nil {
+			return sniffed, nil
+		}
 	}
+	return img, err
+}
 
-	return img, raw, nil
+// isWebP checks for the RIFF....WEBP container header.
+func isWebP(raw []byte) bool {
+	return len(raw) >= 12 && string(raw[0:4]) == "RIFF" && string(raw[8:12]) == "WEBP"
+}
+
+const (
+	defaultImageTimeout = 10 * time.Second
+	imageUserAgent      = "my-record-collection/1.0"
+	maxImageRedirects   = 5
+	imageFetchAttempts  = 3
+)
+
+// imageRetryDelay is the wait before the first retry; it doubles after
+// each failed attempt.
+var imageRetryDelay = 250 * time.Millisecond
+
+// imageClient is shared by every cover download so connections to the same
+// CDN are reused. WithImageTimeout replaces it before the program starts.
+var imageClient = newImageClient(defaultImageTimeout)
+
+// newImageClient follows at most maxImageRedirects redirects and leaves
+// compression to fetchImageBytes, which asks for gzip itself.
+func newImageClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = true
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) >= maxImageRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxImageRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+type imageHTTPError struct {
+	status int
+}
+
+func (e imageHTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.status)
+}
+
+// retryableImageError reports whether a failed download is worth another
+// try: dropped or refused connections and 5xx responses, but not 4xx, bad
+// URLs, redirect loops or a spent deadline.
+func retryableImageError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if statusErr, ok := errors.AsType[imageHTTPError](err); ok {
+		return statusErr.status >= 500
+	}
+	if _, ok := errors.AsType[*net.OpError](err); ok {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// fetchImageBytes downloads url and returns the body with its Content-Type,
+// retrying transient failures. All attempts share one deadline, the image
+// client's timeout, so a flaky CDN can't stall the detail view.
+func fetchImageBytes(ctx context.Context, url string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, imageClient.Timeout)
+	defer cancel()
+
+	wait := imageRetryDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		var raw []byte
+		var ct string
+		raw, ct, err = fetchImageOnce(ctx, url)
+		if err == nil {
+			return raw, ct, nil
+		}
+		if attempt == imageFetchAttempts || !retryableImageError(err) {
+			return nil, "", err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, "", err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+func fetchImageOnce(ctx context.Context, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", imageUserAgent)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := imageClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", imageHTTPError{status: resp.StatusCode}
+	}
+
+	body := io.Reader(resp.Body)
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("gzip body: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), resp.Header.Get("Content-Type"), nil
 }
 
 type kittyResult struct {
@@ -129,29 +320,81 @@ type kittyResult struct {
 	placeholder string
 }
 
-var kittyImageIDCounter int
+// kittyImageIDCounter hands out kitty image IDs. Covers render in
+// concurrent tea.Cmds (the selected record and its prefetched neighbors),
+// so it is atomic.
+var kittyImageIDCounter atomic.Uint32
 
 func renderImage(proto imageProto, img image.Image, raw []byte, width, height int) string {
 	switch proto {
 	case protoKitty:
-		return renderKitty(img).placeholder
+		return renderKitty(img, width, height).placeholder
 	case protoITerm2:
 		return renderITerm2(raw, width, height)
 	case protoSixel:
-		return renderSixel(img)
+		return renderSixel(img, width, height)
+	case protoHalfBlock:
+		return renderHalfBlock(img, width, height)
 	default:
 		return renderMosaic(img, width, height)
 	}
 }
 
+// renderHalfBlock draws two pixel rows per cell using ▀ with a 24-bit
+// foreground for the top pixel and background for the bottom one.
+func renderHalfBlock(img image.Image, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return ""
+	}
+
+	rows := height * 2
+	var b strings.Builder
+	for cy := range height {
+		for cx := range width {
+			tr, tg, tb := averageColor(img, bounds, cx, cy*2, width, rows)
+			br, bg, bb := averageColor(img, bounds, cx, cy*2+1, width, rows)
+			fmt.Fprintf(&b, "\033[38;2;%d;%d;%dm\033[48;2;%d;%d;%dm▀", tr, tg, tb, br, bg, bb)
+		}
+		b.WriteString("\033[0m")
+		if cy < height-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// averageColor box-filters the source pixels that map onto target pixel
+// (tx, ty) of a cols×rows grid.
+func averageColor(img image.Image, bounds image.Rectangle, tx, ty, cols, rows int) (uint8, uint8, uint8) {
+	x0 := bounds.Min.X + tx*bounds.Dx()/cols
+	x1 := max(x0+1, bounds.Min.X+(tx+1)*bounds.Dx()/cols)
+	y0 := bounds.Min.Y + ty*bounds.Dy()/rows
+	y1 := max(y0+1, bounds.Min.Y+(ty+1)*bounds.Dy()/rows)
+
+	var rSum, gSum, bSum, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			rSum += uint64(r >> 8)
+			gSum += uint64(g >> 8)
+			bSum += uint64(b >> 8)
+			n++
+		}
+	}
+	return uint8(rSum / n), uint8(gSum / n), uint8(bSum / n)
+}
+
 func renderMosaic(img image.Image, width, height int) string {
 	m := mosaic.New().Width(width).Height(height)
 	return m.Render(img)
 }
 
-func renderKitty(img image.Image) kittyResult {
-	kittyImageIDCounter++
-	imgID := kittyImageIDCounter
+func renderKitty(img image.Image, cols, rows int) kittyResult {
+	imgID := int(kittyImageIDCounter.Add(1))
 
 	bounds := img.Bounds()
 	var buf bytes.Buffer
@@ -163,15 +406,15 @@ func renderKitty(img image.Image) kittyResult {
 		ImageHeight:      bounds.Dy(),
 		ID:               imgID,
 		VirtualPlacement: true,
-		Columns:          30,
-		Rows:             15,
+		Columns:          cols,
+		Rows:             rows,
 		Chunk:            true,
 		Quite:            2,
 	}); err != nil {
 		return kittyResult{}
 	}
 
-	placeholder := kittyPlaceholder(imgID, 30, 15)
+	placeholder := kittyPlaceholder(imgID, cols, rows)
 	return kittyResult{
 		transmit:    buf.String(),
 		placeholder: placeholder,
@@ -180,7 +423,12 @@ func renderKitty(img image.Image) kittyResult {
 
 func kittyPlaceholder(imgID, cols, rows int) string {
 	var b strings.Builder
+	// Kitty reads the image id from the foreground color: an indexed color
+	// holds ids up to 255, larger ones need all 24 bits.
 	fg := fmt.Sprintf("\033[38;5;%dm", imgID)
+	if imgID > 255 {
+		fg = fmt.Sprintf("\033[38;2;%d;%d;%dm", imgID>>16&0xff, imgID>>8&0xff, imgID&0xff)
+	}
 	reset := "\033[39m"
 	b.WriteString(fg)
 	for row := range rows {
@@ -208,7 +456,19 @@ func renderITerm2(raw []byte, width, height int) string {
 	})
 }
 
-func renderSixel(img image.Image) string {
+// Sixel draws in pixels, so the cell box is converted with a typical
+// terminal cell size.
+const (
+	sixelCellWidth  = 10
+	sixelCellHeight = 20
+)
+
+// renderSixel scales img down to fit width×height cells before encoding so
+// a large cover doesn't flood the terminal.
+func renderSixel(img image.Image, width, height int) string {
+	if width > 0 && height > 0 {
+		img = fitImage(img, width*sixelCellWidth, height*sixelCellHeight)
+	}
 	var enc sixel.Encoder
 	var buf bytes.Buffer
 	if err := enc.Encode(&buf, img); err != nil {
@@ -217,23 +477,59 @@ func renderSixel(img image.Image) string {
 	return ansi.SixelGraphics(0, 1, 0, buf.Bytes())
 }
 
+// fitImage scales img to fit within maxW×maxH pixels, keeping its aspect
+// ratio. Images that already fit are returned as is.
+func fitImage(img image.Image, maxW, maxH int) image.Image {
+	b := img.Bounds()
+	if b.Empty() || (b.Dx() <= maxW && b.Dy() <= maxH) {
+		return img
+	}
+	w, h := maxW, b.Dy()*maxW/b.Dx()
+	if h > maxH {
+		w, h = b.Dx()*maxH/b.Dy(), maxH
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, max(w, 1), max(h, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// flattenImage composites img over a solid bg, so covers with an alpha
+// channel don't show whatever the renderer treats as transparent. Opaque
+// images and a nil bg are returned untouched.
+func flattenImage(img image.Image, bg color.Color) image.Image {
+	if bg == nil {
+		return img
+	}
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img
+	}
+	b := img.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, image.NewUniform(bg), image.Point{}, draw.Src)
+	draw.Draw(dst, b, img, b.Min, draw.Over)
+	return dst
+}
+
 type fetchResult struct {
 	render   string
 	transmit string
 }
 
-func fetchAndRender(proto imageProto, url string, width, height int) (fetchResult, error) {
+// fetchAndRender fetches url and renders it with proto. Transparent covers
+// are flattened onto bg first so they blend with the theme.
+func fetchAndRender(ctx context.Context, cache *diskCache, proto imageProto, url string, width, height int, bg color.Color) (fetchResult, error) {
 	if url == "" {
 		return fetchResult{render: renderPlaceholder(width, height)}, nil
 	}
 
-	img, raw, err := fetchImage(url)
+	img, raw, err := fetchImage(ctx, cache, url)
 	if err != nil {
 		return fetchResult{render: renderPlaceholder(width, height)}, nil
 	}
+	img = flattenImage(img, bg)
 
 	if proto == protoKitty {
-		kr := renderKitty(img)
+		kr := renderKitty(img, width, height)
 		if kr.placeholder == "" {
 			return fetchResult{render: renderPlaceholder(width, height)}, nil
 		}
@@ -248,15 +544,21 @@ func fetchAndRender(proto imageProto, url string, width, height int) (fetchResul
 }
 
 func renderPlaceholder(width, height int) string {
-	top := "┌" + strings.Repeat("─", width-2) + "┐"
-	mid := "│" + strings.Repeat(" ", width-2) + "│"
-	bot := "└" + strings.Repeat("─", width-2) + "┘"
+	return renderPlaceholderLabel(width, height, "No Image")
+}
+
+func renderPlaceholderLabel(width, height int, label string) string {
+	// Border runes are one cell each, so the inside is width-2 cells.
+	inner := max(width-2, 0)
+	top := "┌" + strings.Repeat("─", inner) + "┐"
+	mid := "│" + strings.Repeat(" ", inner) + "│"
+	bot := "└" + strings.Repeat("─", inner) + "┘"
 
-	labelLine := fmt.Sprintf("│%s│", centerText("No Image", width-2))
+	labelLine := fmt.Sprintf("│%s│", centerText(label, inner))
 
 	var lines []string
 	lines = append(lines, top)
-	midCount := height - 2
+	midCount := max(height-2, 1)
 	labelPos := midCount / 2
 	for i := range midCount {
 		if i == labelPos {
@@ -269,10 +571,15 @@ func renderPlaceholder(width, height int) string {
 	return strings.Join(lines, "\n")
 }
 
+// centerText centers s in width terminal cells. Widths are display cells,
+// so wide characters count as two; text that doesn't fit is cut and, if a
+// wide character straddled the edge, padded back to width.
 func centerText(s string, width int) string {
-	if len(s) >= width {
-		return s[:width]
+	w := ansi.StringWidth(s)
+	if w >= width {
+		s = ansi.Truncate(s, width, "")
+		return s + strings.Repeat(" ", width-ansi.StringWidth(s))
 	}
-	pad := (width - len(s)) / 2
-	return strings.Repeat(" ", pad) + s + strings.Repeat(" ", width-len(s)-pad)
+	pad := (width - w) / 2
+	return strings.Repeat(" ", pad) + s + strings.Repeat(" ", width-w-pad)
 }
@@ -1,13 +1,24 @@
 package ui
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
 	"image"
 	"image/color"
 	"image/png"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestImageProtoString(t *testing.T) {
@@ -19,6 +30,7 @@ func TestImageProtoString(t *testing.T) {
 		{protoKitty, "kitty"},
 		{protoITerm2, "iterm2"},
 		{protoSixel, "sixel"},
+		{protoHalfBlock, "halfblock"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -96,34 +108,58 @@ func TestDetectImageProtoMosaicFallback(t *testing.T) {
 	t.Setenv("TERM_PROGRAM", "")
 	t.Setenv("TERM", "xterm-256color")
 	t.Setenv("KITTY_WINDOW_ID", "")
+	t.Setenv("COLORTERM", "")
 	if got := detectImageProto(); got != protoMosaic {
 		t.Errorf("detectImageProto() = %v, want mosaic", got)
 	}
 }
 
+func TestDetectImageProtoHalfBlockTruecolor(t *testing.T) {
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("KITTY_WINDOW_ID", "")
+	t.Setenv("COLORTERM", "truecolor")
+	if got := detectImageProto(); got != protoHalfBlock {
+		t.Errorf("detectImageProto() = %v, want halfblock", got)
+	}
+}
+
+func TestDetectImageProtoKittyBeatsTruecolor(t *testing.T) {
+	t.Setenv("TERM_PROGRAM", "kitty")
+	t.Setenv("TERM", "")
+	t.Setenv("KITTY_WINDOW_ID", "")
+	t.Setenv("COLORTERM", "truecolor")
+	if got := detectImageProto(); got != protoKitty {
+		t.Errorf("detectImageProto() = %v, want kitty", got)
+	}
+}
+
 func TestImageCacheGetSet(t *testing.T) {
 	c := newImageCache()
 
-	_, ok := c.get("http://example.com/img.jpg")
+	_, ok := c.get("http://example.com/img.jpg", protoKitty, defaultArtSize)
 	if ok {
 		t.Error("empty cache should return !ok")
 	}
 
-	c.set("http://example.com/img.jpg", cachedImage{render: "rendered-data"})
-	got, ok := c.get("http://example.com/img.jpg")
+	c.set("http://example.com/img.jpg", protoKitty, defaultArtSize, cachedImage{render: "rendered-data"})
+	got, ok := c.get("http://example.com/img.jpg", protoKitty, defaultArtSize)
 	if !ok {
 		t.Error("cache hit should return ok")
 	}
 	if got.render != "rendered-data" {
 		t.Errorf("cached value = %q, want %q", got, "rendered-data")
 	}
+	if _, ok := c.get("http://example.com/img.jpg", protoSixel, defaultArtSize); ok {
+		t.Error("entries should be per protocol")
+	}
 }
 
 func TestImageCacheOverwrite(t *testing.T) {
 	c := newImageCache()
-	c.set("url", cachedImage{render: "first"})
-	c.set("url", cachedImage{render: "second"})
-	got, _ := c.get("url")
+	c.set("url", protoMosaic, defaultArtSize, cachedImage{render: "first"})
+	c.set("url", protoMosaic, defaultArtSize, cachedImage{render: "second"})
+	got, _ := c.get("url", protoMosaic, defaultArtSize)
 	if got.render != "second" {
 		t.Errorf("overwritten value = %q, want %q", got, "second")
 	}
@@ -154,6 +190,24 @@ func TestRenderPlaceholderSmall(t *testing.T) {
 	}
 }
 
+func TestRenderPlaceholderWideLabel(t *testing.T) {
+	for _, width := range []int{7, 10, 15} {
+		lines := strings.Split(renderPlaceholderLabel(width, 5, "画像なし"), "\n")
+		for i, line := range lines {
+			if got := ansi.StringWidth(line); got != width {
+				t.Errorf("width %d: line %d is %d cells: %q", width, i, got, line)
+			}
+		}
+	}
+}
+
+func TestRenderPlaceholderTiny(t *testing.T) {
+	lines := strings.Split(renderPlaceholder(1, 1), "\n")
+	if len(lines) != 3 {
+		t.Errorf("tiny placeholder lines = %d, want 3", len(lines))
+	}
+}
+
 func TestCenterText(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -165,6 +219,9 @@ func TestCenterText(t *testing.T) {
 		{"exact fit", "test", 4, "test"},
 		{"too long", "toolong", 4, "tool"},
 		{"odd padding", "ab", 5, " ab  "},
+		{"wide centered", "画像なし", 12, "  画像なし  "},
+		{"wide cut at a double cell", "画像なし", 5, "画像 "},
+		{"zero width", "hi", 0, ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -177,7 +234,7 @@ func TestCenterText(t *testing.T) {
 }
 
 func TestFetchAndRenderEmptyURL(t *testing.T) {
-	result, err := fetchAndRender(protoMosaic, "", 20, 5)
+	result, err := fetchAndRender(context.Background(), nil, protoMosaic, "", 20, 5, nil)
 	if err != nil {
 		t.Fatalf("fetchAndRender empty URL err: %v", err)
 	}
@@ -187,7 +244,8 @@ func TestFetchAndRenderEmptyURL(t *testing.T) {
 }
 
 func TestFetchAndRenderInvalidURL(t *testing.T) {
-	result, err := fetchAndRender(protoMosaic, "http://localhost:1/nonexistent.jpg", 20, 5)
+	fastImageRetries(t)
+	result, err := fetchAndRender(context.Background(), nil, protoMosaic, "http://localhost:1/nonexistent.jpg", 20, 5, nil)
 	if err != nil {
 		t.Fatalf("fetchAndRender invalid URL err: %v", err)
 	}
@@ -197,7 +255,8 @@ func TestFetchAndRenderInvalidURL(t *testing.T) {
 }
 
 func TestFetchImageInvalidURL(t *testing.T) {
-	_, _, err := fetchImage("http://localhost:1/nonexistent.jpg")
+	fastImageRetries(t)
+	_, _, err := fetchImage(context.Background(), nil, "http://localhost:1/nonexistent.jpg")
 	if err == nil {
 		t.Error("fetchImage with unreachable URL should error")
 	}
@@ -212,14 +271,14 @@ func TestRenderImageDispatches(t *testing.T) {
 }
 
 func TestFetchImageBadStatusCode(t *testing.T) {
-	_, _, err := fetchImage("")
+	_, _, err := fetchImage(context.Background(), nil, "")
 	if err == nil {
 		t.Error("fetchImage with empty URL should error")
 	}
 }
 
 func TestDetectImageProtoSaved(t *testing.T) {
-	for _, key := range []string{"TERM_PROGRAM", "TERM", "KITTY_WINDOW_ID"} {
+	for _, key := range []string{"TERM_PROGRAM", "TERM", "KITTY_WINDOW_ID", "COLORTERM"} {
 		t.Setenv(key, "")
 	}
 	got := detectImageProto()
@@ -248,9 +307,26 @@ func servePNG(t *testing.T) *httptest.Server {
 	}))
 }
 
+func TestKittyPlaceholderID(t *testing.T) {
+	tests := []struct {
+		id   int
+		want string
+	}{
+		{7, "\033[38;5;7m"},
+		{255, "\033[38;5;255m"},
+		{256, "\033[38;2;0;1;0m"},
+		{0x12345, "\033[38;2;1;35;69m"},
+	}
+	for _, tt := range tests {
+		if got := kittyPlaceholder(tt.id, 1, 1); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("kittyPlaceholder(%d) = %q, want prefix %q", tt.id, got, tt.want)
+		}
+	}
+}
+
 func TestRenderKitty(t *testing.T) {
 	img := testImage()
-	result := renderKitty(img)
+	result := renderKitty(img, 30, 15)
 	if result.placeholder == "" {
 		t.Error("renderKitty should produce non-empty placeholder for valid image")
 	}
@@ -259,6 +335,26 @@ func TestRenderKitty(t *testing.T) {
 	}
 }
 
+func TestRenderKittyConcurrentIDs(t *testing.T) {
+	img := testImage()
+	const n = 20
+	placeholders := make([]string, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Go(func() { placeholders[i] = renderKitty(img, 2, 1).placeholder })
+	}
+	wg.Wait()
+
+	// The placeholders differ only in the image ID their color encodes.
+	seen := make(map[string]bool)
+	for _, p := range placeholders {
+		if seen[p] {
+			t.Fatalf("two concurrent renders got the same image ID: %q", p)
+		}
+		seen[p] = true
+	}
+}
+
 func TestRenderITerm2(t *testing.T) {
 	result := renderITerm2([]byte("fakedata"), 10, 10)
 	if result == "" {
@@ -268,12 +364,59 @@ func TestRenderITerm2(t *testing.T) {
 
 func TestRenderSixel(t *testing.T) {
 	img := testImage()
-	result := renderSixel(img)
+	result := renderSixel(img, 30, 15)
 	if result == "" {
 		t.Error("renderSixel should produce non-empty output for valid image")
 	}
 }
 
+func TestFlattenImage(t *testing.T) {
+	bg := color.RGBA{R: 30, G: 30, B: 46, A: 255}
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 0, color.NRGBA{R: 255, A: 0})
+
+	flat := flattenImage(img, bg)
+	if got := color.RGBAModel.Convert(flat.At(0, 0)); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("opaque pixel = %v, want unchanged red", got)
+	}
+	if got := color.RGBAModel.Convert(flat.At(1, 0)); got != bg {
+		t.Errorf("transparent pixel = %v, want background %v", got, bg)
+	}
+
+	opaque := testImage()
+	if flattenImage(opaque, bg) != opaque {
+		t.Error("opaque image should be returned as is")
+	}
+	if flattenImage(img, nil) != image.Image(img) {
+		t.Error("nil background should leave the image alone")
+	}
+}
+
+func TestFitImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		w, h       int
+		maxW, maxH int
+		wantW      int
+		wantH      int
+	}{
+		{"already fits", 100, 100, 300, 300, 100, 100},
+		{"wide", 1200, 600, 300, 300, 300, 150},
+		{"tall", 600, 1200, 300, 300, 150, 300},
+		{"square into tall box", 1000, 1000, 300, 600, 300, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, tt.w, tt.h))
+			got := fitImage(img, tt.maxW, tt.maxH).Bounds()
+			if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+				t.Errorf("fitImage = %dx%d, want %dx%d", got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
 func TestRenderMosaic(t *testing.T) {
 	img := testImage()
 	result := renderMosaic(img, 10, 5)
@@ -282,11 +425,35 @@ func TestRenderMosaic(t *testing.T) {
 	}
 }
 
+func TestRenderHalfBlock(t *testing.T) {
+	img := testImage()
+	result := renderHalfBlock(img, 10, 5)
+	if result == "" {
+		t.Fatal("renderHalfBlock should produce non-empty output")
+	}
+	lines := strings.Split(result, "\n")
+	if len(lines) != 5 {
+		t.Errorf("renderHalfBlock lines = %d, want 5", len(lines))
+	}
+	if got := strings.Count(lines[0], "▀"); got != 10 {
+		t.Errorf("renderHalfBlock cells per line = %d, want 10", got)
+	}
+	if !strings.Contains(result, "38;2;255;0;0") || !strings.Contains(result, "48;2;255;0;0") {
+		t.Error("renderHalfBlock should use 24-bit fg/bg colors of the source image")
+	}
+}
+
+func TestRenderHalfBlockZeroSize(t *testing.T) {
+	if got := renderHalfBlock(testImage(), 0, 5); got != "" {
+		t.Errorf("renderHalfBlock with zero width = %q, want empty", got)
+	}
+}
+
 func TestRenderImageAllProtos(t *testing.T) {
 	img := testImage()
 	raw := []byte("raw-data")
 
-	for _, proto := range []imageProto{protoMosaic, protoKitty, protoITerm2, protoSixel} {
+	for _, proto := range []imageProto{protoMosaic, protoKitty, protoITerm2, protoSixel, protoHalfBlock} {
 		t.Run(proto.String(), func(t *testing.T) {
 			result := renderImage(proto, img, raw, 10, 5)
 			if result == "" {
@@ -300,7 +467,7 @@ func TestFetchImageHTTPServer(t *testing.T) {
 	server := servePNG(t)
 	defer server.Close()
 
-	img, raw, err := fetchImage(server.URL + "/test.png")
+	img, raw, err := fetchImage(context.Background(), nil, server.URL+"/test.png")
 	if err != nil {
 		t.Fatalf("fetchImage from test server: %v", err)
 	}
@@ -321,9 +488,35 @@ func TestFetchImageHTTPServerJPEG(t *testing.T) {
 	}))
 	defer server.Close()
 
-	_, _, err := fetchImage(server.URL + "/test.jpg")
-	if err == nil {
-		t.Log("jpeg decode of png data may or may not error depending on header bytes")
+	img, _, err := fetchImage(context.Background(), nil, server.URL+"/test.jpg")
+	if err != nil || img == nil {
+		t.Errorf("PNG mislabeled as JPEG should still decode: %v", err)
+	}
+}
+
+// tinyWebP is a 1x1 lossless WebP.
+const tinyWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+func TestDecodeImageWebP(t *testing.T) {
+	raw, err := base64.StdEncoding.DecodeString(tinyWebP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isWebP(raw) {
+		t.Fatal("isWebP should recognise the RIFF/WEBP header")
+	}
+	for _, ct := range []string{"image/webp", "image/jpeg", "application/octet-stream", ""} {
+		img, err := decodeImage(raw, ct)
+		if err != nil {
+			t.Errorf("content-type %q: %v", ct, err)
+			continue
+		}
+		if b := img.Bounds(); b.Dx() != 1 || b.Dy() != 1 {
+			t.Errorf("content-type %q: bounds = %v, want 1x1", ct, b)
+		}
+	}
+	if isWebP([]byte("RIFF\x00\x00\x00\x00WAVE")) {
+		t.Error("a RIFF WAVE header is not WebP")
 	}
 }
 
@@ -333,7 +526,7 @@ func TestFetchImageHTTPServer404(t *testing.T) {
 	}))
 	defer server.Close()
 
-	_, _, err := fetchImage(server.URL + "/missing.png")
+	_, _, err := fetchImage(context.Background(), nil, server.URL+"/missing.png")
 	if err == nil {
 		t.Error("404 should return error")
 	}
@@ -351,7 +544,7 @@ func TestFetchImageHTTPServerBadImage(t *testing.T) {
 	}))
 	defer server.Close()
 
-	_, _, err := fetchImage(server.URL + "/bad.png")
+	_, _, err := fetchImage(context.Background(), nil, server.URL+"/bad.png")
 	if err == nil {
 		t.Error("corrupt image data should return error")
 	}
@@ -361,7 +554,7 @@ func TestFetchAndRenderSuccess(t *testing.T) {
 	server := servePNG(t)
 	defer server.Close()
 
-	result, err := fetchAndRender(protoMosaic, server.URL+"/img.png", 20, 10)
+	result, err := fetchAndRender(context.Background(), nil, protoMosaic, server.URL+"/img.png", 20, 10, nil)
 	if err != nil {
 		t.Fatalf("fetchAndRender err: %v", err)
 	}
@@ -379,7 +572,7 @@ func TestFetchImageDefaultDecode(t *testing.T) {
 	}))
 	defer server.Close()
 
-	img, _, err := fetchImage(server.URL + "/test.bmp")
+	img, _, err := fetchImage(context.Background(), nil, server.URL+"/test.bmp")
 	if err != nil {
 		t.Fatalf("fetchImage with unknown content-type: %v", err)
 	}
@@ -387,3 +580,239 @@ func TestFetchImageDefaultDecode(t *testing.T) {
 		t.Error("img should not be nil")
 	}
 }
+
+func TestFetchImageBytesHeadersGzipAndRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old.png", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new.png", http.StatusFound)
+	})
+	mux.HandleFunc("/new.png", func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.UserAgent(); ua != imageUserAgent {
+			t.Errorf("User-Agent = %q, want %q", ua, imageUserAgent)
+		}
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Error("request should accept gzip")
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte("png-bytes"))
+		_ = gz.Close()
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	raw, ct, err := fetchImageBytes(context.Background(), server.URL+"/old.png")
+	if err != nil {
+		t.Fatalf("fetchImageBytes: %v", err)
+	}
+	if string(raw) != "png-bytes" || ct != "image/png" {
+		t.Errorf("got %q (%s), want decompressed png-bytes (image/png)", raw, ct)
+	}
+
+	if _, _, err := fetchImageBytes(context.Background(), server.URL+"/loop"); err == nil || !strings.Contains(err.Error(), "redirects") {
+		t.Errorf("redirect loop err = %v, want a redirect limit error", err)
+	}
+}
+
+// fastImageRetries shortens the retry backoff for the rest of the test.
+func fastImageRetries(t *testing.T) {
+	t.Helper()
+	saved := imageRetryDelay
+	imageRetryDelay = time.Millisecond
+	t.Cleanup(func() { imageRetryDelay = saved })
+}
+
+func TestRetryableImageError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"5xx", imageHTTPError{status: 503}, true},
+		{"404", imageHTTPError{status: 404}, false},
+		{"connection refused", &url.Error{Op: "Get", Err: &net.OpError{Op: "dial", Err: errors.New("refused")}}, true},
+		{"dropped connection", &url.Error{Op: "Get", Err: io.ErrUnexpectedEOF}, true},
+		{"deadline", &url.Error{Op: "Get", Err: context.DeadlineExceeded}, false},
+		{"bad scheme", &url.Error{Op: "Get", Err: errors.New("unsupported protocol scheme")}, false},
+	}
+	for _, tt := range tests {
+		if got := retryableImageError(tt.err); got != tt.want {
+			t.Errorf("%s: retryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchImageBytesRetries(t *testing.T) {
+	fastImageRetries(t)
+
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantHits  int
+		wantError bool
+	}{
+		{"recovers after 5xx", []int{503, 502, 200}, 3, false},
+		{"gives up after attempts", []int{500, 500, 500, 200}, imageFetchAttempts, true},
+		{"404 is not retried", []int{404, 200}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				status := tt.statuses[min(hits, len(tt.statuses)-1)]
+				hits++
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte("img"))
+			}))
+			defer server.Close()
+
+			_, _, err := fetchImageBytes(context.Background(), server.URL)
+			if (err != nil) != tt.wantError {
+				t.Errorf("err = %v, want error %v", err, tt.wantError)
+			}
+			if hits != tt.wantHits {
+				t.Errorf("requests = %d, want %d", hits, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestFetchImageBytesRetriesRespectDeadline(t *testing.T) {
+	defer func(c *http.Client, d time.Duration) { imageClient, imageRetryDelay = c, d }(imageClient, imageRetryDelay)
+	imageClient = newImageClient(50 * time.Millisecond)
+	imageRetryDelay = time.Second
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	if _, _, err := fetchImageBytes(context.Background(), server.URL); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("retries took %v, should stop at the overall deadline", elapsed)
+	}
+}
+
+func TestWithImageTimeout(t *testing.T) {
+	defer func(c *http.Client) { imageClient = c }(imageClient)
+
+	newTestModel(nil).WithImageTimeout(0)
+	if imageClient.Timeout != defaultImageTimeout {
+		t.Errorf("zero timeout should keep the default, got %v", imageClient.Timeout)
+	}
+	newTestModel(nil).WithImageTimeout(3 * time.Second)
+	if imageClient.Timeout != 3*time.Second {
+		t.Errorf("timeout = %v, want 3s", imageClient.Timeout)
+	}
+}
+
+func TestWithImageProtocolOverridesDetection(t *testing.T) {
+	t.Setenv("TERM_PROGRAM", "kitty")
+	tests := []struct {
+		name string
+		want imageProto
+	}{
+		{"sixel", protoSixel},
+		{"Halfblock", protoHalfBlock},
+		{"mosaic", protoMosaic},
+		{"", protoKitty},
+		{"bogus", protoKitty},
+	}
+	for _, tt := range tests {
+		m := NewModel(&mockStore{}, "", "", "").WithImageProtocol(tt.name)
+		if m.imgProto != tt.want {
+			t.Errorf("WithImageProtocol(%q) = %v, want %v", tt.name, m.imgProto, tt.want)
+		}
+	}
+}
+
+func TestNextImageProtoCycles(t *testing.T) {
+	p := protoMosaic
+	var seen []string
+	for range len(imageProtoCycle) {
+		p = nextImageProto(p)
+		seen = append(seen, p.String())
+	}
+	if got := strings.Join(seen, ","); got != "kitty,iterm2,sixel,halfblock,mosaic" {
+		t.Errorf("cycle = %s", got)
+	}
+}
+
+func TestDetailProtocolKeyReloadsArt(t *testing.T) {
+	recs := testRecords()
+	url := "http://img/a.png"
+	recs[0].CoverImageURL = &url
+	m := newTestModel(recs)
+	m.imgCache.set(url, protoMosaic, m.artSize(), cachedImage{render: "mosaic-art"})
+
+	updated, _ := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if m.artRender != "mosaic-art" {
+		t.Fatalf("artRender = %q, want cached mosaic render", m.artRender)
+	}
+
+	updated, cmd := m.Update(keyMsg("i"))
+	m = updated.(Model)
+	if m.imgProto != protoKitty {
+		t.Fatalf("imgProto = %v, want kitty", m.imgProto)
+	}
+	if !m.artLoading || cmd == nil {
+		t.Fatal("switching protocol should re-render the image")
+	}
+	if !strings.Contains(m.renderDetail(), "[image: kitty]") {
+		t.Error("label should show the new protocol")
+	}
+
+	updated, _ = m.Update(imageLoadedMsg{id: m.artLoadID, url: url, proto: protoMosaic, render: "stale"})
+	m = updated.(Model)
+	if !m.artLoading || m.artRender == "stale" {
+		t.Error("a render for the previous protocol should be ignored")
+	}
+	updated, _ = m.Update(imageLoadedMsg{id: m.artLoadID, url: url, proto: protoKitty, render: "kitty-art"})
+	m = updated.(Model)
+	if m.artLoading || m.artRender != "kitty-art" {
+		t.Errorf("artRender = %q, want kitty-art", m.artRender)
+	}
+}
+
+func TestStaleImageLoadDiscarded(t *testing.T) {
+	recs := testRecords()
+	first, second := "http://img/first.png", "http://img/second.png"
+	recs[1].CoverImageURL = &first
+	recs[0].CoverImageURL = &second
+	m := newTestModel(recs)
+	m.imgDiskCache = nil
+	m.view = detailView
+	m, _ = m.showArt()
+	staleID := m.artLoadID
+
+	updated, _ := m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	updated, _ = m.Update(imageLoadedMsg{id: staleID, url: first, size: m.artSize(), render: "late"})
+	m = updated.(Model)
+	if m.artRender != "" {
+		t.Errorf("artRender = %q after leaving detail, want empty", m.artRender)
+	}
+	if _, ok := m.imgCache.get(first, protoMosaic, m.artSize()); !ok {
+		t.Error("a late load should still fill the cache")
+	}
+
+	m.view = detailView
+	m.cursor = 0
+	m, _ = m.showArt()
+	staleID = m.artLoadID
+	updated, _ = m.Update(keyMsg("j"))
+	m = updated.(Model)
+	updated, _ = m.Update(imageLoadedMsg{id: staleID, url: second, render: "previous record"})
+	m = updated.(Model)
+	if m.artRender != "late" {
+		t.Errorf("artRender = %q, want the current record's cached art", m.artRender)
+	}
+}
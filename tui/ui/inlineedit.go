@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+// inlineField is a detail view row that can be edited in place: its
+// detailFields label and the column UpdateField writes.
+type inlineField struct {
+	label, column string
+}
+
+// inlineFields are the rows tab can focus and e edits, in no particular
+// order; focus follows the order detailFields shows them in.
+var inlineFields = []inlineField{
+	{"Artist", "artist_name"},
+	{"Album", "album_title"},
+	{"Year", "year_released"},
+	{"Label", "label_name"},
+	{"Size", "record_size"},
+	{"Color", "vinyl_color"},
+	{"Catalog #", "catalog_number"},
+	{"UPC", "upc_code"},
+}
+
+func inlineColumn(label string) (string, bool) {
+	i := slices.IndexFunc(inlineFields, func(f inlineField) bool { return f.label == label })
+	if i < 0 {
+		return "", false
+	}
+	return inlineFields[i].column, true
+}
+
+type fieldUpdatedMsg struct {
+	id     string
+	column string
+	value  string
+	err    error
+}
+
+func updateField(ctx context.Context, store db.Store, id, column, value string) tea.Cmd {
+	return func() tea.Msg {
+		err := store.UpdateField(ctx, id, column, value)
+		return fieldUpdatedMsg{id: id, column: column, value: value, err: err}
+	}
+}
+
+// focusableLabels lists the inline-editable rows rec shows, in display
+// order.
+func (m Model) focusableLabels(rec db.Record) []string {
+	var labels []string
+	for _, f := range m.detailFields(rec) {
+		if _, ok := inlineColumn(f.label); ok {
+			labels = append(labels, f.label)
+		}
+	}
+	return labels
+}
+
+// focusedField is the focused row's label when the current record shows
+// it.
+func (m Model) focusedField() (string, bool) {
+	rec, ok := m.selectedRecord()
+	if !ok || m.detailFocus == "" {
+		return "", false
+	}
+	return m.detailFocus, slices.Contains(m.focusableLabels(rec), m.detailFocus)
+}
+
+// cycleDetailFocus moves focus delta rows through the editable fields,
+// wrapping at either end.
+func (m Model) cycleDetailFocus(delta int) Model {
+	rec, ok := m.selectedRecord()
+	if !ok {
+		return m
+	}
+	labels := m.focusableLabels(rec)
+	if len(labels) == 0 {
+		return m
+	}
+	i := slices.Index(labels, m.detailFocus)
+	switch {
+	case i < 0 && delta > 0:
+		i = 0
+	case i < 0:
+		i = len(labels) - 1
+	default:
+		i = (i + delta + len(labels)) % len(labels)
+	}
+	m.detailFocus = labels[i]
+	return m
+}
+
+// startFieldEdit edits the focused field in place, or opens the full edit
+// form when no field has focus.
+func (m Model) startFieldEdit() (tea.Model, tea.Cmd) {
+	rec, ok := m.selectedRecord()
+	if !ok {
+		return m, nil
+	}
+	label, focused := m.focusedField()
+	if !focused {
+		m = m.hideArt()
+		m.startManualEdit(rec)
+		return m, nil
+	}
+	column, _ := inlineColumn(label)
+	m.fieldEditing = true
+	m.fieldInput = rec.FieldText(column)
+	m.fieldOriginal = m.fieldInput
+	m.fieldErr = ""
+	return m, nil
+}
+
+func (m Model) handleFieldEditKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		if guarded, ok := m.guardDiscard(true); ok {
+			return guarded, nil
+		}
+		return m.quit()
+	case "esc":
+		m.fieldEditing = false
+		m.fieldSaving = false
+		m.fieldErr = ""
+		return m, nil
+	case "enter":
+		rec, ok := m.selectedRecord()
+		label, focused := m.focusedField()
+		if m.fieldSaving || !ok || !focused {
+			return m, nil
+		}
+		column, _ := inlineColumn(label)
+		if _, err := rec.WithField(column, m.fieldInput); err != nil {
+			m.fieldErr = label + ": " + err.Error()
+			return m, nil
+		}
+		m.fieldSaving = true
+		m.fieldErr = ""
+		return m, updateField(m.ctx, m.store, rec.RecordID, column, m.fieldInput)
+	case "backspace":
+		if m.fieldSaving {
+			return m, nil
+		}
+		runes := []rune(m.fieldInput)
+		if len(runes) > 0 {
+			m.fieldInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	default:
+		if m.fieldSaving {
+			return m, nil
+		}
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.fieldInput) < maxSearchRunes {
+			m.fieldInput += string(r)
+		}
+		return m, nil
+	}
+}
+
+func (m Model) handleFieldUpdated(msg fieldUpdatedMsg) (tea.Model, tea.Cmd) {
+	m.fieldSaving = false
+	if msg.err != nil {
+		m.fieldErr = msg.err.Error()
+		return m, nil
+	}
+	m.fieldEditing = false
+	m.fieldErr = ""
+	m.records = withRecordField(m.records, msg.id, msg.column, msg.value)
+	m.filtered = withRecordField(m.filtered, msg.id, msg.column, msg.value)
+	return m, m.setStatus(m.detailFocus + " updated.")
+}
+
+func withRecordField(records []db.Record, id, column, value string) []db.Record {
+	out := make([]db.Record, len(records))
+	copy(out, records)
+	for i := range out {
+		if out[i].RecordID == id {
+			if r, err := out[i].WithField(column, value); err == nil {
+				out[i] = r
+			}
+		}
+	}
+	return out
+}
+
+// renderFieldEdit is the inline prompt that replaces the detail view's
+// help line while a field is being edited.
+func (m Model) renderFieldEdit() string {
+	var b strings.Builder
+	input := m.fieldInput
+	if !m.fieldSaving {
+		input += "█"
+	}
+	b.WriteString(m.styles.search.Render(m.detailFocus + ": " + input))
+	b.WriteString("\n")
+	if m.fieldSaving {
+		b.WriteString(m.styles.statusBar.Render("Saving..."))
+		b.WriteString("\n")
+	}
+	if m.fieldErr != "" {
+		b.WriteString(m.styles.error.Render("  " + m.fieldErr))
+		b.WriteString("\n")
+	}
+	if m.discardPrompt {
+		b.WriteString(m.renderDiscardPrompt())
+		return b.String()
+	}
+	b.WriteString(m.helpLine(fieldEditKeys))
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func inlineEditModel() (Model, *mockStore) {
+	catalog := "CL 1355"
+	recs := testRecords()
+	recs[0].CatalogNumber = &catalog
+	m := newTestModel(recs)
+	m.view = detailView
+	return m, m.store.(*mockStore)
+}
+
+func pressKeys(m Model, keys ...tea.KeyPressMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var updated tea.Model
+		updated, cmd = m.Update(k)
+		m = updated.(Model)
+	}
+	return m, cmd
+}
+
+func TestDetailFocusCycles(t *testing.T) {
+	m, _ := inlineEditModel()
+	shiftTab := tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}
+
+	var got []string
+	for range 8 {
+		m, _ = pressKeys(m, keyMsg("tab"))
+		got = append(got, m.detailFocus)
+	}
+	want := "Artist,Album,Year,Label,Size,Color,Catalog #,Artist"
+	if strings.Join(got, ",") != want {
+		t.Errorf("tab focus = %v, want %s", got, want)
+	}
+
+	m, _ = pressKeys(m, shiftTab)
+	if m.detailFocus != "Catalog #" {
+		t.Errorf("shift+tab from Artist focused %q, want Catalog # (wrap)", m.detailFocus)
+	}
+
+	// The next record has no catalog number, so nothing is focused there.
+	m, _ = pressKeys(m, tea.KeyPressMsg{Code: tea.KeyDown})
+	if _, ok := m.focusedField(); ok {
+		t.Error("a field the record doesn't show should not count as focused")
+	}
+
+	m, _ = pressKeys(m, keyMsg("esc"))
+	if m.detailFocus != "" {
+		t.Error("leaving the detail view should drop the focus")
+	}
+}
+
+func TestInlineFieldEdit(t *testing.T) {
+	m, store := inlineEditModel()
+	m, _ = pressKeys(m, keyMsg("tab"), keyMsg("tab"), keyMsg("tab"), keyMsg("tab"), keyMsg("e"))
+	if !m.fieldEditing || m.detailFocus != "Label" || m.fieldInput != "" {
+		t.Fatalf("e on Label: editing=%v focus=%q input=%q, want an empty Label prompt", m.fieldEditing, m.detailFocus, m.fieldInput)
+	}
+	if view := m.View().Content; !strings.Contains(view, "Label: █") {
+		t.Errorf("detail view should show the inline prompt:\n%s", view)
+	}
+
+	for _, r := range "Columbia" {
+		m, _ = pressKeys(m, keyMsg(string(r)))
+	}
+	m, cmd := pressKeys(m, keyMsg("enter"))
+	if !m.fieldSaving || cmd == nil {
+		t.Fatal("enter should save the field")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+
+	if m.fieldEditing || m.status != "Label updated." {
+		t.Errorf("after saving: editing=%v status=%q", m.fieldEditing, m.status)
+	}
+	if rec, _ := m.selectedRecord(); rec.LabelString() != "Columbia" {
+		t.Errorf("label = %q, want Columbia", rec.LabelString())
+	}
+	if got := strings.Join(store.fieldUpdates, ";"); got != "1 label_name=Columbia" {
+		t.Errorf("store updates = %q, want the one label change", got)
+	}
+}
+
+func TestInlineFieldEditRejectsBadValue(t *testing.T) {
+	m, store := inlineEditModel()
+	m, _ = pressKeys(m, keyMsg("tab"), keyMsg("tab"), keyMsg("tab"), keyMsg("e"))
+	for _, r := range "soon" {
+		m, _ = pressKeys(m, keyMsg(string(r)))
+	}
+	m, cmd := pressKeys(m, keyMsg("enter"))
+	if cmd != nil || !m.fieldEditing || !strings.Contains(m.fieldErr, "year must be a number") {
+		t.Errorf("bad year: cmd=%v editing=%v err=%q, want the prompt kept with an error", cmd != nil, m.fieldEditing, m.fieldErr)
+	}
+	if len(store.fieldUpdates) != 0 {
+		t.Errorf("a bad value reached the store: %v", store.fieldUpdates)
+	}
+
+	m, _ = pressKeys(m, keyMsg("esc"))
+	if m.fieldEditing || m.view != detailView {
+		t.Error("esc should cancel the inline edit and stay in the detail view")
+	}
+}
+
+func TestInlineFieldEditWithoutFocusOpensForm(t *testing.T) {
+	m, _ := inlineEditModel()
+	m, _ = pressKeys(m, keyMsg("e"))
+	if m.view != addManualView || m.manualEditing == nil || m.manualEditing.RecordID != "1" {
+		t.Errorf("e with no focused field should open the edit form, got view %d", m.view)
+	}
+}
+
+func TestInlineFieldEditReadOnly(t *testing.T) {
+	m, _ := inlineEditModel()
+	m = m.WithReadOnly(true)
+	m, _ = pressKeys(m, keyMsg("tab"), keyMsg("e"))
+	if m.fieldEditing || m.status != readOnlyStatus {
+		t.Errorf("read-only e: editing=%v status=%q", m.fieldEditing, m.status)
+	}
+}
+
+func TestWithRecordField(t *testing.T) {
+	recs := testRecords()
+	out := withRecordField(recs, "2", "year_released", "1965")
+	if out[1].YearString() != "1965" || recs[1].YearReleased != nil {
+		t.Errorf("withRecordField should set the year on a copy, got %s", out[1].YearString())
+	}
+	if out := withRecordField(recs, "2", "album_title", ""); out[1].AlbumTitle != recs[1].AlbumTitle {
+		t.Error("an invalid value should leave the record alone")
+	}
+}
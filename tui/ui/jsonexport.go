@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type jsonExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+func defaultJSONExportPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return "record-collection.json"
+	}
+	return filepath.Join(home, "record-collection.json")
+}
+
+// writeRecordsJSON writes records to path as an indented JSON array,
+// replacing the file only once the whole array has been written.
+func writeRecordsJSON(records []db.Record, path string) error {
+	if records == nil {
+		records = []db.Record{}
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode records: %w", err)
+	}
+	return replaceFile(path, append(data, '\n'))
+}
+
+// replaceFile writes data to a temp file next to path and renames it into
+// place, so a failed export never leaves a half-written file behind.
+func replaceFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create export dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".export-*"+filepath.Ext(path))
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr == nil {
+		werr = cerr
+	}
+	if werr == nil {
+		werr = os.Rename(tmp.Name(), path)
+	}
+	if werr != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write %s: %w", path, werr)
+	}
+	return nil
+}
+
+func exportJSON(records []db.Record, path string) tea.Cmd {
+	records = append([]db.Record(nil), records...)
+	return func() tea.Msg {
+		return jsonExportedMsg{path: path, count: len(records), err: writeRecordsJSON(records, path)}
+	}
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestWriteRecordsJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "out.json")
+	if err := writeRecordsJSON(testRecords(), path); err != nil {
+		t.Fatalf("writeRecordsJSON: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []db.Record
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output is not a JSON array of records: %v", err)
+	}
+	if len(got) != 3 || got[0].ArtistName != "Miles Davis" {
+		t.Errorf("round-trip = %+v", got)
+	}
+
+	if err := writeRecordsJSON(nil, path); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); strings.TrimSpace(string(data)) != "[]" {
+		t.Errorf("empty export = %q, want []", data)
+	}
+}
+
+func TestJSONExportFlow(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.search = "blue"
+	m.applyFilters()
+
+	updated, _ := m.Update(keyMsg("J"))
+	m = updated.(Model)
+	if !m.jsonExportPrompt {
+		t.Fatal("J should open the export prompt")
+	}
+	if !strings.Contains(m.renderList(), "Export JSON to:") {
+		t.Error("list should show the path prompt")
+	}
+	path := filepath.Join(t.TempDir(), "records.json")
+	m.jsonExportPath = path
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if m.jsonExportPrompt || cmd == nil {
+		t.Fatal("enter should close the prompt and start the export")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !strings.Contains(m.status, "Exported 1 records") {
+		t.Errorf("status = %q, want export summary", m.status)
+	}
+
+	var got []db.Record
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &got); err != nil || len(got) != 1 || got[0].AlbumTitle != "Kind of Blue" {
+		t.Errorf("exported %s, want only the filtered record", data)
+	}
+}
+
+func TestJSONExportError(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(jsonExportedMsg{path: "/x.json", err: os.ErrPermission})
+	m = updated.(Model)
+	if !strings.Contains(m.renderList(), "json export: permission denied") {
+		t.Error("failure should show in the list")
+	}
+}
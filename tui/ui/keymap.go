@@ -0,0 +1,239 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// keyBinding is one entry of a view's keys. Brief bindings make up the
+// status-line help of the busier views; the ? overlay lists every one.
+type keyBinding struct {
+	keys     []string
+	help     string
+	desc     string
+	mutating bool
+	brief    bool
+}
+
+var listKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll", brief: true},
+	{keys: []string{"pgup", "pgdown"}, help: "pgup/pgdn", desc: "page"},
+	{keys: []string{"enter"}, help: "enter", desc: "detail", brief: true},
+	{keys: []string{"[", "]"}, help: "[]", desc: "letter"},
+	{keys: []string{"z", "Z"}, help: "z/Z", desc: "random/open"},
+	{keys: []string{"a"}, help: "a", desc: "add discogs", mutating: true, brief: true},
+	{keys: []string{"m"}, help: "m", desc: "add manual", mutating: true},
+	{keys: []string{"e"}, help: "e", desc: "edit", mutating: true},
+	{keys: []string{"space"}, help: "space", desc: "select"},
+	{keys: []string{"d", "y"}, help: "d", desc: "delete", mutating: true},
+	{keys: []string{"/"}, help: "/", desc: "search", brief: true},
+	{keys: []string{":"}, help: ":", desc: "command"},
+	{keys: []string{"o", "O"}, help: "o/O", desc: "sort/order"},
+	{keys: []string{"u"}, help: "u", desc: "not on discogs"},
+	{keys: []string{"Y"}, help: "Y", desc: "year range"},
+	{keys: []string{"c"}, help: "c", desc: "clear filters"},
+	{keys: []string{"s"}, help: "s", desc: "sync", mutating: true},
+	{keys: []string{"v"}, help: "v", desc: "report"},
+	{keys: []string{"w"}, help: "w", desc: "changes"},
+	{keys: []string{"S"}, help: "S", desc: "stats"},
+	{keys: []string{"D"}, help: "D", desc: "duplicates"},
+	{keys: []string{"L"}, help: "L", desc: "labels"},
+	{keys: []string{"b"}, help: "b", desc: "genres"},
+	{keys: []string{"X"}, help: "X", desc: "export covers"},
+	{keys: []string{"J"}, help: "J", desc: "export json"},
+	{keys: []string{"R"}, help: "R", desc: "reverse"},
+	{keys: []string{"#"}, help: "#", desc: "line numbers"},
+	{keys: []string{"I"}, help: "I", desc: "thumbnails"},
+	{keys: []string{"T"}, help: "T", desc: "theme"},
+	{keys: []string{"r"}, help: "r", desc: "reload"},
+	{keys: []string{"q", "ctrl+c"}, help: "q", desc: "quit", brief: true},
+	{keys: []string{"?"}, help: "?", desc: "all keys", brief: true},
+}
+
+var disconnectedKeys = []keyBinding{
+	{keys: []string{"r"}, help: "r", desc: "retry connection"},
+	{keys: []string{"q", "ctrl+c"}, help: "q", desc: "quit"},
+}
+
+var searchKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "history"},
+	{keys: []string{"enter"}, help: "enter", desc: "confirm"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var coverExportPromptKeys = []keyBinding{
+	{keys: []string{"enter"}, help: "enter", desc: "start export"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var yearPromptKeys = []keyBinding{
+	{keys: []string{"enter"}, help: "enter", desc: "apply range"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var commandKeys = []keyBinding{
+	{keys: []string{"enter"}, help: "enter", desc: "run"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var detailKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "prev/next record", brief: true},
+	{keys: []string{"k", "j"}, help: "j/k", desc: "scroll info"},
+	{keys: []string{"tab", "shift+tab"}, help: "tab", desc: "focus field", brief: true},
+	{keys: []string{"e"}, help: "e", desc: "edit field", mutating: true, brief: true},
+	{keys: []string{"i"}, help: "i", desc: "image protocol"},
+	{keys: []string{"g"}, help: "g", desc: "same genre"},
+	{keys: []string{"t"}, help: "t", desc: "tags", mutating: true},
+	{keys: []string{"n"}, help: "n", desc: "notes", mutating: true},
+	{keys: []string{"1", "2", "3", "4", "5", "0"}, help: "1-5/0", desc: "rate/unrate", mutating: true},
+	{keys: []string{"p"}, help: "p", desc: "played", mutating: true},
+	{keys: []string{"s"}, help: "s", desc: "sync from discogs", mutating: true},
+	{keys: []string{"y"}, help: "y", desc: "copy discogs link"},
+	{keys: []string{"o"}, help: "o", desc: "open discogs"},
+	{keys: []string{"q", "esc", "backspace"}, help: "esc/q", desc: "back", brief: true},
+	{keys: []string{"?"}, help: "?", desc: "all keys", brief: true},
+}
+
+var tagEditKeys = []keyBinding{
+	{keys: []string{"enter"}, help: "enter", desc: "save tags"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var fieldEditKeys = []keyBinding{
+	{keys: []string{"enter"}, help: "enter", desc: "save field"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var noteEditKeys = []keyBinding{
+	{keys: []string{"enter"}, help: "enter", desc: "save notes"},
+	{keys: []string{"esc"}, help: "esc", desc: "cancel"},
+}
+
+var changesKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll"},
+	{keys: []string{"q", "esc", "w"}, help: "esc/q", desc: "back"},
+	{keys: []string{"?"}, help: "?", desc: "all keys"},
+}
+
+var statsKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll"},
+	{keys: []string{"r"}, help: "r", desc: "refresh"},
+	{keys: []string{"q", "esc", "S"}, help: "esc/q", desc: "back"},
+	{keys: []string{"?"}, help: "?", desc: "all keys"},
+}
+
+var syncReportKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll"},
+	{keys: []string{"enter"}, help: "enter", desc: "open record"},
+	{keys: []string{"q", "esc", "v"}, help: "esc/q", desc: "back"},
+	{keys: []string{"?"}, help: "?", desc: "all keys"},
+}
+
+var duplicatesKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll"},
+	{keys: []string{"enter"}, help: "enter", desc: "open record"},
+	{keys: []string{"d", "y"}, help: "d", desc: "delete", mutating: true},
+	{keys: []string{"r"}, help: "r", desc: "refresh"},
+	{keys: []string{"q", "esc", "D"}, help: "esc/q", desc: "back"},
+	{keys: []string{"?"}, help: "?", desc: "all keys"},
+}
+
+var labelsKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll"},
+	{keys: []string{"enter"}, help: "enter", desc: "filter list"},
+	{keys: []string{"r"}, help: "r", desc: "refresh"},
+	{keys: []string{"q", "esc", "L"}, help: "esc/q", desc: "back"},
+	{keys: []string{"?"}, help: "?", desc: "all keys"},
+}
+
+var genresKeys = []keyBinding{
+	{keys: []string{"up", "down"}, help: "↑↓", desc: "scroll"},
+	{keys: []string{"enter"}, help: "enter", desc: "filter list"},
+	{keys: []string{"o"}, help: "o", desc: "sort by count/name"},
+	{keys: []string{"r"}, help: "r", desc: "refresh"},
+	{keys: []string{"q", "esc", "b"}, help: "esc/q", desc: "back"},
+	{keys: []string{"?"}, help: "?", desc: "all keys"},
+}
+
+// keyGroup is one section of the help overlay.
+type keyGroup struct {
+	title    string
+	bindings []keyBinding
+}
+
+var helpGroups = []keyGroup{
+	{"List", listKeys},
+	{"Search", searchKeys},
+	{"Detail", detailKeys},
+	{"Tag editing", tagEditKeys},
+	{"Note editing", noteEditKeys},
+	{"Field editing", fieldEditKeys},
+	{"Export prompts", coverExportPromptKeys},
+	{"Year range", yearPromptKeys},
+	{"Command", commandKeys},
+	{"Changes", changesKeys},
+	{"Sync report", syncReportKeys},
+	{"Stats", statsKeys},
+	{"Duplicates", duplicatesKeys},
+	{"Labels", labelsKeys},
+	{"Genres", genresKeys},
+}
+
+// readOnlyStatus answers a mutating key pressed in read-only mode.
+const readOnlyStatus = "Read-only mode: changes are disabled."
+
+// bindingEnabled reports whether b is usable in the current mode.
+func (m Model) bindingEnabled(b keyBinding) bool {
+	return !b.mutating || !m.readOnly
+}
+
+// keyDisabled reports whether key belongs to a binding the current mode
+// has switched off.
+func (m Model) keyDisabled(bindings []keyBinding, key string) bool {
+	for _, b := range bindings {
+		for _, k := range b.keys {
+			if k == key {
+				return !m.bindingEnabled(b)
+			}
+		}
+	}
+	return false
+}
+
+// briefKeys returns the bindings marked brief.
+func briefKeys(bindings []keyBinding) []keyBinding {
+	var out []keyBinding
+	for _, b := range bindings {
+		if b.brief {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (m Model) helpLine(bindings []keyBinding) string {
+	return m.fitHelpLine(bindings, m.width)
+}
+
+// fitHelpLine renders bindings as a help line at most width columns wide.
+// Entries are dropped from before the last one (the ? hint, where there is
+// one) until the line fits; width <= 0 means unlimited.
+func (m Model) fitHelpLine(bindings []keyBinding, width int) string {
+	var items []string
+	for _, b := range bindings {
+		if !m.bindingEnabled(b) {
+			continue
+		}
+		items = append(items, m.helpItem(b.help, b.desc))
+	}
+	line := "  " + strings.Join(items, m.helpSep())
+	if width <= 0 {
+		return line
+	}
+	for ansi.StringWidth(line) > width && len(items) > 1 {
+		items = append(items[:len(items)-2], items[len(items)-1])
+		line = "  " + strings.Join(items, m.helpSep())
+	}
+	return ansi.Truncate(line, width, "…")
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"context"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+// unknownLabel names the bucket the store counts unlabelled records under
+// as "".
+const unknownLabel = "Unknown"
+
+type labelsLoadedMsg struct {
+	labels []db.Count
+	err    error
+}
+
+func loadLabels(ctx context.Context, store db.Store) tea.Cmd {
+	return func() tea.Msg {
+		labels, err := store.Labels(ctx)
+		return labelsLoadedMsg{labels: labels, err: err}
+	}
+}
+
+func labelName(label string) string {
+	if label == "" {
+		return unknownLabel
+	}
+	return label
+}
+
+func (m Model) openLabels() (tea.Model, tea.Cmd) {
+	m.view = labelsView
+	m.labelBrowser.load()
+	m.deleteConfirm = false
+	return m, loadLabels(m.ctx, m.store)
+}
+
+func (m Model) handleLabelsLoaded(msg labelsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.labelBrowser.loaded(msg.labels, msg.err, m.labelFilter, m.listVisibleRows())
+	return m, nil
+}
+
+func (m Model) handleLabelsKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "q", "esc", "L":
+		m.view = listView
+	case "up":
+		m.labelBrowser.move(-1, m.listVisibleRows())
+	case "down":
+		m.labelBrowser.move(1, m.listVisibleRows())
+	case "enter":
+		if c, ok := m.labelBrowser.selected(); ok {
+			m.labelFilter = new(c.Name)
+			m.view = listView
+			m.loading = true
+			return m, m.fetchRecords(false)
+		}
+	case "r":
+		return m.openLabels()
+	}
+	return m, nil
+}
+
+func (m Model) renderLabels() string {
+	return m.renderCountBrowser(m.labelBrowser, "Labels", "labels", labelName, labelsKeys)
+}
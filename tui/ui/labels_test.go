@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+func labelRecords() []db.Record {
+	blueNote, impulse := "Blue Note", "Impulse!"
+	return []db.Record{
+		{RecordID: "1", ArtistName: "Art Blakey", AlbumTitle: "Moanin'", LabelName: &blueNote},
+		{RecordID: "2", ArtistName: "John Coltrane", AlbumTitle: "A Love Supreme", LabelName: &impulse},
+		{RecordID: "3", ArtistName: "Lee Morgan", AlbumTitle: "The Sidewinder", LabelName: &blueNote},
+		{RecordID: "4", ArtistName: "Unknown Artist", AlbumTitle: "White Label"},
+	}
+}
+
+func TestLabelsViewLists(t *testing.T) {
+	m := newTestModel(labelRecords())
+
+	updated, cmd := m.Update(keyMsg("L"))
+	m = updated.(Model)
+	if m.view != labelsView || !m.labelBrowser.loading || cmd == nil {
+		t.Fatal("L should open the label browser and load the counts")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	var rows []string
+	for _, line := range strings.Split(ansi.Strip(m.View().Content), "\n") {
+		if f := strings.Fields(line); len(f) >= 2 && (strings.HasPrefix(f[0], "Blue") || f[0] == "Impulse!" || f[0] == unknownLabel) {
+			rows = append(rows, strings.Join(f, " "))
+		}
+	}
+	want := []string{"Blue Note 2", "Impulse! 1", "Unknown 1"}
+	if strings.Join(rows, "|") != strings.Join(want, "|") {
+		t.Errorf("label rows = %q, want %q", rows, want)
+	}
+
+	updated, _ = m.Update(keyMsg("L"))
+	if updated.(Model).view != listView {
+		t.Error("L in the label browser should go back to the list")
+	}
+}
+
+func TestLabelsFilterList(t *testing.T) {
+	tests := []struct {
+		name  string
+		downs int
+		want  []string
+		title string
+	}{
+		{"label", 0, []string{"1", "3"}, `on label "Blue Note"`},
+		{"unknown", 2, []string{"4"}, `on label "Unknown"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(labelRecords())
+			updated, cmd := m.Update(keyMsg("L"))
+			updated, _ = updated.(Model).Update(cmd())
+			m = updated.(Model)
+			for range tt.downs {
+				updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+				m = updated.(Model)
+			}
+			updated, cmd = m.Update(keyMsg("enter"))
+			m = updated.(Model)
+			if m.view != listView || cmd == nil {
+				t.Fatal("enter should return to the list and reload it filtered")
+			}
+			updated, _ = m.Update(cmd())
+			m = updated.(Model)
+
+			var ids []string
+			for _, r := range m.filtered {
+				ids = append(ids, r.RecordID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("filtered = %v, want %v", ids, tt.want)
+			}
+			if view := m.View().Content; !strings.Contains(view, tt.title) {
+				t.Errorf("title should say %s:\n%s", tt.title, view)
+			}
+
+			updated, _ = m.Update(keyMsg("c"))
+			if updated.(Model).labelFilter != nil {
+				t.Error("c should clear the label filter")
+			}
+		})
+	}
+}
+
+func TestLabelsReopenOnCurrentFilter(t *testing.T) {
+	m := newTestModel(labelRecords())
+	m.labelFilter = new("Impulse!")
+	updated, cmd := m.Update(keyMsg("L"))
+	updated, _ = updated.(Model).Update(cmd())
+	if c := updated.(Model).labelBrowser.cursor; c != 1 {
+		t.Errorf("cursor = %d, want 1 on the filtered label", c)
+	}
+}
+
+func TestLabelsLoadError(t *testing.T) {
+	m := newTestModel(labelRecords())
+	updated, _ := m.Update(keyMsg("L"))
+	updated, _ = updated.(Model).Update(labelsLoadedMsg{err: errors.New("boom")})
+	if view := updated.(Model).View().Content; !strings.Contains(view, "boom") {
+		t.Errorf("view should show the load error:\n%s", view)
+	}
+}
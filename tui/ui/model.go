@@ -2,14 +2,19 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"image/color"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"my-record-collection-tui/db"
 )
 
@@ -20,14 +25,33 @@ const (
 	detailView
 	addDiscogsView
 	addManualView
+	changesView
+	syncReportView
+	statsView
+	duplicatesView
+	labelsView
+	genresView
 )
 
 const maxSearchRunes = 200
 
+// maxListSyncErrors caps the sync errors shown under the list; the rest
+// are in the sync report.
+const maxListSyncErrors = 3
+
 var sqlInjectionPattern = regexp.MustCompile(`(?i)(--|/\*|\*/|;|\b(select|union|drop|delete|insert|update|alter|truncate|create)\b)`)
 
 type Model struct {
-	store                db.Store
+	store         db.Store
+	connect       ConnectFunc
+	queryTimeout  time.Duration
+	queries       context.Context
+	cancelQueries context.CancelFunc
+
+	// ctx is the parent of every database call and image download the
+	// model starts; quitting cancels it.
+	ctx                  context.Context
+	cancel               context.CancelFunc
 	discogsUsername      string
 	discogsCfg           discogsConfig
 	records              []db.Record
@@ -41,13 +65,28 @@ type Model struct {
 	searching            bool
 	err                  error
 	loading              bool
+	loadingSearch        string // query in flight while loading; empty for a plain load
+	healthInterval       time.Duration
+	healthChecking       bool
+	connLost             error // set while background pings can't reach the database
+	total                int
 	imgCache             *imageCache
+	imgDiskCache         *diskCache
 	imgProto             imageProto
 	artRender            string
+	protosDrawn          uint8
+	thumbnails           bool
+	thumbInFlight        int
 	artLoading           bool
+	spinnerID            int
+	artLoadID            int
+	detailOffset         int
+	spinnerFrame         int
 	deleteConfirm        bool
 	deleteErr            string
 	deleting             bool
+	selected             map[string]bool
+	showHelp             bool
 	discogsSearchMethod  discogsSearchMethod
 	discogsArtist        string
 	discogsTitle         string
@@ -60,7 +99,72 @@ type Model struct {
 	discogsErr           string
 	discogsSearching     bool
 	discogsSaving        bool
-	successMsg           string
+	status               string
+	statusAt             time.Time
+	detailErr            string
+	recordSyncing        bool
+	readOnly             bool
+	lastLaunch           time.Time
+	changesOffset        int
+	stats                db.Stats
+	statsLoading         bool
+	statsErr             string
+	statsOffset          int
+	tagEditing           bool
+	tagInput             string
+	tagSaving            bool
+	tagErr               string
+	noteEditing          bool
+	noteInput            string
+	noteOriginal         string
+	noteSaving           bool
+	noteErr              string
+	detailFocus          string
+	fieldEditing         bool
+	fieldInput           string
+	fieldOriginal        string
+	fieldSaving          bool
+	fieldErr             string
+	discardPrompt        bool
+	discardQuits         bool
+	coverExportPrompt    bool
+	coverExportDir       string
+	coverExporting       bool
+	coverExportProgress  coverExportProgress
+	coverExportErr       string
+	jsonExportPrompt     bool
+	jsonExportPath       string
+	jsonExportErr        string
+	commandMode          bool
+	commandInput         string
+	commandErr           string
+	reverseList          bool
+	lineNumbers          bool
+	stripes              bool
+	sortCol              sortColumn
+	genreFilter          string
+	tagFilter            string
+	labelFilter          *string
+	unsyncedOnly         bool
+	yearRange            yearRange
+	yearPrompt           bool
+	yearInput            string
+	yearErr              string
+	recordsSearched      bool
+	searchSeq            int
+	searchHistory        []string
+	historyPos           int
+	searchDraft          string
+	searchDebounce       time.Duration
+	searchMatches        map[string][]int
+	sortDesc             bool
+	ignoreArticles       bool
+	pageSize             int
+	columns              []listColumn
+
+	colorDepth colorDepth
+	themeIdx   int
+	styles     styles
 
 	syncing     bool
 	syncPhase   string
@@ -70,21 +174,42 @@ type Model struct {
 	syncTotal   int
 	syncErrors  []string
 
+	randIntN func(int) int
+
+	syncResults      []syncResult
+	syncReportCursor int
+	syncReportOffset int
+
+	dupGroups        []db.DuplicateGroup
+	dupCursor        int
+	dupOffset        int
+	dupLoading       bool
+	dupErr           string
+	dupDeleteConfirm bool
+	dupDeleting      bool
+
+	labelBrowser countBrowser
+	genreBrowser countBrowser
+	genresByName bool
+
 	manualArtist  string
 	manualAlbum   string
 	manualYear    string
 	manualLabel   string
 	manualCatalog string
 	manualGenres  string
+	manualTags    string
 	manualSize    string
 	manualColor   string
 	manualCursor  int
 	manualSaving  bool
 	manualErr     string
+	manualInvalid db.ValidationErrors
+	manualEditing *db.Record
 }
 
 func NewModel(store db.Store, discogsUsername, discogsToken, discogsUserAgent string) Model {
-	return Model{
+	m := Model{
 		store:               store,
 		discogsUsername:     discogsUsername,
 		discogsCfg:          discogsConfig{token: discogsToken, userAgent: discogsUserAgent},
@@ -92,16 +217,128 @@ func NewModel(store db.Store, discogsUsername, discogsToken, discogsUserAgent st
 		imgCache:            newImageCache(),
 		imgProto:            detectImageProto(),
 		discogsSearchMethod: discogsSearchArtistTitle,
+		colorDepth:          detectColorDepth(),
+		columns:             parseListColumns(defaultListColumns),
+		searchDebounce:      defaultSearchDebounce,
+		healthInterval:      defaultHealthInterval,
+		randIntN:            newRandIntN(),
+	}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.setTheme(0)
+	return m
+}
+
+// WithTheme selects a built-in theme by name; unknown names fall back to
+// Mocha.
+func (m Model) WithTheme(name string) Model {
+	m.setTheme(themeIndex(name))
+	return m
+}
+
+// WithImageProtocol overrides terminal detection with a protocol name
+// ("kitty", "iterm2", "sixel", "halfblock", "mosaic"). Empty or unknown
+// names keep the detected protocol.
+func (m Model) WithImageProtocol(name string) Model {
+	if p, ok := parseImageProto(name); ok {
+		m.imgProto = p
+	}
+	return m
+}
+
+// WithImageTimeout sets how long a cover download may take; d <= 0 keeps
+// the default. The client is shared process-wide, so call it before Run.
+func (m Model) WithImageTimeout(d time.Duration) Model {
+	if d > 0 {
+		imageClient = newImageClient(d)
 	}
+	return m
+}
+
+// WithPageSize sets how many rows pgup/pgdown move; n <= 0 uses the
+// visible list height.
+func (m Model) WithPageSize(n int) Model {
+	m.pageSize = max(n, 0)
+	return m
+}
+
+// WithSortColumn sets the initial sort column by name; unknown names keep
+// the artist sort.
+func (m Model) WithSortColumn(name string) Model {
+	if c, ok := parseSortColumn(name); ok {
+		m.sortCol = c
+	}
+	return m
+}
+
+// WithIgnoreArticles sorts artists without a leading "The", "A" or "An",
+// so "The Rolling Stones" files under R.
+func (m Model) WithIgnoreArticles(on bool) Model {
+	m.ignoreArticles = on
+	return m
+}
+
+// WithReverseList shows m.filtered bottom-up without changing sort order.
+func (m Model) WithReverseList(on bool) Model {
+	m.reverseList = on
+	return m
+}
+
+// WithImageDiskCache keeps downloaded cover art under dir between runs,
+// trimmed to maxBytes and dropping entries older than ttl (0 disables
+// either limit).
+func (m Model) WithImageDiskCache(dir string, maxBytes int64, ttl time.Duration) Model {
+	if dir == "" {
+		dir = defaultImageCacheDir()
+	}
+	if dir != "" {
+		m.imgDiskCache = newDiskCache(dir, maxBytes, ttl)
+	}
+	return m
+}
+
+// WithReadOnly switches off every key that would change the collection.
+func (m Model) WithReadOnly(on bool) Model {
+	m.readOnly = on
+	return m
+}
+
+// WithLineNumbers shows a leading column numbering the filtered list from 1.
+func (m Model) WithLineNumbers(on bool) Model {
+	m.lineNumbers = on
+	return m
+}
+
+// WithStripes shades every other list row.
+func (m Model) WithStripes(on bool) Model {
+	m.stripes = on
+	return m
+}
+
+// WithLastLaunch sets the time of the previous run, used by the
+// "changed since last launch" view.
+func (m Model) WithLastLaunch(t time.Time) Model {
+	m.lastLaunch = t
+	return m
 }
 
 type recordsLoadedMsg struct {
-	records []db.Record
-	err     error
+	records      []db.Record
+	err          error
+	total        int // records in the whole collection
+	keepPosition bool
+	searched     bool
+	query        string
+	seq          int // searchSeq when a search started
 }
 
+// imageLoadedMsg carries a finished cover load. id is the artLoadID the
+// load was started under; a result from an earlier load only fills the
+// cache.
 type imageLoadedMsg struct {
+	id       int
 	url      string
+	proto    imageProto
+	size     artSize
 	render   string
 	transmit string
 }
@@ -123,6 +360,12 @@ type manualRecordAddedMsg struct {
 	err error
 }
 
+type tagsSavedMsg struct {
+	id   string
+	tags []string
+	err  error
+}
+
 type syncProgressMsg struct {
 	progress syncProgress
 }
@@ -132,59 +375,97 @@ type syncDoneMsg struct {
 	progress syncProgress
 }
 
-func loadRecords(store db.Store) tea.Cmd {
+func loadRecords(ctx context.Context, store db.Store) tea.Cmd {
 	return func() tea.Msg {
-		records, err := store.List(context.Background())
-		return recordsLoadedMsg{records: records, err: err}
+		records, err := store.List(ctx)
+		return recordsLoadedMsg{records: records, err: err, total: len(records)}
 	}
 }
 
-func searchRecords(store db.Store, query string) tea.Cmd {
+// fetchRecords reloads the list, through ListByYearRange or FilterByGenre
+// while one of those filters is active. With keepPosition the cursor stays
+// put, clamped to the new list.
+func (m Model) fetchRecords(keepPosition bool) tea.Cmd {
+	ctx, store, genre, tag, label, years := m.ctx, m.store, m.genreFilter, m.tagFilter, m.labelFilter, m.yearRange
 	return func() tea.Msg {
-		records, err := store.Search(context.Background(), query)
-		return recordsLoadedMsg{records: records, err: err}
+		var records []db.Record
+		var err error
+		if years.active() {
+			records, err = store.ListByYearRange(ctx, years.from, years.to)
+		} else if genre != "" {
+			records, err = store.FilterByGenre(ctx, genre)
+		} else if tag != "" {
+			records, err = store.ListByTag(ctx, tag)
+		} else if label != nil {
+			records, err = store.ListByLabel(ctx, *label)
+		} else {
+			records, err = store.List(ctx)
+		}
+		total := len(records)
+		if err == nil && (years.active() || genre != "" || tag != "" || label != nil) {
+			total, err = store.Total(ctx)
+		}
+		return recordsLoadedMsg{records: records, err: err, total: total, keepPosition: keepPosition}
+	}
+}
+
+func searchRecords(ctx context.Context, store db.Store, query string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		records, err := store.Search(ctx, query)
+		var total int
+		if err == nil {
+			total, err = store.Total(ctx)
+		}
+		return recordsLoadedMsg{records: records, err: err, total: total, searched: true, query: query, seq: seq}
 	}
 }
 
-func loadImage(proto imageProto, url string, width, height int) tea.Cmd {
+func loadImage(ctx context.Context, id int, cache *diskCache, proto imageProto, url string, size artSize, bg color.Color) tea.Cmd {
 	return func() tea.Msg {
-		result, _ := fetchAndRender(proto, url, width, height)
-		return imageLoadedMsg{url: url, render: result.render, transmit: result.transmit}
+		result, _ := fetchAndRender(ctx, cache, proto, url, size.w, size.h, bg)
+		return imageLoadedMsg{id: id, url: url, proto: proto, size: size, render: result.render, transmit: result.transmit}
 	}
 }
 
-func deleteRecord(store db.Store, id string) tea.Cmd {
+func deleteRecord(ctx context.Context, store db.Store, id string) tea.Cmd {
 	return func() tea.Msg {
-		err := store.Delete(context.Background(), id)
+		err := store.Delete(ctx, id)
 		return recordDeletedMsg{err: err}
 	}
 }
 
-func runDiscogsSearch(dcfg discogsConfig, query discogsSearchQuery) tea.Cmd {
+func runDiscogsSearch(ctx context.Context, dcfg discogsConfig, query discogsSearchQuery) tea.Cmd {
 	return func() tea.Msg {
-		results, err := searchDiscogs(dcfg, query)
+		results, err := searchDiscogs(ctx, dcfg, query)
 		return discogsSearchResultsMsg{results: results, err: err}
 	}
 }
 
-func addDiscogsRecord(store db.Store, releaseID int, username string, dcfg discogsConfig) tea.Cmd {
+func addDiscogsRecord(ctx context.Context, store db.Store, releaseID int, username string, dcfg discogsConfig) tea.Cmd {
 	return func() tea.Msg {
-		err := addDiscogsReleaseToStore(store, releaseID, username, dcfg)
+		err := addDiscogsReleaseToStore(ctx, store, releaseID, username, dcfg)
 		return discogsRecordAddedMsg{err: err}
 	}
 }
 
-func addManualRecord(store db.Store, r db.Record) tea.Cmd {
+func addManualRecord(ctx context.Context, store db.Store, r db.Record) tea.Cmd {
 	return func() tea.Msg {
-		err := store.Create(context.Background(), r)
+		_, err := store.Create(ctx, r)
 		return manualRecordAddedMsg{err: err}
 	}
 }
 
-func runSync(store db.Store, username string, dcfg discogsConfig) tea.Cmd {
+func saveTags(ctx context.Context, store db.Store, id string, tags []string) tea.Cmd {
+	return func() tea.Msg {
+		err := store.SetTags(ctx, id, tags)
+		return tagsSavedMsg{id: id, tags: tags, err: err}
+	}
+}
+
+func runSync(ctx context.Context, store db.Store, username string, dcfg discogsConfig) tea.Cmd {
 	return func() tea.Msg {
 		var lastProgress syncProgress
-		err := executeSync(store, username, dcfg, func(p syncProgress) {
+		err := executeSync(ctx, store, username, dcfg, func(p syncProgress) {
 			lastProgress = p
 		})
 		return syncDoneMsg{err: err, progress: lastProgress}
@@ -192,46 +473,130 @@ func runSync(store db.Store, username string, dcfg discogsConfig) tea.Cmd {
 }
 
 func (m Model) Init() tea.Cmd {
-	return loadRecords(m.store)
+	if m.store == nil {
+		return connectStore(m.ctx, m.connect)
+	}
+	return loadRecords(m.ctx, m.store)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		oldSize := m.artSize()
 		m.width = msg.Width
 		m.height = msg.Height
-		return m, nil
+		if m.view == detailView && m.artSize() != oldSize {
+			return m.showArt()
+		}
+		return m.loadThumbnails()
+
+	case storeConnectedMsg:
+		if msg.err != nil {
+			m.loading = false
+			m.err = msg.err
+			return m, nil
+		}
+		m.attachStore(msg.store)
+		m.err = nil
+		return m, loadRecords(m.ctx, m.store)
 
 	case recordsLoadedMsg:
+		if m.staleSearch(msg) {
+			return m, nil
+		}
 		m.loading = false
+		m.loadingSearch = ""
 		if msg.err != nil {
+			if db.IsConnectionError(msg.err) {
+				m.connLost = msg.err
+				if len(m.records) > 0 {
+					// Keep the stale list up; the banner says it's stale.
+					return m, nil
+				}
+			}
 			m.err = msg.err
 			return m, nil
 		}
 		m.err = nil
+		m.connLost = nil
 		m.records = msg.records
-		m.filtered = msg.records
-		m.cursor = 0
-		m.offset = 0
+		m.total = msg.total
+		m.recordsSearched = msg.searched
+		if msg.searched {
+			m.searchHistory = pushSearchHistory(m.searchHistory, msg.query)
+		}
+		m.applyFilters()
+		m.pruneSelection()
+		if msg.keepPosition {
+			m.cursor = max(0, min(m.cursor, len(m.filtered)-1))
+			m.offset = max(0, min(m.offset, m.cursor, len(m.filtered)-m.listVisibleRows()))
+		} else {
+			m.cursor = 0
+			m.offset = 0
+		}
 		m.deleteConfirm = false
 		m.deleting = false
 		m.deleteErr = ""
-		return m, nil
+		var thumbs, health tea.Cmd
+		m, thumbs = m.loadThumbnails()
+		m, health = m.startHealthCheck()
+		return m, tea.Batch(thumbs, health)
 
 	case tea.KeyPressMsg:
-		if m.successMsg != "" {
-			m.successMsg = ""
+		if m.status != "" {
+			m.status = ""
 		}
+		m.detailErr = ""
+		m.commandErr = ""
 		if m.syncPhase == "done" {
 			m.syncPhase = ""
 			m.syncErrors = nil
 		}
 		return m.handleKey(msg)
 
+	case browserOpenedMsg:
+		if msg.err != nil {
+			m.detailErr = "open browser: " + msg.err.Error()
+		} else {
+			return m, m.setStatus("Opened " + msg.url)
+		}
+		return m, nil
+
+	case spinnerTickMsg:
+		return m.handleSpinnerTick(msg)
+
+	case searchDebounceMsg:
+		return m.handleSearchDebounce(msg)
+
+	case statusMsg:
+		return m, m.setStatus(msg.text)
+
+	case statusClearMsg:
+		return m.handleStatusClear(msg)
+
+	case healthTickMsg:
+		return m, pingStore(m.ctx, m.store)
+
+	case healthCheckedMsg:
+		return m.handleHealthChecked(msg)
+
+	case thumbLoadedMsg:
+		return m.handleThumbLoaded(msg)
+
+	case imagePrefetchedMsg:
+		m.imgCache.set(msg.url, msg.proto, msg.size, cachedImage{render: msg.render, transmit: msg.transmit})
+		return m, nil
+
 	case imageLoadedMsg:
-		m.imgCache.set(msg.url, cachedImage{render: msg.render, transmit: msg.transmit})
+		m.imgCache.set(msg.url, msg.proto, msg.size, cachedImage{render: msg.render, transmit: msg.transmit})
+		if msg.id != m.artLoadID || msg.proto != m.imgProto {
+			// Loaded for a record the user has since left, or a protocol
+			// they've cycled away from.
+			return m, nil
+		}
 		m.artRender = msg.render
 		m.artLoading = false
+		m.markDrawn()
 		if msg.transmit != "" {
 			return m, tea.Raw(msg.transmit)
 		}
@@ -246,7 +611,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.deleteErr = ""
 		m.loading = true
-		return m, loadRecords(m.store)
+		return m, m.fetchRecords(true)
+
+	case recordsDeletedMsg:
+		m.deleting = false
+		m.deleteConfirm = false
+		if msg.err != nil {
+			m.deleteErr = msg.err.Error()
+			return m, nil
+		}
+		m.deleteErr = ""
+		m.selected = nil
+		status := m.setStatus(fmt.Sprintf("Deleted %d records.", len(msg.deleted)))
+		m.loading = true
+		return m, tea.Batch(status, m.fetchRecords(true))
 
 	case discogsSearchResultsMsg:
 		m.discogsSearching = false
@@ -270,11 +648,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.discogsErr = ""
-		m.successMsg = "Record added successfully."
+		status := m.setStatus("Record added successfully.")
 		m.resetDiscogsAddState()
 		m.view = listView
 		m.loading = true
-		return m, loadRecords(m.store)
+		return m, tea.Batch(status, m.fetchRecords(false))
+
+	case statsLoadedMsg:
+		m.statsLoading = false
+		if msg.err != nil {
+			m.statsErr = msg.err.Error()
+			return m, nil
+		}
+		m.statsErr = ""
+		m.stats = msg.stats
+		return m, nil
 
 	case manualRecordAddedMsg:
 		m.manualSaving = false
@@ -283,11 +671,97 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.manualErr = ""
-		m.successMsg = "Record added successfully."
+		status := m.setStatus("Record added successfully.")
 		m.resetManualAddState()
 		m.view = listView
 		m.loading = true
-		return m, loadRecords(m.store)
+		return m, tea.Batch(status, m.fetchRecords(false))
+
+	case recordUpdatedMsg:
+		m.manualSaving = false
+		if msg.err != nil {
+			m.manualErr = msg.err.Error()
+			return m, nil
+		}
+		status := m.setStatus("Record updated.")
+		m.resetManualAddState()
+		m.view = listView
+		m.loading = true
+		return m, tea.Batch(status, m.fetchRecords(true))
+
+	case recordSyncedMsg:
+		return m.handleRecordSynced(msg)
+
+	case tagToggledMsg:
+		return m.handleTagToggled(msg)
+
+	case genresLoadedMsg:
+		return m.handleGenresLoaded(msg)
+
+	case labelsLoadedMsg:
+		return m.handleLabelsLoaded(msg)
+
+	case duplicatesFoundMsg:
+		return m.handleDuplicatesFound(msg)
+
+	case duplicateDeletedMsg:
+		return m.handleDuplicateDeleted(msg)
+
+	case tagsSavedMsg:
+		m.tagSaving = false
+		if msg.err != nil {
+			m.tagErr = msg.err.Error()
+			return m, nil
+		}
+		m.tagEditing = false
+		m.tagErr = ""
+		m.records = withRecordTags(m.records, msg.id, msg.tags)
+		m.filtered = withRecordTags(m.filtered, msg.id, msg.tags)
+		return m, nil
+
+	case fieldUpdatedMsg:
+		return m.handleFieldUpdated(msg)
+
+	case notesSavedMsg:
+		return m.handleNotesSaved(msg)
+
+	case ratingSavedMsg:
+		return m.handleRatingSaved(msg)
+
+	case playRecordedMsg:
+		return m.handlePlayRecorded(msg)
+
+	case jsonExportedMsg:
+		if msg.err != nil {
+			m.jsonExportErr = msg.err.Error()
+			return m, nil
+		}
+		return m, m.setStatus(fmt.Sprintf("Exported %d records to %s", msg.count, msg.path))
+
+	case csvExportedMsg:
+		if msg.err != nil {
+			m.commandErr = "csv export: " + msg.err.Error()
+			return m, nil
+		}
+		return m, m.setStatus(fmt.Sprintf("Exported %d records to %s", msg.count, msg.path))
+
+	case coverExportProgressMsg:
+		m.coverExportProgress = msg.progress
+		return m, waitCoverExport(msg.updates, msg.done)
+
+	case coverExportDoneMsg:
+		m.coverExporting = false
+		m.coverExportProgress = msg.progress
+		if msg.err != nil {
+			m.coverExportErr = msg.err.Error()
+			return m, nil
+		}
+		p := msg.progress
+		status := m.setStatus(fmt.Sprintf("Saved %d covers to %s (%d failed, %d without image)", p.Saved, m.coverExportDir, p.Failed, p.Skipped))
+		if p.Failed > 0 {
+			m.coverExportErr = p.Errors[0]
+		}
+		return m, status
 
 	case syncProgressMsg:
 		p := msg.progress
@@ -297,6 +771,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.syncSkipped = p.Skipped
 		m.syncTotal = p.TotalDiscogsItems
 		m.syncErrors = p.Errors
+		m.syncResults = p.Results
 		return m, nil
 
 	case syncDoneMsg:
@@ -307,12 +782,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.syncSkipped = msg.progress.Skipped
 		m.syncTotal = msg.progress.TotalDiscogsItems
 		m.syncErrors = msg.progress.Errors
+		m.syncResults = msg.progress.Results
 		if msg.err != nil {
 			m.syncErrors = append(m.syncErrors, msg.err.Error())
 			return m, nil
 		}
 		m.loading = true
-		return m, loadRecords(m.store)
+		return m, m.fetchRecords(false)
 
 	}
 
@@ -322,9 +798,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	if m.store == nil {
+		return m.handleDisconnectedKey(key)
+	}
+	if m.showHelp {
+		return m.handleHelpOverlayKey(key)
+	}
+	if key == "?" && m.helpOverlayAvailable() {
+		m.showHelp = true
+		return m, nil
+	}
 	if m.searching {
 		return m.handleSearchKey(key)
 	}
+	if m.tagEditing {
+		return m.handleTagEditKey(key)
+	}
+	if m.discardPrompt {
+		return m.handleDiscardKey(key)
+	}
+	if m.noteEditing {
+		return m.handleNoteEditKey(key)
+	}
+	if m.fieldEditing {
+		return m.handleFieldEditKey(key)
+	}
+	if m.coverExportPrompt {
+		return m.handleCoverExportPromptKey(key)
+	}
+	if m.jsonExportPrompt {
+		return m.handleJSONExportPromptKey(key)
+	}
+	if m.yearPrompt {
+		return m.handleYearPromptKey(key)
+	}
+	if m.commandMode {
+		return m.handleCommandKey(key)
+	}
 
 	switch m.view {
 	case listView:
@@ -335,44 +845,142 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m.handleAddDiscogsKey(key)
 	case addManualView:
 		return m.handleAddManualKey(key)
+	case changesView:
+		return m.handleChangesKey(key)
+	case syncReportView:
+		return m.handleSyncReportKey(key)
+	case statsView:
+		return m.handleStatsKey(key)
+	case duplicatesView:
+		return m.handleDuplicatesKey(key)
+	case labelsView:
+		return m.handleLabelsKey(key)
+	case genresView:
+		return m.handleGenresKey(key)
 	}
 
 	return m, nil
 }
 
+// applyFilters rebuilds m.filtered from m.records using the active search,
+// so a reload keeps whatever the user had narrowed the list to.
+func (m *Model) applyFilters() {
+	m.filtered = make([]db.Record, 0, len(m.records))
+	m.searchMatches = nil
+	live := m.searching && strings.TrimSpace(m.search) != ""
+	switch {
+	case live:
+		m.filtered, m.searchMatches = fuzzyFilter(m.records, m.search, m.sortCol, m.sortDesc, m.ignoreArticles)
+	case m.search == "" || m.searching:
+		m.filtered = append(m.filtered, m.records...)
+	default:
+		q := db.ParseSearchQuery(m.search)
+		for _, r := range m.records {
+			if q.Matches(r) {
+				m.filtered = append(m.filtered, r)
+			}
+		}
+	}
+	if m.genreFilter != "" {
+		m.filtered = slices.DeleteFunc(m.filtered, func(r db.Record) bool { return !r.HasGenre(m.genreFilter) })
+	}
+	if m.tagFilter != "" {
+		m.filtered = slices.DeleteFunc(m.filtered, func(r db.Record) bool { return !r.HasTag(m.tagFilter) })
+	}
+	if m.labelFilter != nil {
+		m.filtered = slices.DeleteFunc(m.filtered, func(r db.Record) bool { return !r.HasLabel(*m.labelFilter) })
+	}
+	if m.yearRange.active() {
+		m.filtered = slices.DeleteFunc(m.filtered, func(r db.Record) bool { return !r.ReleasedBetween(m.yearRange.from, m.yearRange.to) })
+	}
+	if m.unsyncedOnly {
+		m.filtered = slices.DeleteFunc(m.filtered, func(r db.Record) bool { return r.IsSyncedWithDiscogs })
+	}
+	if !live {
+		sortRecords(m.filtered, m.sortCol, m.sortDesc, m.ignoreArticles)
+	}
+}
+
+// liveSearch re-filters the loaded records after each keystroke in search
+// mode and moves back to the best match.
+func (m Model) liveSearch() Model {
+	m.applyFilters()
+	m.cursor, m.offset = 0, 0
+	if m.reverseList {
+		m.cursor = max(0, len(m.filtered)-1)
+		m.offset = max(0, len(m.filtered)-m.listVisibleRows())
+	}
+	return m
+}
+
+// nextGenre picks the genre to filter by: the first one, or the one after
+// current when the record is already being browsed by current.
+func nextGenre(genres []string, current string) string {
+	if i := slices.Index(genres, current); i >= 0 {
+		return genres[(i+1)%len(genres)]
+	}
+	return genres[0]
+}
+
+// clearSearch shows the unsearched list again, refetching if m.records
+// currently holds database search results.
+func (m Model) clearSearch() (tea.Model, tea.Cmd) {
+	m.cancelSearch()
+	m.applyFilters()
+	if !m.recordsSearched {
+		return m, nil
+	}
+	return m, m.fetchRecords(false)
+}
+
 func (m Model) handleSearchKey(key string) (tea.Model, tea.Cmd) {
 	switch key {
+	case "up":
+		return m.recallSearch(-1), nil
+	case "down":
+		return m.recallSearch(1), nil
 	case "esc":
+		if m.browsingHistory() {
+			// Back to what was being typed before recalling history.
+			m.historyPos = len(m.searchHistory)
+			m.search = m.searchDraft
+			return m.liveSearch(), nil
+		}
 		m.searching = false
 		m.search = ""
-		m.filtered = m.records
-		return m, nil
+		return m.clearSearch()
 	case "enter":
 		m.searching = false
 		if m.search == "" {
-			m.filtered = m.records
-			return m, nil
+			return m.clearSearch()
 		}
-		return m, searchRecords(m.store, m.search)
+		return m.debounceSearch()
 	case "backspace":
 		if len(m.search) > 0 {
 			runes := []rune(m.search)
 			m.search = string(runes[:len(runes)-1])
 		}
-		return m, nil
+		m.historyPos = len(m.searchHistory)
+		return m.liveSearch(), nil
 	default:
 		r, ok := inputKeyRune(key)
 		if ok && utf8.RuneCountInString(m.search) < maxSearchRunes {
 			m.search += string(r)
 		}
-		return m, nil
+		// Editing a recalled query makes it the one being typed.
+		m.historyPos = len(m.searchHistory)
+		return m.liveSearch(), nil
 	}
 }
 
 func (m Model) handleListKey(key string) (tea.Model, tea.Cmd) {
+	if m.keyDisabled(listKeys, key) {
+		return m, m.setStatus(readOnlyStatus)
+	}
+	prevCursor := m.cursor
 	switch key {
 	case "q", "ctrl+c":
-		return m, tea.Quit
+		return m.quit()
 	case "up":
 		if m.cursor > 0 {
 			m.cursor--
@@ -390,6 +998,16 @@ func (m Model) handleListKey(key string) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.deleteConfirm = false
+	case "pgup", "pgdown":
+		if len(m.filtered) > 0 {
+			step := m.listPageSize()
+			if key == "pgup" {
+				step = -step
+			}
+			m.cursor = max(0, min(m.cursor+step, len(m.filtered)-1))
+			m.keepCursorVisible()
+		}
+		m.deleteConfirm = false
 	case "home", "g":
 		m.cursor = 0
 		m.offset = 0
@@ -402,49 +1020,168 @@ func (m Model) handleListKey(key string) (tea.Model, tea.Cmd) {
 	case "enter":
 		if len(m.filtered) > 0 {
 			m.view = detailView
-			m.artRender = ""
-			m.artLoading = true
-			rec := m.filtered[m.cursor]
-			url := rec.ImageURL()
-			if cached, ok := m.imgCache.get(url); ok {
-				m.artRender = cached.render
-				m.artLoading = false
-				if cached.transmit != "" {
-					return m, tea.Raw(cached.transmit)
-				}
-				return m, nil
-			}
-			return m, loadImage(m.imgProto, url, 30, 15)
+			m.detailOffset = 0
+			return m.showArt()
 		}
 	case "/":
 		m.searching = true
 		m.search = ""
+		m.cancelSearch()
+		m.startSearchHistory()
+		m.deleteConfirm = false
+	case ":":
+		m.commandMode = true
+		m.commandInput = ""
 		m.deleteConfirm = false
 	case "a":
 		m.view = addDiscogsView
 		m.resetDiscogsAddState()
+		if strings.TrimSpace(m.discogsCfg.token) == "" {
+			m.discogsErr = errDiscogsNoToken
+		}
 	case "m":
 		m.view = addManualView
 		m.resetManualAddState()
+	case "e":
+		if rec, ok := m.selectedRecord(); ok {
+			m.startManualEdit(rec)
+		}
 	case "d", "y":
 		if len(m.filtered) == 0 || m.deleting {
 			return m, nil
 		}
 		if !m.deleteConfirm {
+			if key == "y" {
+				return m, nil
+			}
 			m.deleteConfirm = true
 			m.deleteErr = ""
 			return m, nil
 		}
 		m.deleting = true
-		recordID := m.filtered[m.cursor].RecordID
-		return m, deleteRecord(m.store, recordID)
-	case "esc", "n":
+		if len(m.selected) > 0 {
+			return m, deleteRecords(m.ctx, m.store, m.selectedIDs())
+		}
+		rec, _ := m.selectedRecord()
+		return m, deleteRecord(m.ctx, m.store, rec.RecordID)
+	case "n":
+		m.deleteConfirm = false
+	case "space":
+		m.toggleSelected()
+		m.deleteConfirm = false
+	case "esc":
+		if m.deleteConfirm {
+			m.deleteConfirm = false
+			return m, nil
+		}
+		if len(m.selected) > 0 {
+			m.selected = nil
+			return m, nil
+		}
+		if m.genreFilter == "" && m.tagFilter == "" && m.labelFilter == nil && !m.yearRange.active() {
+			return m, nil
+		}
+		m.genreFilter = ""
+		m.tagFilter = ""
+		m.labelFilter = nil
+		m.yearRange = yearRange{}
+		m.loading = true
+		return m, m.fetchRecords(false)
+	case "X":
+		if m.coverExporting || len(m.records) == 0 {
+			return m, nil
+		}
+		m.coverExportPrompt = true
+		m.coverExportDir = defaultCoverExportDir()
+		m.coverExportErr = ""
 		m.deleteConfirm = false
+	case "J":
+		if len(m.filtered) == 0 {
+			return m, nil
+		}
+		m.jsonExportPrompt = true
+		if m.jsonExportPath == "" {
+			m.jsonExportPath = defaultJSONExportPath()
+		}
+		m.jsonExportErr = ""
+		m.deleteConfirm = false
+	case "R":
+		m.reverseList = !m.reverseList
+		if len(m.filtered) > 0 {
+			m.cursor = len(m.filtered) - 1 - m.cursor
+			visible := m.listVisibleRows()
+			if m.cursor < m.offset || m.cursor >= m.offset+visible {
+				m.offset = max(0, min(m.cursor-visible/2, len(m.filtered)-visible))
+			}
+		}
+		m.deleteConfirm = false
+	case "u":
+		m = m.toggleUnsynced()
+		m.deleteConfirm = false
+	case "c":
+		return m.clearFilters()
+	case "Y":
+		m.yearPrompt = true
+		m.yearInput = ""
+		if m.yearRange.active() {
+			m.yearInput = fmt.Sprintf("%d-%d", m.yearRange.from, m.yearRange.to)
+		}
+		m.yearErr = ""
+		m.deleteConfirm = false
+	case "#":
+		m.lineNumbers = !m.lineNumbers
+	case "I":
+		return m.toggleThumbnails()
+	case "T":
+		m.setTheme((m.themeIdx + 1) % len(themes))
+	case "[":
+		m = m.jumpToInitial(-1)
+		m.deleteConfirm = false
+	case "]":
+		m = m.jumpToInitial(1)
+		m.deleteConfirm = false
+	case "o", "O":
+		if key == "o" {
+			m.sortCol = (m.sortCol + 1) % sortColumnCount
+		} else {
+			m.sortDesc = !m.sortDesc
+		}
+		m.applyFilters()
+		m.cursor = 0
+		m.offset = 0
+		m.deleteConfirm = false
+	case "v":
+		if len(m.syncResults) > 0 {
+			m.view = syncReportView
+			m.syncReportCursor = 0
+			m.syncReportOffset = 0
+		}
+		m.deleteConfirm = false
+	case "w":
+		m.view = changesView
+		m.changesOffset = 0
+		m.deleteConfirm = false
+	case "S":
+		m.view = statsView
+		m.statsOffset = 0
+		m.statsLoading = true
+		m.statsErr = ""
+		m.deleteConfirm = false
+		return m, loadStats(m.ctx, m.store)
+	case "D":
+		m.deleteConfirm = false
+		return m.openDuplicates()
+	case "L":
+		return m.openLabels()
+	case "b":
+		return m.openGenres()
+	case "z", "Z":
+		return m.pickRandom(key == "Z")
 	case "r":
 		m.loading = true
 		m.deleteConfirm = false
 		m.deleteErr = ""
-		return m, loadRecords(m.store)
+		return m, m.fetchRecords(false)
 	case "s":
 		if m.syncing {
 			return m, nil
@@ -456,33 +1193,277 @@ func (m Model) handleListKey(key string) (tea.Model, tea.Cmd) {
 		m.syncSkipped = 0
 		m.syncTotal = 0
 		m.syncErrors = nil
+		m.syncResults = nil
 		m.deleteConfirm = false
-		return m, runSync(m.store, m.discogsUsername, m.discogsCfg)
+		return m, runSync(m.ctx, m.store, m.discogsUsername, m.discogsCfg)
+	}
+	if m.cursor != prevCursor {
+		var thumbs tea.Cmd
+		m, thumbs = m.loadThumbnails()
+		return m, tea.Batch(m.prefetchNeighbors(), thumbs)
 	}
 	return m, nil
 }
 
+func (m Model) handleCoverExportPromptKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.coverExportPrompt = false
+		return m, nil
+	case "enter":
+		dir := strings.TrimSpace(m.coverExportDir)
+		if dir == "" {
+			return m, nil
+		}
+		m.coverExportPrompt = false
+		m.coverExporting = true
+		m.coverExportDir = dir
+		m.coverExportProgress = coverExportProgress{Total: len(m.records)}
+		return m, startCoverExport(m.ctx, m.records, dir)
+	case "backspace":
+		runes := []rune(m.coverExportDir)
+		if len(runes) > 0 {
+			m.coverExportDir = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	default:
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.coverExportDir) < maxSearchRunes {
+			m.coverExportDir += string(r)
+		}
+		return m, nil
+	}
+}
+
+func (m Model) handleJSONExportPromptKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.jsonExportPrompt = false
+		return m, nil
+	case "enter":
+		path := strings.TrimSpace(m.jsonExportPath)
+		if path == "" {
+			return m, nil
+		}
+		m.jsonExportPrompt = false
+		m.jsonExportPath = path
+		return m, exportJSON(m.displayedRecords(), path)
+	case "backspace":
+		runes := []rune(m.jsonExportPath)
+		if len(runes) > 0 {
+			m.jsonExportPath = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	default:
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.jsonExportPath) < maxSearchRunes {
+			m.jsonExportPath += string(r)
+		}
+		return m, nil
+	}
+}
+
 func (m Model) handleDetailKey(key string) (tea.Model, tea.Cmd) {
+	if m.keyDisabled(detailKeys, key) {
+		return m, m.setStatus(readOnlyStatus)
+	}
 	switch key {
 	case "q", "esc", "backspace":
 		m.view = listView
-		m.artRender = ""
+		m.detailFocus = ""
+		m = m.hideArt()
+		return m.loadThumbnails()
+	case "tab":
+		return m.cycleDetailFocus(1), nil
+	case "shift+tab":
+		return m.cycleDetailFocus(-1), nil
+	case "e":
+		return m.startFieldEdit()
 	case "ctrl+c":
-		return m, tea.Quit
+		return m.quit()
+	case "up", "k", "down", "j":
+		// j/k scroll the info block when it overflows; otherwise they move
+		// between records like the arrows.
+		if rec, ok := m.selectedRecord(); ok && (key == "j" || key == "k") && m.detailMaxScroll(rec) > 0 {
+			delta := 1
+			if key == "k" {
+				delta = -1
+			}
+			return m.scrollDetail(delta), nil
+		}
+		next := m.cursor + 1
+		if key == "up" || key == "k" {
+			next = m.cursor - 1
+		}
+		if next < 0 || next >= len(m.filtered) {
+			return m, nil
+		}
+		m.cursor = next
+		m.detailOffset = 0
+		m.keepCursorVisible()
+		var cmd tea.Cmd
+		m, cmd = m.showArt()
+		return m, tea.Batch(cmd, m.prefetchNeighbors())
+	case "g":
+		rec, ok := m.selectedRecord()
+		if !ok || len(rec.Genres) == 0 {
+			return m, nil
+		}
+		m.genreFilter = nextGenre(rec.Genres, m.genreFilter)
+		m.view = listView
+		m = m.hideArt()
+		m.loading = true
+		return m, m.fetchRecords(false)
+	case "s":
+		return m.startRecordSync()
+	case "t":
+		if rec, ok := m.selectedRecord(); ok {
+			m.tagEditing = true
+			m.tagInput = strings.Join(rec.Tags, ", ")
+			m.tagErr = ""
+		}
+	case "n":
+		m = m.startNoteEdit()
+	case "0", "1", "2", "3", "4", "5":
+		return m.rateSelected(key)
+	case "p":
+		if rec, ok := m.selectedRecord(); ok {
+			return m, recordPlay(m.ctx, m.store, rec.RecordID)
+		}
+	case "i":
+		m.imgProto = nextImageProto(m.imgProto)
+		return m.showArt()
+	case "y", "o":
+		rec, ok := m.selectedRecord()
+		if !ok {
+			return m, nil
+		}
+		if rec.DiscogsURI == nil || *rec.DiscogsURI == "" {
+			m.detailErr = "No Discogs link for this record."
+			return m, nil
+		}
+		if key == "o" {
+			return m, openBrowser(*rec.DiscogsURI)
+		}
+		status := m.setStatus("Copied " + *rec.DiscogsURI)
+		return m, tea.Batch(status, tea.SetClipboard(*rec.DiscogsURI))
 	}
 	return m, nil
 }
 
-func (m Model) handleAddDiscogsKey(key string) (tea.Model, tea.Cmd) {
+// showArt displays the selected record's cover with the current protocol,
+// from the in-memory cache when possible.
+func (m Model) showArt() (Model, tea.Cmd) {
+	m.artLoadID++
+	m.artRender = ""
+	m.artLoading = true
+	rec, _ := m.selectedRecord()
+	url := rec.ImageURL()
+	size := m.artSize()
+	if cached, ok := m.imgCache.get(url, m.imgProto, size); ok {
+		m.artRender = cached.render
+		m.artLoading = false
+		m.markDrawn()
+		if cached.transmit != "" {
+			return m, tea.Raw(cached.transmit)
+		}
+		return m, nil
+	}
+	// Keep prefetchNeighbors from fetching the same image again.
+	m.imgCache.claim(url, m.imgProto, size)
+	var tick tea.Cmd
+	m, tick = m.startSpinner()
+	return m, tea.Batch(loadImage(m.ctx, m.artLoadID, m.imgDiskCache, m.imgProto, url, size, m.artBackground()), tick)
+}
+
+// hideArt clears the cover when leaving the detail view. Bumping artLoadID
+// means a load still in flight lands in the cache instead of on screen.
+func (m Model) hideArt() Model {
+	m.artLoadID++
+	m.artRender = ""
+	m.artLoading = false
+	return m
+}
+
+func (m Model) handleTagEditKey(key string) (tea.Model, tea.Cmd) {
 	switch key {
 	case "ctrl+c":
-		return m, tea.Quit
+		return m.quit()
 	case "esc":
-		m.view = listView
-		m.discogsErr = ""
-		m.discogsSearching = false
-		m.discogsSaving = false
+		m.tagEditing = false
+		m.tagSaving = false
+		m.tagErr = ""
 		return m, nil
+	case "enter":
+		rec, ok := m.selectedRecord()
+		if m.tagSaving || !ok {
+			return m, nil
+		}
+		m.tagSaving = true
+		m.tagErr = ""
+		return m, saveTags(m.ctx, m.store, rec.RecordID, splitCommaList(m.tagInput))
+	case "backspace":
+		if m.tagSaving {
+			return m, nil
+		}
+		runes := []rune(m.tagInput)
+		if len(runes) > 0 {
+			m.tagInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	default:
+		if m.tagSaving {
+			return m, nil
+		}
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.tagInput) < maxSearchRunes {
+			m.tagInput += string(r)
+		}
+		return m, nil
+	}
+}
+
+// splitCommaList trims and de-duplicates (case-insensitively) the
+// comma-separated values in s, dropping empties.
+func splitCommaList(s string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for part := range strings.SplitSeq(s, ",") {
+		v := strings.TrimSpace(part)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+func withRecordTags(records []db.Record, id string, tags []string) []db.Record {
+	out := make([]db.Record, len(records))
+	copy(out, records)
+	for i := range out {
+		if out[i].RecordID == id {
+			out[i].Tags = tags
+		}
+	}
+	return out
+}
+
+func (m Model) handleAddDiscogsKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c", "esc":
+		if guarded, ok := m.guardDiscard(key == "ctrl+c"); ok {
+			return guarded, nil
+		}
+		if key == "ctrl+c" {
+			return m.quit()
+		}
+		return m.leaveForm(), nil
 	case "1":
 		m.discogsSearchMethod = discogsSearchArtistTitle
 		m.discogsCursor = 0
@@ -525,7 +1506,7 @@ func (m Model) handleAddDiscogsKey(key string) (tea.Model, tea.Cmd) {
 			m.discogsSaving = true
 			m.discogsErr = ""
 			releaseID := m.discogsResults[m.discogsResultCursor].ID
-			return m, addDiscogsRecord(m.store, releaseID, m.discogsUsername, m.discogsCfg)
+			return m, addDiscogsRecord(m.ctx, m.store, releaseID, m.discogsUsername, m.discogsCfg)
 		}
 	}
 
@@ -561,6 +1542,10 @@ func (m Model) handleAddDiscogsKey(key string) (tea.Model, tea.Cmd) {
 		if m.discogsSearching || m.discogsSaving {
 			return m, nil
 		}
+		if strings.TrimSpace(m.discogsCfg.token) == "" {
+			m.discogsErr = errDiscogsNoToken
+			return m, nil
+		}
 		query, errMsg := m.discogsQueryFromState()
 		if errMsg != "" {
 			m.discogsErr = errMsg
@@ -571,7 +1556,7 @@ func (m Model) handleAddDiscogsKey(key string) (tea.Model, tea.Cmd) {
 		m.discogsResultCursor = 0
 		m.discogsResultsFocus = false
 		m.discogsSearching = true
-		return m, runDiscogsSearch(m.discogsCfg, query)
+		return m, runDiscogsSearch(m.ctx, m.discogsCfg, query)
 	default:
 		if m.discogsSearching || m.discogsSaving {
 			return m, nil
@@ -671,15 +1656,51 @@ func (m Model) discogsQueryFromState() (discogsSearchQuery, string) {
 	}
 }
 
+// recordAt maps a display row to its record, honoring reverseList.
+func (m Model) recordAt(row int) db.Record {
+	if m.reverseList {
+		return m.filtered[len(m.filtered)-1-row]
+	}
+	return m.filtered[row]
+}
+
+func (m Model) selectedRecord() (db.Record, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return db.Record{}, false
+	}
+	return m.recordAt(m.cursor), true
+}
+
+// keepCursorVisible scrolls the list just enough to show the cursor row.
+func (m *Model) keepCursorVisible() {
+	visible := m.listVisibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+}
+
 func (m Model) listVisibleRows() int {
 	return max(1, m.height-6)
 }
 
+func (m Model) listPageSize() int {
+	if m.pageSize > 0 {
+		return m.pageSize
+	}
+	return m.listVisibleRows()
+}
+
 func (m Model) View() tea.View {
 	if m.width == 0 {
 		return tea.NewView("Loading...")
 	}
 
+	if m.store == nil {
+		return tea.NewView(m.renderDisconnected())
+	}
+
 	var s string
 	switch m.view {
 	case listView:
@@ -690,6 +1711,21 @@ func (m Model) View() tea.View {
 		s = m.renderAddDiscogs()
 	case addManualView:
 		s = m.renderAddManual()
+	case changesView:
+		s = m.renderChanges()
+	case syncReportView:
+		s = m.renderSyncReport()
+	case statsView:
+		s = m.renderStats()
+	case duplicatesView:
+		s = m.renderDuplicates()
+	case labelsView:
+		s = m.renderLabels()
+	case genresView:
+		s = m.renderGenres()
+	}
+	if m.showHelp {
+		s = m.renderHelpOverlay()
 	}
 
 	return tea.NewView(s)
@@ -698,31 +1734,88 @@ func (m Model) View() tea.View {
 func (m Model) renderList() string {
 	var b strings.Builder
 
-	title := titleStyle.Render("♫ Record Collection")
-	count := statusBarStyle.Render(fmt.Sprintf("%d records", len(m.filtered)))
+	title := m.styles.title.Render("♫ Record Collection")
+	countText := m.recordCount()
+	if m.genreFilter != "" {
+		countText += fmt.Sprintf(" in genre %q", m.genreFilter)
+	}
+	if m.tagFilter != "" {
+		countText += fmt.Sprintf(" tagged %q", m.tagFilter)
+	}
+	if m.labelFilter != nil {
+		countText += fmt.Sprintf(" on label %q", labelName(*m.labelFilter))
+	}
+	if m.search != "" && !m.searching {
+		countText += fmt.Sprintf(" matching %q", m.search)
+	}
+	if m.yearRange.active() {
+		countText += " from " + m.yearRange.String()
+	}
+	if m.unsyncedOnly {
+		countText += " · unsynced only"
+	}
+	if m.readOnly {
+		countText += " · read-only"
+	}
+	if n := len(m.selected); n > 0 {
+		countText += fmt.Sprintf(" · %d selected", n)
+	}
+	count := m.styles.statusBar.Render(countText)
 	titleLine := lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", count)
-	b.WriteString(titleLine)
+	b.WriteString(ansi.Truncate(titleLine, m.width, "…"))
 	b.WriteString("\n")
 
 	if m.searching {
-		b.WriteString(searchStyle.Render("Search: " + m.search + "█"))
+		b.WriteString(m.styles.search.Render("Search: " + m.search + "█"))
+		b.WriteString("\n")
+	} else if m.coverExportPrompt {
+		b.WriteString(m.styles.search.Render("Export covers to: " + m.coverExportDir + "█"))
+		b.WriteString("\n")
+	} else if m.jsonExportPrompt {
+		b.WriteString(m.styles.search.Render("Export JSON to: " + m.jsonExportPath + "█"))
+		b.WriteString("\n")
+	} else if m.yearPrompt {
+		b.WriteString(m.styles.search.Render("Years (e.g. 1970-1979, empty for all): " + m.yearInput + "█"))
+		if m.yearErr != "" {
+			b.WriteString("  " + m.styles.error.Render(m.yearErr))
+		}
+		b.WriteString("\n")
+	} else if m.commandMode {
+		b.WriteString(m.styles.search.Render(":" + m.commandInput + "█"))
+		b.WriteString("\n")
+	} else if !m.loading && len(m.filtered) > 0 {
+		b.WriteString(m.renderAlphabetIndex())
 		b.WriteString("\n")
 	} else {
 		b.WriteString("\n")
 	}
 
-	if m.loading {
+	if m.loading && m.loadingSearch != "" {
+		fmt.Fprintf(&b, "\n  Searching for '%s'...\n", m.loadingSearch)
+		return b.String()
+	}
+	if m.loading && m.width <= 0 {
 		b.WriteString("\n  Loading records...\n")
 		return b.String()
 	}
+	if m.loading {
+		b.WriteString(m.renderSkeleton())
+		b.WriteString(m.styles.statusBar.Render("Loading records..."))
+		b.WriteString("\n")
+		return b.String()
+	}
 	if m.err != nil {
 		fmt.Fprintf(&b, "\n  Error: %v\n", m.err)
 		return b.String()
 	}
 	if len(m.filtered) == 0 {
-		b.WriteString("\n  No records found.\n")
+		if m.collectionEmpty() {
+			b.WriteString(m.renderOnboarding())
+		} else {
+			b.WriteString("\n  No records found.\n")
+		}
 		if m.deleteErr != "" {
-			b.WriteString(errorStyle.Render("  " + m.deleteErr))
+			b.WriteString(m.styles.error.Render("  " + m.deleteErr))
 			b.WriteString("\n")
 		}
 		b.WriteString(m.renderHelp())
@@ -730,41 +1823,46 @@ func (m Model) renderList() string {
 	}
 
 	colW := m.columnWidths()
-	header := headerStyle.Render(
-		truncPad("Artist", colW[0]) + " " +
-			truncPad("Album", colW[1]) + " " +
-			truncPad("Year", colW[2]) + " " +
-			truncPad("Label", colW[3]) + " " +
-			truncPad("Genres", colW[4]))
-	b.WriteString(header)
+	b.WriteString(m.renderListHeader(colW))
 	b.WriteString("\n")
 
 	visible := m.listVisibleRows()
 	end := min(m.offset+visible, len(m.filtered))
+	rows := make([]string, 0, end-m.offset)
 	for i := m.offset; i < end; i++ {
-		rec := m.filtered[i]
-		row := truncPad(rec.ArtistName, colW[0]) + " " +
-			truncPad(rec.AlbumTitle, colW[1]) + " " +
-			truncPad(rec.YearString(), colW[2]) + " " +
-			truncPad(rec.LabelString(), colW[3]) + " " +
-			truncPad(rec.GenresString(), colW[4])
-
-		if i == m.cursor {
-			b.WriteString(selectedRowStyle.Render(row))
+		rec := m.recordAt(i)
+		rowStyle := m.styles.normalRow
+		switch {
+		case i == m.cursor:
+			rowStyle = m.styles.selectedRow
+		case m.stripes && i%2 == 1:
+			rowStyle = m.styles.stripedRow
+		}
+		// The thumbnail sits outside the row style: its placeholder sets
+		// and resets the foreground color, which would cut the style short.
+		row := m.thumbnailCell(&rec)
+		if pos, ok := m.searchMatches[rec.RecordID]; ok {
+			row += m.highlightedRow(i, rec, pos, colW, rowStyle)
 		} else {
-			b.WriteString(normalRowStyle.Render(row))
+			row += rowStyle.Render(m.selectionCell(rec.RecordID) + m.lineNumberCell(i) + m.listRow(rec, colW))
 		}
+		rows = append(rows, row)
+	}
+	for _, row := range m.withScrollbar(rows) {
+		b.WriteString(row)
 		b.WriteString("\n")
 	}
 
 	if len(m.filtered) > visible {
 		scrollInfo := fmt.Sprintf(" %d-%d of %d ", m.offset+1, end, len(m.filtered))
-		b.WriteString(statusBarStyle.Render(scrollInfo))
+		b.WriteString(m.styles.statusBar.Render(scrollInfo))
 		b.WriteString("\n")
 	}
 
-	if m.successMsg != "" {
-		b.WriteString(successStyle.Render("  " + m.successMsg))
+	b.WriteString(m.renderConnLost())
+	b.WriteString(m.renderStatus())
+	if m.commandErr != "" {
+		b.WriteString(m.styles.error.Render("  " + m.commandErr))
 		b.WriteString("\n")
 	}
 	if m.syncing {
@@ -772,23 +1870,45 @@ func (m Model) renderList() string {
 		if m.syncTotal > 0 {
 			syncStatus += fmt.Sprintf(" (%d/%d)", m.syncPulled+m.syncSkipped, m.syncTotal)
 		}
-		b.WriteString(statusBarStyle.Render(syncStatus))
+		b.WriteString(m.styles.statusBar.Render(syncStatus))
 		b.WriteString("\n")
 	} else if m.syncPhase == "done" && (m.syncPulled > 0 || m.syncPushed > 0 || m.syncSkipped > 0 || len(m.syncErrors) > 0) {
 		summary := fmt.Sprintf("  Sync complete — pulled:%d pushed:%d skipped:%d", m.syncPulled, m.syncPushed, m.syncSkipped)
-		b.WriteString(successStyle.Render(summary))
+		b.WriteString(m.styles.success.Render(summary))
+		b.WriteString("\n")
+	}
+	if m.coverExporting {
+		p := m.coverExportProgress
+		b.WriteString(m.styles.statusBar.Render(fmt.Sprintf("  Exporting covers... %d/%d saved:%d failed:%d", p.Done, p.Total, p.Saved, p.Failed)))
+		b.WriteString("\n")
+	}
+	if m.coverExportErr != "" {
+		b.WriteString(m.styles.error.Render("  cover export: " + m.coverExportErr))
 		b.WriteString("\n")
 	}
-	for _, syncErr := range m.syncErrors {
-		b.WriteString(errorStyle.Render("  sync error: " + syncErr))
+	if m.jsonExportErr != "" {
+		b.WriteString(m.styles.error.Render("  json export: " + m.jsonExportErr))
+		b.WriteString("\n")
+	}
+	for i, syncErr := range m.syncErrors {
+		if i == maxListSyncErrors {
+			b.WriteString(m.styles.error.Render(fmt.Sprintf("  …and %d more — press v for the sync report", len(m.syncErrors)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(m.styles.error.Render("  sync error: " + syncErr))
 		b.WriteString("\n")
 	}
 	if m.deleteErr != "" {
-		b.WriteString(errorStyle.Render("  " + m.deleteErr))
+		b.WriteString(m.styles.error.Render("  " + m.deleteErr))
 		b.WriteString("\n")
 	}
-	if m.deleteConfirm {
-		b.WriteString(errorStyle.Render("  delete selected record? press d again (or y) to confirm, esc/n to cancel"))
+	if m.deleteConfirm && len(m.selected) > 0 {
+		b.WriteString(m.styles.error.Render(m.bulkDeletePrompt()))
+		b.WriteString("\n")
+	} else if m.deleteConfirm {
+		rec, _ := m.selectedRecord()
+		b.WriteString(m.styles.error.Render(fmt.Sprintf("  Delete %q? y/n", rec.AlbumTitle)))
 		b.WriteString("\n")
 	}
 
@@ -797,94 +1917,146 @@ func (m Model) renderList() string {
 }
 
 func (m Model) renderDetail() string {
-	if m.cursor >= len(m.filtered) {
+	rec, ok := m.selectedRecord()
+	if !ok {
 		return "No record selected"
 	}
-	rec := m.filtered[m.cursor]
 
 	var b strings.Builder
 
-	title := titleStyle.Render(fmt.Sprintf("♫ %s — %s", rec.ArtistName, rec.AlbumTitle))
+	title := m.styles.title.Render(fmt.Sprintf("♫ %s — %s", rec.ArtistName, rec.AlbumTitle))
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
 	var artBlock string
+	size := m.artSize()
 	if m.artLoading {
-		artBlock = renderPlaceholder(30, 15)
-		artBlock = strings.Replace(artBlock, "No Image", "Loading…", 1)
+		artBlock = m.loadingPlaceholder(size.w, size.h)
 	} else if m.artRender != "" {
 		artBlock = m.artRender
 	} else {
-		artBlock = renderPlaceholder(30, 15)
+		artBlock = renderPlaceholder(size.w, size.h)
+	}
+
+	infoLines, scrollInfo := m.detailWindow(m.detailInfoLines(rec))
+	b.WriteString(m.renderDetailPanes(artBlock, strings.Join(infoLines, "\n")))
+	b.WriteString("\n\n")
+
+	if m.tagEditing {
+		input := m.tagInput
+		if !m.tagSaving {
+			input += "█"
+		}
+		b.WriteString(m.styles.search.Render("Tags: " + input))
+		b.WriteString("\n")
+		if m.tagSaving {
+			b.WriteString(m.styles.statusBar.Render("Saving tags..."))
+			b.WriteString("\n")
+		}
+		if m.tagErr != "" {
+			b.WriteString(m.styles.error.Render("  " + m.tagErr))
+			b.WriteString("\n")
+		}
+		b.WriteString(m.helpLine(tagEditKeys))
+		return b.String()
+	}
+	if m.noteEditing {
+		b.WriteString(m.renderNoteEdit())
+		return b.String()
+	}
+	if m.fieldEditing {
+		b.WriteString(m.renderFieldEdit())
+		return b.String()
 	}
 
-	fields := []struct{ label, value string }{
+	b.WriteString(m.renderConnLost())
+	b.WriteString(m.renderStatus())
+	if m.recordSyncing {
+		b.WriteString(m.styles.statusBar.Render("  Syncing with Discogs..."))
+		b.WriteString("\n")
+	}
+	if m.detailErr != "" {
+		b.WriteString(m.styles.error.Render("  " + m.detailErr))
+		b.WriteString("\n")
+	}
+
+	extra := m.styles.help.Render(fmt.Sprintf("  [image: %s]", m.imgProto))
+	if scrollInfo != "" {
+		extra += m.styles.help.Render("  " + scrollInfo)
+	}
+	help := m.fitHelpLine(briefKeys(detailKeys), m.width-ansi.StringWidth(extra))
+	if m.width > 0 && ansi.StringWidth(help+extra) > m.width {
+		extra = ""
+		help = m.helpLine(briefKeys(detailKeys))
+	}
+	b.WriteString(help)
+	b.WriteString(extra)
+
+	return b.String()
+}
+
+// detailField is one label/value row of the detail view's info block.
+type detailField struct {
+	label, value string
+}
+
+func (m Model) detailFields(rec db.Record) []detailField {
+	fields := []detailField{
 		{"Artist", rec.ArtistName},
 		{"Album", rec.AlbumTitle},
 		{"Year", rec.YearString()},
 		{"Label", rec.LabelString()},
 		{"Genres", rec.GenresString()},
 		{"Styles", rec.StylesString()},
+		{"Tags", rec.TagsString()},
+		{"Rating", rec.RatingString()},
+		{"Notes", rec.NotesString()},
 		{"Size", rec.SizeString()},
 		{"Color", rec.ColorString()},
+		{"Shaped", rec.ShapedString()},
 		{"Source", rec.DataSource},
 	}
 
 	if rec.CatalogNumber != nil {
-		fields = append(fields, struct{ label, value string }{"Catalog #", *rec.CatalogNumber})
+		fields = append(fields, detailField{"Catalog #", *rec.CatalogNumber})
 	}
 	if rec.UPCCode != nil {
-		fields = append(fields, struct{ label, value string }{"UPC", *rec.UPCCode})
+		fields = append(fields, detailField{"UPC", *rec.UPCCode})
 	}
-
-	syncLabel := "Synced"
-	var syncValue string
-	if rec.IsSyncedWithDiscogs {
-		syncValue = syncedStyle.Render("✓ Yes")
-	} else {
-		syncValue = notSyncedStyle.Render("✗ No")
+	if rec.DiscogsURI != nil && *rec.DiscogsURI != "" {
+		fields = append(fields, detailField{"Discogs", *rec.DiscogsURI})
 	}
-	fields = append(fields, struct{ label, value string }{syncLabel, syncValue})
 
-	var infoLines []string
-	for _, f := range fields {
-		infoLines = append(infoLines,
-			labelStyle.Render(f.label)+valueStyle.Render(f.value))
-	}
-	infoBlock := strings.Join(infoLines, "\n")
+	now := time.Now()
+	fields = append(fields,
+		detailField{"Plays", rec.PlaysString()},
+		detailField{"Last played", lastPlayedString(rec, now)},
+		detailField{"Added", timestampString(rec.CreatedAt, now)},
+		detailField{"Updated", timestampString(rec.UpdatedAt, now)},
+	)
 
-	if m.imgProto == protoMosaic {
-		content := lipgloss.JoinHorizontal(lipgloss.Top, artBlock, "  ", infoBlock)
-		b.WriteString(detailBoxStyle.Render(content))
-	} else {
-		b.WriteString(detailBoxStyle.Render(infoBlock))
-		b.WriteString("\n")
-		b.WriteString(artBlock)
+	syncValue := m.styles.notSynced.Render("✗ No")
+	if rec.IsSyncedWithDiscogs {
+		syncValue = m.styles.synced.Render("✓ Yes")
 	}
-	b.WriteString("\n\n")
-
-	protoLabel := helpStyle.Render(fmt.Sprintf("  [image: %s]", m.imgProto))
-	b.WriteString(helpStyle.Render("  esc/q back"))
-	b.WriteString(protoLabel)
-
-	return b.String()
+	return append(fields, detailField{"Synced", syncValue})
 }
 
 func (m Model) renderAddDiscogs() string {
 	var b strings.Builder
-	title := titleStyle.Render("♫ Add Record")
-	status := statusBarStyle.Render("discogs search")
+	title := m.styles.title.Render("♫ Add Record")
+	status := m.styles.statusBar.Render("discogs search")
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", status))
 	b.WriteString("\n\n")
 
 	methodLine := "  Methods: "
 	switch m.discogsSearchMethod {
 	case discogsSearchArtistTitle:
-		methodLine += selectedRowStyle.Render("1 Artist+Album") + "  2 Catalog #  3 UPC"
+		methodLine += m.styles.selectedRow.Render("1 Artist+Album") + "  2 Catalog #  3 UPC"
 	case discogsSearchCatalog:
-		methodLine += "1 Artist+Album  " + selectedRowStyle.Render("2 Catalog #") + "  3 UPC"
+		methodLine += "1 Artist+Album  " + m.styles.selectedRow.Render("2 Catalog #") + "  3 UPC"
 	case discogsSearchUPC:
-		methodLine += "1 Artist+Album  2 Catalog #  " + selectedRowStyle.Render("3 UPC")
+		methodLine += "1 Artist+Album  2 Catalog #  " + m.styles.selectedRow.Render("3 UPC")
 	}
 	b.WriteString(methodLine)
 	b.WriteString("\n\n")
@@ -902,10 +2074,10 @@ func (m Model) renderAddDiscogs() string {
 		artistLine := "  Artist: " + artist
 		titleLine := "  Album: " + titleValue
 		if !m.discogsResultsFocus && m.discogsCursor == 0 {
-			artistLine = selectedRowStyle.Render("→ " + strings.TrimPrefix(artistLine, "  "))
+			artistLine = m.styles.selectedRow.Render("→ " + strings.TrimPrefix(artistLine, "  "))
 		}
 		if !m.discogsResultsFocus && m.discogsCursor == 1 {
-			titleLine = selectedRowStyle.Render("→ " + strings.TrimPrefix(titleLine, "  "))
+			titleLine = m.styles.selectedRow.Render("→ " + strings.TrimPrefix(titleLine, "  "))
 		}
 		b.WriteString(artistLine)
 		b.WriteString("\n")
@@ -918,7 +2090,7 @@ func (m Model) renderAddDiscogs() string {
 		}
 		line := "  Catalog #: " + catalog
 		if !m.discogsResultsFocus {
-			line = selectedRowStyle.Render("→ " + strings.TrimPrefix(line, "  "))
+			line = m.styles.selectedRow.Render("→ " + strings.TrimPrefix(line, "  "))
 		}
 		b.WriteString(line)
 		b.WriteString("\n")
@@ -929,7 +2101,7 @@ func (m Model) renderAddDiscogs() string {
 		}
 		line := "  UPC: " + upc
 		if !m.discogsResultsFocus {
-			line = selectedRowStyle.Render("→ " + strings.TrimPrefix(line, "  "))
+			line = m.styles.selectedRow.Render("→ " + strings.TrimPrefix(line, "  "))
 		}
 		b.WriteString(line)
 		b.WriteString("\n")
@@ -937,23 +2109,23 @@ func (m Model) renderAddDiscogs() string {
 
 	if m.discogsSearching {
 		b.WriteString("\n")
-		b.WriteString(statusBarStyle.Render("Searching Discogs..."))
+		b.WriteString(m.styles.statusBar.Render("Searching Discogs..."))
 		b.WriteString("\n")
 	}
 	if m.discogsSaving {
 		b.WriteString("\n")
-		b.WriteString(statusBarStyle.Render("Adding selected release..."))
+		b.WriteString(m.styles.statusBar.Render("Adding selected release..."))
 		b.WriteString("\n")
 	}
 	if m.discogsErr != "" {
 		b.WriteString("\n")
-		b.WriteString(errorStyle.Render("  " + m.discogsErr))
+		b.WriteString(m.styles.error.Render("  " + m.discogsErr))
 		b.WriteString("\n")
 	}
 
 	if len(m.discogsResults) > 0 {
 		b.WriteString("\n")
-		b.WriteString(statusBarStyle.Render(fmt.Sprintf("Results (%d)", len(m.discogsResults))))
+		b.WriteString(m.styles.statusBar.Render(fmt.Sprintf("Results (%d)", len(m.discogsResults))))
 		b.WriteString("\n")
 		for i, result := range m.discogsResults {
 			prefix := "  "
@@ -978,85 +2150,108 @@ func (m Model) renderAddDiscogs() string {
 			}
 			line := prefix + strings.Join(parts, " • ")
 			if m.discogsResultsFocus && i == m.discogsResultCursor {
-				b.WriteString(selectedRowStyle.Render(line))
+				b.WriteString(m.styles.selectedRow.Render(line))
 			} else {
-				b.WriteString(normalRowStyle.Render(line))
+				b.WriteString(m.styles.normalRow.Render(line))
 			}
 			b.WriteString("\n")
 		}
 	}
 
 	b.WriteString("\n")
+	if m.discardPrompt {
+		b.WriteString(m.renderDiscardPrompt())
+		return b.String()
+	}
 	helpItems := []string{
-		helpItem("enter", "search/add"),
-		helpItem("tab", "switch fields/results"),
-		helpItem("1/2/3", "method"),
-		helpItem("esc", "cancel"),
-		helpItem("ctrl+c", "quit"),
+		m.helpItem("enter", "search/add"),
+		m.helpItem("tab", "switch fields/results"),
+		m.helpItem("1/2/3", "method"),
+		m.helpItem("esc", "cancel"),
+		m.helpItem("ctrl+c", "quit"),
 	}
 	b.WriteString("  ")
-	b.WriteString(strings.Join(helpItems, helpSep()))
+	b.WriteString(strings.Join(helpItems, m.helpSep()))
 
 	return b.String()
 }
 
-func helpKey(key string) string {
-	return helpKeyStyle.Render(key)
+func (m Model) helpKey(key string) string {
+	return m.styles.helpKey.Render(key)
 }
 
-func helpSep() string {
-	return helpSepStyle.Render(" · ")
+func (m Model) helpSep() string {
+	return m.styles.helpSep.Render(" · ")
 }
 
-func helpItem(key, desc string) string {
-	return helpKey(key) + helpDescStyle.Render(" "+desc)
+func (m Model) helpItem(key, desc string) string {
+	return m.helpKey(key) + m.styles.helpDesc.Render(" "+desc)
 }
 
 func (m Model) renderHelp() string {
 	if m.searching {
-		items := []string{
-			helpItem("enter", "confirm"),
-			helpItem("esc", "cancel"),
-		}
-		return "  " + strings.Join(items, helpSep())
+		return m.helpLine(searchKeys)
+	}
+	if m.coverExportPrompt || m.jsonExportPrompt {
+		return m.helpLine(coverExportPromptKeys)
 	}
-	items := []string{
-		helpItem("↑↓", "scroll"),
-		helpItem("enter", "detail"),
-		helpItem("a", "add discogs"),
-		helpItem("m", "add manual"),
-		helpItem("d", "delete"),
-		helpItem("/", "search"),
-		helpItem("s", "sync"),
-		helpItem("r", "reload"),
-		helpItem("q", "quit"),
+	if m.yearPrompt {
+		return m.helpLine(yearPromptKeys)
 	}
-	return "  " + strings.Join(items, helpSep())
+	if m.commandMode {
+		return m.helpLine(commandKeys)
+	}
+	return m.helpLine(briefKeys(listKeys))
 }
 
-func (m Model) columnWidths() [5]int {
-	w := max(m.width-5, 40)
-	return [5]int{
-		w * 25 / 100,
-		w * 30 / 100,
-		6,
-		w * 18 / 100,
-		w * 18 / 100,
+// lineNumberWidth is the gutter taken by the line number column, sized to
+// the largest number so rows stay aligned while scrolling.
+func (m Model) lineNumberWidth() int {
+	if !m.lineNumbers {
+		return 0
 	}
+	return len(strconv.Itoa(max(len(m.filtered), 1))) + 1
 }
 
+// lineNumberCell renders row i's 1-based number, or the header when i < 0.
+func (m Model) lineNumberCell(i int) string {
+	w := m.lineNumberWidth()
+	if w == 0 {
+		return ""
+	}
+	label := "#"
+	if i >= 0 {
+		label = strconv.Itoa(i + 1)
+	}
+	return fmt.Sprintf("%*s ", w-1, label)
+}
+
+// shapedMarker follows the album title of shaped (non-round) records.
+const shapedMarker = " ◆"
+
+func albumCell(rec db.Record) string {
+	if rec.IsShapedVinyl != nil && *rec.IsShapedVinyl {
+		return rec.AlbumTitle + shapedMarker
+	}
+	return rec.AlbumTitle
+}
+
+// truncPad fits s to exactly width terminal cells, padding with spaces or
+// cutting it short with an ellipsis. Widths are display cells, so wide
+// (e.g. CJK) characters count as two and combining marks as none.
 func truncPad(s string, width int) string {
 	if width <= 0 {
 		return ""
 	}
-	runes := []rune(s)
-	if len(runes) > width {
-		if width > 1 {
-			return string(runes[:width-1]) + "…"
+	if ansi.StringWidth(s) > width {
+		tail := "…"
+		if width == 1 {
+			tail = ""
 		}
-		return string(runes[:width])
+		// A wide character that doesn't fit leaves a cell to pad.
+		s = ansi.Truncate(s, width, tail)
 	}
-	return s + strings.Repeat(" ", width-len(runes))
+	return s + strings.Repeat(" ", width-ansi.StringWidth(s))
 }
 
 func inputKeyRune(key string) (rune, bool) {
@@ -1081,11 +2276,12 @@ var manualFieldLabels = []string{
 	"Label",
 	"Catalog #",
 	"Genres",
+	"Tags",
 	"Size",
 	"Color",
 }
 
-const manualFieldCount = 8
+const manualFieldCount = 9
 
 func (m *Model) activeManualField() *string {
 	switch m.manualCursor {
@@ -1102,6 +2298,8 @@ func (m *Model) activeManualField() *string {
 	case 5:
 		return &m.manualGenres
 	case 6:
+		return &m.manualTags
+	case 7:
 		return &m.manualSize
 	default:
 		return &m.manualColor
@@ -1115,22 +2313,75 @@ func (m *Model) resetManualAddState() {
 	m.manualLabel = ""
 	m.manualCatalog = ""
 	m.manualGenres = ""
+	m.manualTags = ""
 	m.manualSize = ""
 	m.manualColor = ""
 	m.manualCursor = 0
 	m.manualSaving = false
 	m.manualErr = ""
+	m.manualInvalid = nil
+	m.manualEditing = nil
+}
+
+// manualFieldNames maps form rows to db.FieldError field names; rows
+// without validation rules are empty.
+var manualFieldNames = []string{db.FieldArtist, db.FieldAlbum, db.FieldYear, "", "", "", "", db.FieldSize, ""}
+
+// manualRecord builds a record from the form and returns every problem with
+// it, including a year that isn't a number. When editing, fields the form
+// doesn't show are carried over from the original record.
+func (m Model) manualRecord() (db.Record, db.ValidationErrors) {
+	rec := db.Record{DataSource: "manual"}
+	if m.manualEditing != nil {
+		rec = *m.manualEditing
+	}
+	rec.ArtistName = strings.TrimSpace(m.manualArtist)
+	rec.AlbumTitle = strings.TrimSpace(m.manualAlbum)
+	rec.YearReleased = nil
+	var yearErr *db.FieldError
+	if y := strings.TrimSpace(m.manualYear); y != "" {
+		if parsed, err := strconv.Atoi(y); err != nil {
+			yearErr = &db.FieldError{Field: db.FieldYear, Problem: "must be a number"}
+		} else {
+			rec.YearReleased = &parsed
+		}
+	}
+	optional := func(s string) *string {
+		if s = strings.TrimSpace(s); s != "" {
+			return &s
+		}
+		return nil
+	}
+	rec.LabelName = optional(m.manualLabel)
+	rec.CatalogNumber = optional(m.manualCatalog)
+	rec.Genres = splitCommaList(m.manualGenres)
+	rec.Tags = splitCommaList(m.manualTags)
+	rec.RecordSize = optional(m.manualSize)
+	rec.VinylColor = optional(m.manualColor)
+
+	var invalid db.ValidationErrors
+	if err := rec.Validate(); err != nil {
+		invalid, _ = errors.AsType[db.ValidationErrors](err)
+	}
+	if yearErr != nil {
+		invalid = append(invalid, *yearErr)
+		slices.SortStableFunc(invalid, func(a, b db.FieldError) int {
+			return slices.Index(manualFieldNames, a.Field) - slices.Index(manualFieldNames, b.Field)
+		})
+	}
+	return rec, invalid
 }
 
 func (m Model) handleAddManualKey(key string) (tea.Model, tea.Cmd) {
 	switch key {
-	case "ctrl+c":
-		return m, tea.Quit
-	case "esc":
-		m.view = listView
-		m.manualErr = ""
-		m.manualSaving = false
-		return m, nil
+	case "ctrl+c", "esc":
+		if guarded, ok := m.guardDiscard(key == "ctrl+c"); ok {
+			return guarded, nil
+		}
+		if key == "ctrl+c" {
+			return m.quit()
+		}
+		return m.leaveForm(), nil
 	case "up":
 		if m.manualCursor > 0 {
 			m.manualCursor--
@@ -1160,50 +2411,25 @@ func (m Model) handleAddManualKey(key string) (tea.Model, tea.Cmd) {
 		if m.manualSaving {
 			return m, nil
 		}
-		artist := strings.TrimSpace(m.manualArtist)
-		album := strings.TrimSpace(m.manualAlbum)
-		if artist == "" || album == "" {
-			m.manualErr = "artist and album are required"
+		rec, invalid := m.manualRecord()
+		if invalid != nil {
+			m.manualInvalid = invalid
+			m.manualErr = fmt.Sprintf("%d field(s) need fixing", len(invalid))
 			return m, nil
 		}
-		rec := db.Record{
-			ArtistName: artist,
-			AlbumTitle: album,
-			DataSource: "manual",
-		}
-		if y := strings.TrimSpace(m.manualYear); y != "" {
-			parsed, err := strconv.Atoi(y)
-			if err != nil || parsed < 1 || parsed > 9999 {
-				m.manualErr = "year must be a valid 4-digit number"
+		m.manualInvalid = nil
+		m.manualErr = ""
+		if m.manualEditing != nil {
+			if m.manualUnchanged(rec) {
+				m.resetManualAddState()
+				m.view = listView
 				return m, nil
 			}
-			rec.YearReleased = &parsed
-		}
-		if v := strings.TrimSpace(m.manualLabel); v != "" {
-			rec.LabelName = &v
-		}
-		if v := strings.TrimSpace(m.manualCatalog); v != "" {
-			rec.CatalogNumber = &v
-		}
-		if v := strings.TrimSpace(m.manualGenres); v != "" {
-			parts := strings.Split(v, ",")
-			var genres []string
-			for _, p := range parts {
-				if g := strings.TrimSpace(p); g != "" {
-					genres = append(genres, g)
-				}
-			}
-			rec.Genres = genres
+			m.manualSaving = true
+			return m, updateRecord(m.ctx, m.store, rec)
 		}
-		if v := strings.TrimSpace(m.manualSize); v != "" {
-			rec.RecordSize = &v
-		}
-		if v := strings.TrimSpace(m.manualColor); v != "" {
-			rec.VinylColor = &v
-		}
-		m.manualErr = ""
 		m.manualSaving = true
-		return m, addManualRecord(m.store, rec)
+		return m, addManualRecord(m.ctx, m.store, rec)
 	default:
 		if m.manualSaving {
 			return m, nil
@@ -1222,8 +2448,12 @@ func (m Model) handleAddManualKey(key string) (tea.Model, tea.Cmd) {
 
 func (m Model) renderAddManual() string {
 	var b strings.Builder
-	title := titleStyle.Render("♫ Add Record")
-	status := statusBarStyle.Render("manual entry")
+	title := m.styles.title.Render("♫ Add Record")
+	status := m.styles.statusBar.Render("manual entry")
+	if m.manualEditing != nil {
+		title = m.styles.title.Render("♫ Edit Record")
+		status = m.styles.statusBar.Render("editing " + m.manualEditing.ArtistName + " — " + m.manualEditing.AlbumTitle)
+	}
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", status))
 	b.WriteString("\n\n")
 
@@ -1234,6 +2464,7 @@ func (m Model) renderAddManual() string {
 		m.manualLabel,
 		m.manualCatalog,
 		m.manualGenres,
+		m.manualTags,
 		m.manualSize,
 		m.manualColor,
 	}
@@ -1246,32 +2477,39 @@ func (m Model) renderAddManual() string {
 		}
 		line := fmt.Sprintf("  %-12s %s", label+":", val)
 		if active {
-			b.WriteString(selectedRowStyle.Render("→ " + strings.TrimPrefix(line, "  ")))
+			b.WriteString(m.styles.selectedRow.Render("→ " + strings.TrimPrefix(line, "  ")))
 		} else {
-			b.WriteString(normalRowStyle.Render(line))
+			b.WriteString(m.styles.normalRow.Render(line))
+		}
+		if problem := m.manualInvalid.For(manualFieldNames[i]); manualFieldNames[i] != "" && problem != "" {
+			b.WriteString(m.styles.error.Render("  ✗ " + problem))
 		}
 		b.WriteString("\n")
 	}
 
 	if m.manualSaving {
 		b.WriteString("\n")
-		b.WriteString(statusBarStyle.Render("Saving..."))
+		b.WriteString(m.styles.statusBar.Render("Saving..."))
 		b.WriteString("\n")
 	}
 	if m.manualErr != "" {
 		b.WriteString("\n")
-		b.WriteString(errorStyle.Render("  " + m.manualErr))
+		b.WriteString(m.styles.error.Render("  " + m.manualErr))
 		b.WriteString("\n")
 	}
 
 	b.WriteString("\n")
+	if m.discardPrompt {
+		b.WriteString(m.renderDiscardPrompt())
+		return b.String()
+	}
 	manualHelpItems := []string{
-		helpItem("enter", "save"),
-		helpItem("tab/↑↓", "navigate"),
-		helpItem("esc", "cancel"),
-		helpItem("ctrl+c", "quit"),
+		m.helpItem("enter", "save"),
+		m.helpItem("tab/↑↓", "navigate"),
+		m.helpItem("esc", "cancel"),
+		m.helpItem("ctrl+c", "quit"),
 	}
 	b.WriteString("  ")
-	b.WriteString(strings.Join(manualHelpItems, helpSep()))
+	b.WriteString(strings.Join(manualHelpItems, m.helpSep()))
 	return b.String()
 }
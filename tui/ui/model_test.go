@@ -3,18 +3,27 @@ package ui
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 
 	tea "charm.land/bubbletea/v2"
 	"my-record-collection-tui/db"
 )
 
+var _ db.Store = (*mockStore)(nil)
+
 type mockStore struct {
 	records []db.Record
 	err     error
 	created []db.Record
+	updated []db.Record
+	pingErr error
+
+	fieldUpdates []string
 }
 
 func (m *mockStore) List(_ context.Context) ([]db.Record, error) {
@@ -22,13 +31,113 @@ func (m *mockStore) List(_ context.Context) ([]db.Record, error) {
 }
 
 func (m *mockStore) Search(_ context.Context, query string) ([]db.Record, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	q := db.ParseSearchQuery(query)
+	var results []db.Record
+	for _, r := range m.records {
+		if q.Matches(r) {
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+func (m *mockStore) FilterByGenre(_ context.Context, genre string) ([]db.Record, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var results []db.Record
+	for _, r := range m.records {
+		if r.HasGenre(genre) {
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+func (m *mockStore) ListByTag(_ context.Context, tag string) ([]db.Record, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var results []db.Record
+	for _, r := range m.records {
+		if r.HasTag(tag) {
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+func (m *mockStore) Labels(_ context.Context) ([]db.Count, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var counts []db.Count
+	for _, r := range m.records {
+		name := ""
+		if r.LabelName != nil {
+			name = *r.LabelName
+		}
+		i := slices.IndexFunc(counts, func(c db.Count) bool { return c.Name == name })
+		if i < 0 {
+			counts = append(counts, db.Count{Name: name})
+			i = len(counts) - 1
+		}
+		counts[i].N++
+	}
+	slices.SortFunc(counts, func(a, b db.Count) int {
+		if (a.Name == "") != (b.Name == "") {
+			if a.Name == "" {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return counts, nil
+}
+
+func (m *mockStore) Genres(_ context.Context) ([]db.Count, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var counts []db.Count
+	for _, r := range m.records {
+		for _, g := range r.Genres {
+			i := slices.IndexFunc(counts, func(c db.Count) bool { return c.Name == g })
+			if i < 0 {
+				counts = append(counts, db.Count{Name: g})
+				i = len(counts) - 1
+			}
+			counts[i].N++
+		}
+	}
+	sortCountsBySize(counts)
+	return counts, nil
+}
+
+func (m *mockStore) ListByLabel(_ context.Context, label string) ([]db.Record, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var results []db.Record
+	for _, r := range m.records {
+		if r.HasLabel(label) {
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+func (m *mockStore) ListByYearRange(_ context.Context, from, to int) ([]db.Record, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	var results []db.Record
 	for _, r := range m.records {
-		if strings.Contains(strings.ToLower(r.ArtistName), strings.ToLower(query)) ||
-			strings.Contains(strings.ToLower(r.AlbumTitle), strings.ToLower(query)) {
+		if r.ReleasedBetween(from, to) {
 			results = append(results, r)
 		}
 	}
@@ -37,14 +146,180 @@ func (m *mockStore) Search(_ context.Context, query string) ([]db.Record, error)
 
 func (m *mockStore) Delete(_ context.Context, _ string) error { return m.err }
 
-func (m *mockStore) Create(_ context.Context, r db.Record) error {
+func (m *mockStore) DeleteBatch(_ context.Context, ids []string) ([]string, error) {
 	if m.err != nil {
-		return m.err
+		return nil, m.err
+	}
+	var deleted []string
+	var kept []db.Record
+	for _, r := range m.records {
+		if slices.Contains(ids, r.RecordID) {
+			deleted = append(deleted, r.RecordID)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	m.records = kept
+	return deleted, nil
+}
+
+func (m *mockStore) Create(_ context.Context, r db.Record) (string, error) {
+	if m.err != nil {
+		return "", m.err
 	}
 	m.created = append(m.created, r)
+	return fmt.Sprintf("new-%d", len(m.created)), nil
+}
+
+func (m *mockStore) CreateBatch(ctx context.Context, records []db.Record) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var ids []string
+	for _, r := range records {
+		id, _ := m.Create(ctx, r)
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (m *mockStore) Update(_ context.Context, r db.Record) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.updated = append(m.updated, r)
 	return nil
 }
 
+func (m *mockStore) UpdateField(_ context.Context, id, column, value string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.fieldUpdates = append(m.fieldUpdates, id+" "+column+"="+value)
+	return nil
+}
+
+func (m *mockStore) UpdateFromDiscogs(_ context.Context, r db.Record) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.updated = append(m.updated, r)
+	return nil
+}
+
+func (m *mockStore) Ping(_ context.Context) error {
+	return m.pingErr
+}
+
+func (m *mockStore) Total(_ context.Context) (int, error) {
+	return len(m.records), m.err
+}
+
+func (m *mockStore) Stats(_ context.Context) (db.Stats, error) {
+	if m.err != nil {
+		return db.Stats{}, m.err
+	}
+	st := db.Stats{Total: len(m.records)}
+	for _, r := range m.records {
+		if r.IsSyncedWithDiscogs {
+			st.Synced++
+		}
+	}
+	return st, nil
+}
+
+func (m *mockStore) SetTags(_ context.Context, id string, tags []string) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			m.records[i].Tags = tags
+		}
+	}
+	return nil
+}
+
+func (m *mockStore) AddTag(_ context.Context, id string, tag string) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			if !m.records[i].HasTag(tag) {
+				m.records[i].Tags = append(slices.Clone(m.records[i].Tags), tag)
+			}
+			return m.records[i].Tags, nil
+		}
+	}
+	return nil, fmt.Errorf("record not found: %s", id)
+}
+
+func (m *mockStore) RemoveTag(_ context.Context, id string, tag string) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			m.records[i].Tags = slices.DeleteFunc(slices.Clone(m.records[i].Tags), func(t string) bool { return strings.EqualFold(t, tag) })
+			return m.records[i].Tags, nil
+		}
+	}
+	return nil, fmt.Errorf("record not found: %s", id)
+}
+
+func (m *mockStore) SetNotes(_ context.Context, id string, notes string) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			m.records[i].Notes = &notes
+		}
+	}
+	return nil
+}
+
+func (m *mockStore) SetRating(_ context.Context, id string, rating int) error {
+	if m.err != nil {
+		return m.err
+	}
+	if !db.ValidRating(rating) {
+		return db.ValidationErrors{{Field: db.FieldRating, Problem: "out of range"}}
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			m.records[i].Rating = &rating
+		}
+	}
+	return nil
+}
+
+func (m *mockStore) ClearRating(_ context.Context, id string) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			m.records[i].Rating = nil
+		}
+	}
+	return nil
+}
+
+func (m *mockStore) RecordPlay(_ context.Context, id string) (db.Play, error) {
+	if m.err != nil {
+		return db.Play{}, m.err
+	}
+	for i := range m.records {
+		if m.records[i].RecordID == id {
+			m.records[i].PlayCount++
+			return db.Play{Count: m.records[i].PlayCount, At: time.Now()}, nil
+		}
+	}
+	return db.Play{}, fmt.Errorf("record not found: %s", id)
+}
+
 func (m *mockStore) ListDiscogsIDs(_ context.Context) (map[string]struct{}, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -91,6 +366,9 @@ func newTestModel(records []db.Record) Model {
 	m.loading = false
 	m.records = records
 	m.filtered = records
+	m.imgProto = protoMosaic
+	m.searchDebounce = 0
+	m.healthInterval = 0
 	return m
 }
 
@@ -153,7 +431,7 @@ func TestModelUpdateRecordsError(t *testing.T) {
 func TestModelUpdateImageLoaded(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.artLoading = true
-	updated, _ := m.Update(imageLoadedMsg{url: "http://img.jpg", render: "rendered"})
+	updated, _ := m.Update(imageLoadedMsg{url: "http://img.jpg", size: m.artSize(), render: "rendered"})
 	model := updated.(Model)
 	if model.artLoading {
 		t.Error("artLoading should be false")
@@ -161,7 +439,7 @@ func TestModelUpdateImageLoaded(t *testing.T) {
 	if model.artRender != "rendered" {
 		t.Errorf("artRender = %q, want %q", model.artRender, "rendered")
 	}
-	cached, ok := model.imgCache.get("http://img.jpg")
+	cached, ok := model.imgCache.get("http://img.jpg", protoMosaic, model.artSize())
 	if !ok || cached.render != "rendered" {
 		t.Error("image should be cached")
 	}
@@ -199,6 +477,49 @@ func TestListNavigation(t *testing.T) {
 	}
 }
 
+func TestReverseListSelection(t *testing.T) {
+	recs := testRecords()
+	m := newTestModel(recs).WithReverseList(true)
+
+	if !strings.Contains(m.renderList(), recs[len(recs)-1].AlbumTitle) {
+		t.Fatal("reversed list should render the last record")
+	}
+	rec, ok := m.selectedRecord()
+	if !ok || rec.RecordID != recs[len(recs)-1].RecordID {
+		t.Errorf("selected at top = %q, want %q", rec.RecordID, recs[len(recs)-1].RecordID)
+	}
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	updated, _ = updated.(Model).Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	model := updated.(Model)
+	if model.view != detailView {
+		t.Fatalf("view = %d, want detailView", model.view)
+	}
+	want := recs[len(recs)-2]
+	if !strings.Contains(model.renderDetail(), want.AlbumTitle) {
+		t.Errorf("detail should show %q", want.AlbumTitle)
+	}
+}
+
+func TestReverseListToggleKeepsSelection(t *testing.T) {
+	recs := testRecords()
+	m := newTestModel(recs)
+	m.cursor = 0
+
+	updated, _ := m.Update(keyMsg("R"))
+	model := updated.(Model)
+	if !model.reverseList {
+		t.Fatal("R should enable reverse list")
+	}
+	rec, _ := model.selectedRecord()
+	if rec.RecordID != recs[0].RecordID {
+		t.Errorf("selection after toggle = %q, want %q", rec.RecordID, recs[0].RecordID)
+	}
+	if model.cursor != len(recs)-1 {
+		t.Errorf("cursor = %d, want %d", model.cursor, len(recs)-1)
+	}
+}
+
 func TestListNavigationHomeEnd(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.cursor = 1
@@ -235,7 +556,7 @@ func TestListNavigationGShift(t *testing.T) {
 func TestEnterDetailView(t *testing.T) {
 	m := newTestModel(testRecords())
 	// Pre-cache an image to test the cached path
-	m.imgCache.set("", cachedImage{render: "cached-placeholder"})
+	m.imgCache.set("", protoMosaic, m.artSize(), cachedImage{render: "cached-placeholder"})
 
 	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
 	model := updated.(Model)
@@ -251,6 +572,35 @@ func TestEnterDetailView(t *testing.T) {
 	_ = cmd
 }
 
+func TestCachedImageKeepsTransmit(t *testing.T) {
+	recs := testRecords()
+	url := "http://img/kitty.png"
+	recs[0].CoverImageURL = &url
+	m := newTestModel(recs)
+	m.view = detailView
+	m.artLoading = true
+
+	updated, cmd := m.Update(imageLoadedMsg{url: url, size: m.artSize(), render: "placeholder", transmit: "\x1b_Gdata\x1b\\"})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("first load should transmit the image")
+	}
+	cached, ok := m.imgCache.get(url, protoMosaic, m.artSize())
+	if !ok || cached.transmit == "" || cached.render != "placeholder" {
+		t.Fatalf("cache entry = %+v, want render and transmit", cached)
+	}
+
+	m.view = listView
+	updated, cmd = m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if m.artLoading || m.artRender != "placeholder" {
+		t.Error("reopening should use the cached render without loading")
+	}
+	if cmd == nil {
+		t.Error("reopening should re-send the cached transmit sequence")
+	}
+}
+
 func TestEnterDetailViewUncached(t *testing.T) {
 	m := newTestModel(testRecords())
 	// Don't pre-cache — should trigger load command
@@ -290,6 +640,46 @@ func TestDetailViewQuit(t *testing.T) {
 	}
 }
 
+func TestDetailViewNextPrevious(t *testing.T) {
+	recs := testRecords()
+	urls := []string{"http://img/1.png", "http://img/2.png", "http://img/3.png"}
+	for i := range recs {
+		recs[i].CoverImageURL = &urls[i]
+	}
+	m := newTestModel(recs)
+	m.imgCache.set(urls[1], protoMosaic, m.artSize(), cachedImage{render: "art-2"})
+	m.view = detailView
+
+	updated, _ := m.Update(keyMsg("j"))
+	m = updated.(Model)
+	if m.cursor != 1 || m.artRender != "art-2" || m.artLoading {
+		t.Fatalf("j: cursor = %d, artRender = %q; want 1 with cached art", m.cursor, m.artRender)
+	}
+	if !strings.Contains(m.renderDetail(), "A Love Supreme") {
+		t.Error("detail should show the next record")
+	}
+
+	updated, cmd := m.Update(keyMsg("j"))
+	m = updated.(Model)
+	if m.cursor != 2 || !m.artLoading || cmd == nil {
+		t.Fatal("moving to an uncached record should start loading its art")
+	}
+	if m.imgCache.claim(urls[2], protoMosaic, m.artSize()) {
+		t.Error("the displayed image should not be prefetched again")
+	}
+
+	updated, cmd = m.Update(keyMsg("j"))
+	if updated.(Model).cursor != 2 || cmd != nil {
+		t.Error("j on the last record should do nothing")
+	}
+	updated, _ = m.Update(keyMsg("k"))
+	updated, _ = updated.(Model).Update(keyMsg("k"))
+	updated, _ = updated.(Model).Update(keyMsg("k"))
+	if updated.(Model).cursor != 0 || updated.(Model).view != detailView {
+		t.Error("k should stop at the first record and stay in the detail view")
+	}
+}
+
 func TestSearchMode(t *testing.T) {
 	m := newTestModel(testRecords())
 
@@ -389,6 +779,42 @@ func TestSearchEnterWithQuery(t *testing.T) {
 	}
 }
 
+func TestReloadReappliesActiveSearch(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.search = "miles"
+	m.applyFilters()
+	if len(m.filtered) != 1 {
+		t.Fatalf("filtered before reload = %d, want 1", len(m.filtered))
+	}
+
+	store := m.store.(*mockStore)
+	store.records = append(testRecords(), db.Record{RecordID: "4", ArtistName: "Miles Davis", AlbumTitle: "Bitches Brew"})
+
+	updated, cmd := m.Update(keyMsg("r"))
+	if cmd == nil {
+		t.Fatal("reload should return a command")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	model := updated.(Model)
+	if model.search != "miles" {
+		t.Errorf("search after reload = %q, want %q", model.search, "miles")
+	}
+	if len(model.records) != 4 {
+		t.Errorf("records after reload = %d, want 4", len(model.records))
+	}
+	if len(model.filtered) != 2 {
+		t.Fatalf("filtered after reload = %d, want 2", len(model.filtered))
+	}
+	for _, r := range model.filtered {
+		if r.ArtistName != "Miles Davis" {
+			t.Errorf("unexpected record after reload: %s", r.ArtistName)
+		}
+	}
+	if !strings.Contains(model.renderList(), `matching "miles"`) {
+		t.Error("list header should show the active search")
+	}
+}
+
 func TestSearchIgnoresMultiCharKeys(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.searching = true
@@ -493,8 +919,65 @@ func TestDeleteRecordCancel(t *testing.T) {
 	}
 }
 
+func TestDeletePromptNamesRecord(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg("d"))
+	if v := updated.(Model).renderList(); !strings.Contains(v, `Delete "Kind of Blue"? y/n`) {
+		t.Errorf("prompt should name the selected album:\n%s", v)
+	}
+}
+
+func TestDeleteYWithoutPromptIgnored(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, cmd := m.Update(keyMsg("y"))
+	if cmd != nil || updated.(Model).deleteConfirm {
+		t.Error("y without a pending prompt should do nothing")
+	}
+}
+
+func TestDeleteCancelWithNLeavesState(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.cursor = 1
+
+	updated, _ := m.Update(keyMsg("d"))
+	updated, cmd := updated.(Model).Update(keyMsg("n"))
+	model := updated.(Model)
+	if cmd != nil || model.deleteConfirm || model.deleting {
+		t.Error("n should cancel without deleting")
+	}
+	if model.cursor != 1 || len(model.filtered) != 3 {
+		t.Errorf("cursor/filtered = %d/%d, want 1/3", model.cursor, len(model.filtered))
+	}
+}
+
+func TestDeleteLastRecordClampsCursor(t *testing.T) {
+	recs := testRecords()
+	m := newTestModel(recs)
+	m.cursor = len(recs) - 1
+
+	updated, _ := m.Update(keyMsg("d"))
+	updated, cmd := updated.(Model).Update(keyMsg("y"))
+	model := updated.(Model)
+	if cmd == nil {
+		t.Fatal("y should confirm the delete")
+	}
+
+	store := model.store.(*mockStore)
+	store.records = recs[:len(recs)-1]
+	updated, reload := model.Update(cmd())
+	updated, _ = updated.(Model).Update(reload())
+	model = updated.(Model)
+	if model.cursor != len(recs)-2 {
+		t.Errorf("cursor after deleting last = %d, want %d", model.cursor, len(recs)-2)
+	}
+	if _, ok := model.selectedRecord(); !ok {
+		t.Error("cursor should point at a record after delete")
+	}
+}
+
 func TestDiscogsAddSearchValidation(t *testing.T) {
 	m := newTestModel(testRecords())
+	m.discogsCfg.token = "t"
 	updated, _ := m.Update(keyMsg("a"))
 	model := updated.(Model)
 
@@ -510,6 +993,7 @@ func TestDiscogsAddSearchValidation(t *testing.T) {
 
 func TestDiscogsAddSearchCommand(t *testing.T) {
 	m := newTestModel(testRecords())
+	m.discogsCfg.token = "t"
 	updated, _ := m.Update(keyMsg("a"))
 	model := updated.(Model)
 
@@ -525,6 +1009,26 @@ func TestDiscogsAddSearchCommand(t *testing.T) {
 	}
 }
 
+func TestDiscogsAddSearchNeedsToken(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg("a"))
+	model := updated.(Model)
+	if model.discogsErr != errDiscogsNoToken {
+		t.Errorf("discogsErr on open = %q, want token hint", model.discogsErr)
+	}
+
+	model.discogsArtist = "Miles Davis"
+	model.discogsTitle = "Kind of Blue"
+	updated, cmd := model.Update(keyMsg("enter"))
+	model = updated.(Model)
+	if cmd != nil || model.discogsSearching {
+		t.Error("search without a token should not run")
+	}
+	if model.discogsErr != errDiscogsNoToken {
+		t.Errorf("discogsErr = %q, want token hint", model.discogsErr)
+	}
+}
+
 func TestDiscogsInputAcceptsSpaceKeyName(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.view = addDiscogsView
@@ -624,8 +1128,8 @@ func TestViewListError(t *testing.T) {
 func TestViewListEmpty(t *testing.T) {
 	m := newTestModel([]db.Record{})
 	v := m.View()
-	if !strings.Contains(v.Content, "No records") {
-		t.Error("empty view should say 'No records'")
+	if !strings.Contains(v.Content, "collection is empty") {
+		t.Error("empty view should say the collection is empty")
 	}
 }
 
@@ -678,6 +1182,48 @@ func TestRenderHelp(t *testing.T) {
 	}
 }
 
+func TestRenderHelpReadOnlyOmitsMutatingKeys(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.readOnly = true
+
+	help := m.renderHelp()
+	for _, hidden := range []string{"delete", "add", "sync"} {
+		if strings.Contains(help, hidden) {
+			t.Errorf("read-only help should not mention %q: %q", hidden, help)
+		}
+	}
+	if !strings.Contains(help, "search") {
+		t.Error("read-only help should still mention search")
+	}
+}
+
+func TestReadOnlyIgnoresMutatingKeys(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.readOnly = true
+
+	for _, key := range []string{"a", "m", "d", "s"} {
+		updated, _ := m.Update(keyMsg(key))
+		model := updated.(Model)
+		if model.view != listView || model.deleteConfirm || model.syncing {
+			t.Errorf("key %q should be ignored in read-only mode", key)
+		}
+		if model.status != readOnlyStatus {
+			t.Errorf("key %q status = %q, want %q", key, model.status, readOnlyStatus)
+		}
+	}
+}
+
+func TestReadOnlyTitleAndOnboarding(t *testing.T) {
+	m := newTestModel(nil).WithReadOnly(true)
+	out := m.renderList()
+	if !strings.Contains(out, "read-only") {
+		t.Error("title should flag read-only mode")
+	}
+	if strings.Contains(out, "add a record") {
+		t.Error("read-only onboarding should not offer to add records")
+	}
+}
+
 func TestColumnWidths(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.width = 120
@@ -714,6 +1260,12 @@ func TestTruncPad(t *testing.T) {
 		{"truncated with ellipsis", "toolongstring", 5, "tool…"},
 		{"zero width", "test", 0, ""},
 		{"width 1 truncation", "long", 1, "l"},
+		{"wide padded by cells", "坂本龍一", 10, "坂本龍一  "},
+		{"wide exact fit", "坂本", 4, "坂本"},
+		{"wide truncated", "坂本龍一", 6, "坂本… "},
+		{"wide split leaves a pad cell", "坂本龍一", 4, "坂… "},
+		{"combining marks take no cells", "Beyonce\u0301", 9, "Beyonce\u0301  "},
+		{"wide width 1", "坂本", 1, " "},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -764,6 +1316,75 @@ func TestScrolling(t *testing.T) {
 	}
 }
 
+func TestLineNumbersUnderScroll(t *testing.T) {
+	records := make([]db.Record, 120)
+	for i := range records {
+		records[i] = db.Record{ArtistName: fmt.Sprintf("Artist %d", i), AlbumTitle: "Album"}
+	}
+	m := newTestModel(records).WithLineNumbers(true)
+	m.height = 10
+	m.offset = 95
+	m.cursor = 95
+
+	if got := m.lineNumberWidth(); got != 4 {
+		t.Fatalf("lineNumberWidth = %d, want 4 for 120 rows", got)
+	}
+	tests := []struct {
+		row  int
+		want string
+	}{
+		{-1, "  # "},
+		{0, "  1 "},
+		{8, "  9 "},
+		{95, " 96 "},
+		{119, "120 "},
+	}
+	for _, tt := range tests {
+		if got := m.lineNumberCell(tt.row); got != tt.want {
+			t.Errorf("lineNumberCell(%d) = %q, want %q", tt.row, got, tt.want)
+		}
+	}
+
+	view := m.renderList()
+	if !strings.Contains(view, " 96 Artist 95") {
+		t.Error("first visible row should be numbered by its list index, not screen position")
+	}
+	if strings.Contains(view, "  1 Artist 0") {
+		t.Error("scrolled-off rows should not be rendered")
+	}
+}
+
+func TestLineNumbersToggle(t *testing.T) {
+	m := newTestModel(testRecords())
+	if m.lineNumberCell(0) != "" {
+		t.Error("line numbers should be off by default")
+	}
+	updated, _ := m.Update(keyMsg("#"))
+	if got := updated.(Model).lineNumberCell(0); got != "1 " {
+		t.Errorf("lineNumberCell(0) after toggle = %q, want %q", got, "1 ")
+	}
+}
+
+func TestStripedRows(t *testing.T) {
+	records := make([]db.Record, 4)
+	for i := range records {
+		records[i] = db.Record{RecordID: fmt.Sprint(i), ArtistName: fmt.Sprintf("Artist %d", i), AlbumTitle: "Album"}
+	}
+	for _, cursor := range []int{0, 1} {
+		m := newTestModel(records)
+		m.colorDepth = depthTrueColor
+		m.setTheme(0)
+		m.cursor = cursor
+		plain, striped := listLines(m), listLines(m.WithStripes(true))
+		for i := range records {
+			shaded := i%2 == 1 && i != cursor
+			if got := plain[i+1] != striped[i+1]; got != shaded {
+				t.Errorf("cursor %d: row %d shaded = %v, want %v", cursor, i, got, shaded)
+			}
+		}
+	}
+}
+
 func TestScrollUpAdjustsOffset(t *testing.T) {
 	records := make([]db.Record, 50)
 	for i := range records {
@@ -846,6 +1467,27 @@ func TestDetailViewNotSynced(t *testing.T) {
 	}
 }
 
+func TestShapedVinylShown(t *testing.T) {
+	records := testRecords()
+	records[0].IsShapedVinyl = new(true)
+	records[1].IsShapedVinyl = new(false)
+	m := newTestModel(records)
+
+	list := m.renderList()
+	if !strings.Contains(list, "Kind of Blue"+shapedMarker) {
+		t.Error("shaped record should be marked in the list")
+	}
+	if strings.Contains(list, "A Love Supreme"+shapedMarker) {
+		t.Error("round record should not be marked")
+	}
+
+	m.view = detailView
+	detail := m.renderDetail()
+	if !strings.Contains(detail, "Shaped") || !strings.Contains(detail, "Yes") {
+		t.Error("detail should show Shaped: Yes")
+	}
+}
+
 func TestDetailMosaicLayout(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.view = detailView
@@ -857,6 +1499,20 @@ func TestDetailMosaicLayout(t *testing.T) {
 	}
 }
 
+func TestDetailHalfBlockLayout(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.imgProto = protoHalfBlock
+	m.artRender = "halfblock-art"
+	v := m.View()
+	if !strings.Contains(v.Content, "halfblock-art") {
+		t.Error("half-block layout should include art inline")
+	}
+	if !strings.Contains(v.Content, "Miles Davis") {
+		t.Error("half-block layout should include record info")
+	}
+}
+
 func TestDetailNativeLayout(t *testing.T) {
 	m := newTestModel(testRecords())
 	m.view = detailView
@@ -905,7 +1561,7 @@ func TestScrollInfoShown(t *testing.T) {
 
 func TestLoadRecordsCmd(t *testing.T) {
 	store := &mockStore{records: testRecords()}
-	cmd := loadRecords(store)
+	cmd := loadRecords(context.Background(), store)
 	if cmd == nil {
 		t.Fatal("loadRecords should return a command")
 	}
@@ -921,7 +1577,7 @@ func TestLoadRecordsCmd(t *testing.T) {
 
 func TestSearchRecordsCmd(t *testing.T) {
 	store := &mockStore{records: testRecords()}
-	cmd := searchRecords(store, "miles")
+	cmd := searchRecords(context.Background(), store, "miles", 0)
 	if cmd == nil {
 		t.Fatal("searchRecords should return a command")
 	}
@@ -935,8 +1591,98 @@ func TestSearchRecordsCmd(t *testing.T) {
 	}
 }
 
+func TestSearchRecordsMultiTerm(t *testing.T) {
+	store := &mockStore{records: testRecords()}
+	loaded := searchRecords(context.Background(), store, "miles blue", 0)().(recordsLoadedMsg)
+	if len(loaded.records) != 1 || loaded.records[0].AlbumTitle != "Kind of Blue" {
+		t.Errorf("multi-term search = %v, want Kind of Blue only", loaded.records)
+	}
+
+	loaded = searchRecords(context.Background(), store, "miles supreme", 0)().(recordsLoadedMsg)
+	if len(loaded.records) != 0 {
+		t.Errorf("terms split across records should not match, got %d", len(loaded.records))
+	}
+}
+
+func TestSearchRecordsQuotedPhrase(t *testing.T) {
+	store := &mockStore{records: testRecords()}
+	loaded := searchRecords(context.Background(), store, `"love supreme"`, 0)().(recordsLoadedMsg)
+	if len(loaded.records) != 1 || loaded.records[0].ArtistName != "John Coltrane" {
+		t.Errorf("quoted phrase search = %v, want A Love Supreme", loaded.records)
+	}
+
+	loaded = searchRecords(context.Background(), store, `"miles blue"`, 0)().(recordsLoadedMsg)
+	if len(loaded.records) != 0 {
+		t.Errorf("quoted phrase should match literally, got %d records", len(loaded.records))
+	}
+}
+
+func TestSearchRecordsTagPrefix(t *testing.T) {
+	records := testRecords()
+	records[1].Tags = []string{"to-sell"}
+	store := &mockStore{records: records}
+
+	loaded := searchRecords(context.Background(), store, "tag:to-sell", 0)().(recordsLoadedMsg)
+	if len(loaded.records) != 1 || loaded.records[0].RecordID != "2" {
+		t.Errorf("tag search = %v, want record 2", loaded.records)
+	}
+	loaded = searchRecords(context.Background(), store, "tag:to-sell miles", 0)().(recordsLoadedMsg)
+	if len(loaded.records) != 0 {
+		t.Errorf("tag plus non-matching term should be empty, got %d", len(loaded.records))
+	}
+}
+
+func TestDetailShowsTags(t *testing.T) {
+	records := testRecords()
+	records[0].Tags = []string{"gift", "rare-pressing"}
+	m := newTestModel(records)
+	m.view = detailView
+	if !strings.Contains(m.View().Content, "gift, rare-pressing") {
+		t.Error("detail should show tags")
+	}
+}
+
+func TestDetailEditTags(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+
+	updated, _ := m.Update(keyMsg("t"))
+	m = updated.(Model)
+	if !m.tagEditing {
+		t.Fatal("t should start tag editing")
+	}
+	for _, k := range "gift, Gift ,to-sell" {
+		updated, _ = m.Update(keyMsg(string(k)))
+		m = updated.(Model)
+	}
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if cmd == nil || !m.tagSaving {
+		t.Fatal("enter should save tags")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.tagEditing {
+		t.Error("tag editing should end after save")
+	}
+	got := m.filtered[0].Tags
+	if len(got) != 2 || got[0] != "gift" || got[1] != "to-sell" {
+		t.Errorf("tags = %q, want [gift to-sell]", got)
+	}
+}
+
+func TestDetailEditTagsReadOnly(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.readOnly = true
+	updated, _ := m.Update(keyMsg("t"))
+	if updated.(Model).tagEditing {
+		t.Error("t should be ignored in read-only mode")
+	}
+}
+
 func TestLoadImageCmd(t *testing.T) {
-	cmd := loadImage(protoMosaic, "", 20, 10)
+	cmd := loadImage(context.Background(), 0, nil, protoMosaic, "", artSize{w: 20, h: 10}, nil)
 	if cmd == nil {
 		t.Fatal("loadImage should return a command")
 	}
@@ -973,6 +1719,10 @@ func keyMsg(key string) tea.KeyPressMsg {
 		return tea.KeyPressMsg{Code: tea.KeyBackspace}
 	case "tab":
 		return tea.KeyPressMsg{Code: tea.KeyTab}
+	case "pgup":
+		return tea.KeyPressMsg{Code: tea.KeyPgUp}
+	case "pgdown":
+		return tea.KeyPressMsg{Code: tea.KeyPgDown}
 	default:
 		if len(key) == 1 {
 			return tea.KeyPressMsg{Code: rune(key[0])}
@@ -1074,3 +1824,240 @@ func TestSyncProgressMsgUpdatesState(t *testing.T) {
 		t.Errorf("syncTotal = %d, want 50", m.syncTotal)
 	}
 }
+
+func TestManualAddReportsAllInvalidFields(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = addManualView
+	m.manualAlbum = "Kind of Blue"
+	m.manualYear = "fifty-nine"
+	m.manualSize = "LP"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	model := updated.(Model)
+	if cmd != nil {
+		t.Fatal("invalid form should not save")
+	}
+	for _, field := range []string{db.FieldArtist, db.FieldYear, db.FieldSize} {
+		if model.manualInvalid.For(field) == "" {
+			t.Errorf("expected a problem for %s, got %v", field, model.manualInvalid)
+		}
+	}
+	if model.manualInvalid.For(db.FieldAlbum) != "" {
+		t.Error("album is filled in and should be valid")
+	}
+	view := model.renderAddManual()
+	if strings.Count(view, "✗") != 3 {
+		t.Errorf("form should flag 3 fields:\n%s", view)
+	}
+
+	model.manualArtist = "Miles Davis"
+	model.manualYear = "1959"
+	model.manualSize = `12"`
+	updated, cmd = model.Update(keyMsg("enter"))
+	if cmd == nil || updated.(Model).manualInvalid != nil {
+		t.Error("fixed form should save and clear field errors")
+	}
+}
+
+func TestManualAddCreatesAndReloads(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg("m"))
+	m = updated.(Model)
+	if m.view != addManualView {
+		t.Fatalf("view = %d, want addManualView", m.view)
+	}
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if cmd != nil || m.manualInvalid.For(db.FieldArtist) == "" || m.manualInvalid.For(db.FieldAlbum) == "" {
+		t.Fatal("empty artist and album should be flagged inline without saving")
+	}
+
+	m.manualArtist = "Bill Evans"
+	m.manualAlbum = "Sunday at the Village Vanguard"
+	m.manualLabel = "Riverside"
+	m.manualColor = "Black"
+	updated, cmd = m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if cmd == nil || !m.manualSaving {
+		t.Fatal("valid form should start saving")
+	}
+
+	store := m.store.(*mockStore)
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(store.created) != 1 || store.created[0].ArtistName != "Bill Evans" || *store.created[0].LabelName != "Riverside" {
+		t.Fatalf("created = %+v", store.created)
+	}
+	if m.view != listView || !m.loading || cmd == nil {
+		t.Error("successful create should return to the list and reload")
+	}
+}
+
+func TestManualAddCreateError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = addManualView
+	m.manualSaving = true
+
+	updated, _ := m.Update(manualRecordAddedMsg{err: errors.New("insert failed")})
+	m = updated.(Model)
+	if m.view != addManualView || m.manualSaving {
+		t.Error("failed create should stay on the form and stop saving")
+	}
+	if !strings.Contains(m.renderAddManual(), "insert failed") {
+		t.Error("form should show the create error")
+	}
+}
+
+func genreRecords() []db.Record {
+	return []db.Record{
+		{RecordID: "1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", Genres: []string{"Jazz"}},
+		{RecordID: "2", ArtistName: "Miles Davis", AlbumTitle: "Bitches Brew", Genres: []string{"Jazz", "Rock"}},
+		{RecordID: "3", ArtistName: "Led Zeppelin", AlbumTitle: "IV", Genres: []string{"Rock"}},
+		{RecordID: "4", ArtistName: "John Coltrane", AlbumTitle: "Blue Train", Genres: []string{"Jazz"}},
+	}
+}
+
+func TestDetailGenreFilter(t *testing.T) {
+	m := newTestModel(genreRecords())
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("g"))
+	m = updated.(Model)
+	if m.view != listView || m.genreFilter != "Jazz" || cmd == nil {
+		t.Fatalf("g should filter by Jazz and return to the list (view=%d genre=%q)", m.view, m.genreFilter)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.filtered) != 3 {
+		t.Errorf("filtered = %d, want 3 jazz records", len(m.filtered))
+	}
+	if !strings.Contains(m.renderList(), `in genre "Jazz"`) {
+		t.Error("title bar should show the genre filter")
+	}
+
+	updated, cmd = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.genreFilter != "" || cmd == nil {
+		t.Fatal("esc should clear the genre filter and reload")
+	}
+	updated, _ = m.Update(cmd())
+	if got := len(updated.(Model).filtered); got != 4 {
+		t.Errorf("filtered after clearing = %d, want 4", got)
+	}
+}
+
+func TestGenreFilterComposesWithSearch(t *testing.T) {
+	m := newTestModel(genreRecords())
+	m.genreFilter = "Jazz"
+	m.searching = true
+	m.search = "miles"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if got := recordIDs(m.filtered); got != "2,1" {
+		t.Errorf("jazz records matching miles = %s, want 2,1", got)
+	}
+
+	updated, _ = m.Update(keyMsg("/"))
+	updated, cmd = updated.(Model).Update(keyMsg("esc"))
+	if cmd == nil {
+		t.Fatal("clearing a database search should refetch")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.genreFilter != "Jazz" || len(m.filtered) != 3 {
+		t.Errorf("clearing search should keep the genre filter: genre=%q filtered=%d", m.genreFilter, len(m.filtered))
+	}
+}
+
+func TestNextGenre(t *testing.T) {
+	genres := []string{"Jazz", "Rock", "Funk / Soul"}
+	tests := []struct{ current, want string }{
+		{"", "Jazz"},
+		{"Jazz", "Rock"},
+		{"Funk / Soul", "Jazz"},
+		{"Blues", "Jazz"},
+	}
+	for _, tt := range tests {
+		if got := nextGenre(genres, tt.current); got != tt.want {
+			t.Errorf("nextGenre(%q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestPageKeys(t *testing.T) {
+	var recs []db.Record
+	for i := range 30 {
+		recs = append(recs, db.Record{RecordID: fmt.Sprint(i), ArtistName: fmt.Sprint(i), AlbumTitle: "x"})
+	}
+	tests := []struct {
+		name     string
+		pageSize int
+		keys     []string
+		want     int
+	}{
+		{"configured size", 7, []string{"pgdown", "pgdown"}, 14},
+		{"back up", 7, []string{"pgdown", "pgdown", "pgup"}, 7},
+		{"clamps at end", 20, []string{"pgdown", "pgdown"}, 29},
+		{"clamps at start", 7, []string{"pgup"}, 0},
+		{"defaults to visible rows", 0, []string{"pgdown"}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(recs).WithPageSize(tt.pageSize)
+			m.height = 10
+			for _, k := range tt.keys {
+				updated, _ := m.Update(keyMsg(k))
+				m = updated.(Model)
+			}
+			if m.cursor != tt.want {
+				t.Errorf("cursor = %d, want %d", m.cursor, tt.want)
+			}
+			if m.cursor < m.offset || m.cursor >= m.offset+m.listVisibleRows() {
+				t.Errorf("cursor %d outside window offset=%d", m.cursor, m.offset)
+			}
+		})
+	}
+}
+
+func TestDetailCopyDiscogsURI(t *testing.T) {
+	recs := testRecords()
+	recs[0].DiscogsURI = stringPointer("https://www.discogs.com/release/123")
+	recs[1].DiscogsURI = nil
+	fastStatus(t)
+	m := newTestModel(recs)
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("y"))
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("y should return a clipboard command")
+	}
+	var clipboard string
+	for _, msg := range runBatch(cmd) {
+		if _, ok := msg.(statusClearMsg); !ok {
+			clipboard = fmt.Sprint(msg)
+		}
+	}
+	if clipboard != "https://www.discogs.com/release/123" {
+		t.Errorf("clipboard = %q", clipboard)
+	}
+	if !strings.Contains(m.renderDetail(), "Copied https://www.discogs.com/release/123") {
+		t.Error("detail view should confirm the copy")
+	}
+
+	m.cursor = 1
+	updated, cmd = m.Update(keyMsg("y"))
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("no URI should not touch the clipboard")
+	}
+	if !strings.Contains(m.renderDetail(), "No Discogs link") {
+		t.Error("detail view should say there is no URI")
+	}
+	if m.status != "" {
+		t.Error("earlier confirmation should be cleared")
+	}
+}
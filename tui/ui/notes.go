@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+// maxNoteRunes caps a record's notes; they're a line or two, not an essay.
+const maxNoteRunes = 500
+
+type notesSavedMsg struct {
+	id    string
+	notes string
+	err   error
+}
+
+func saveNotes(ctx context.Context, store db.Store, id, notes string) tea.Cmd {
+	return func() tea.Msg {
+		err := store.SetNotes(ctx, id, notes)
+		return notesSavedMsg{id: id, notes: notes, err: err}
+	}
+}
+
+func (m Model) startNoteEdit() Model {
+	if rec, ok := m.selectedRecord(); ok {
+		m.noteEditing = true
+		m.noteInput = ""
+		if rec.Notes != nil {
+			m.noteInput = *rec.Notes
+		}
+		m.noteOriginal = m.noteInput
+		m.noteErr = ""
+	}
+	return m
+}
+
+func (m Model) handleNoteEditKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		if guarded, ok := m.guardDiscard(true); ok {
+			return guarded, nil
+		}
+		return m.quit()
+	case "esc":
+		m.noteEditing = false
+		m.noteSaving = false
+		m.noteErr = ""
+		return m, nil
+	case "enter":
+		rec, ok := m.selectedRecord()
+		if m.noteSaving || !ok {
+			return m, nil
+		}
+		m.noteSaving = true
+		m.noteErr = ""
+		return m, saveNotes(m.ctx, m.store, rec.RecordID, strings.TrimSpace(m.noteInput))
+	case "backspace":
+		if m.noteSaving {
+			return m, nil
+		}
+		runes := []rune(m.noteInput)
+		if len(runes) > 0 {
+			m.noteInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	default:
+		if m.noteSaving {
+			return m, nil
+		}
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.noteInput) < maxNoteRunes {
+			m.noteInput += string(r)
+		}
+		return m, nil
+	}
+}
+
+func (m Model) handleNotesSaved(msg notesSavedMsg) (tea.Model, tea.Cmd) {
+	m.noteSaving = false
+	if msg.err != nil {
+		m.noteErr = msg.err.Error()
+		return m, nil
+	}
+	m.noteEditing = false
+	m.noteErr = ""
+	var notes *string
+	if msg.notes != "" {
+		notes = &msg.notes
+	}
+	m.records = withRecordNotes(m.records, msg.id, notes)
+	m.filtered = withRecordNotes(m.filtered, msg.id, notes)
+	return m, nil
+}
+
+func withRecordNotes(records []db.Record, id string, notes *string) []db.Record {
+	out := make([]db.Record, len(records))
+	copy(out, records)
+	for i := range out {
+		if out[i].RecordID == id {
+			out[i].Notes = notes
+		}
+	}
+	return out
+}
+
+// renderNoteEdit is the notes prompt that replaces the detail view's help
+// line while editing.
+func (m Model) renderNoteEdit() string {
+	var b strings.Builder
+	input := m.noteInput
+	if !m.noteSaving {
+		input += "█"
+	}
+	prompt := m.styles.search
+	if m.width > 0 {
+		// Notes run longer than tags; wrap them rather than spill past
+		// the edge.
+		prompt = prompt.Width(m.width)
+	}
+	b.WriteString(prompt.Render("Notes: " + input))
+	b.WriteString("\n")
+	if m.noteSaving {
+		b.WriteString(m.styles.statusBar.Render("Saving notes..."))
+		b.WriteString("\n")
+	}
+	if m.noteErr != "" {
+		b.WriteString(m.styles.error.Render("  " + m.noteErr))
+		b.WriteString("\n")
+	}
+	if m.discardPrompt {
+		b.WriteString(m.renderDiscardPrompt())
+		return b.String()
+	}
+	b.WriteString(m.helpLine(noteEditKeys))
+	return b.String()
+}
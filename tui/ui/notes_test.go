@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetailShowsNotes(t *testing.T) {
+	records := testRecords()
+	records[0].Notes = new("Bought at Amoeba, sleeve split")
+	m := newTestModel(records)
+	m.view = detailView
+	if !strings.Contains(m.View().Content, "Bought at Amoeba") {
+		t.Error("detail should show notes")
+	}
+
+	m.filtered[0].Notes = nil
+	if !strings.Contains(m.View().Content, "Notes") {
+		t.Error("detail should keep the Notes row when there are none")
+	}
+}
+
+func TestDetailEditNotes(t *testing.T) {
+	records := testRecords()
+	records[0].Notes = new("VG")
+	m := newTestModel(records)
+	m.view = detailView
+
+	updated, _ := m.Update(keyMsg("n"))
+	m = updated.(Model)
+	if !m.noteEditing || m.noteInput != "VG" {
+		t.Fatalf("n should start editing the current notes, got editing=%v input=%q", m.noteEditing, m.noteInput)
+	}
+	for _, k := range "+ first press " {
+		updated, _ = m.Update(keyMsg(string(k)))
+		m = updated.(Model)
+	}
+	if !strings.Contains(m.View().Content, "Notes: VG+ first press") {
+		t.Error("detail should show the notes prompt while editing")
+	}
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if cmd == nil || !m.noteSaving {
+		t.Fatal("enter should save notes")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.noteEditing {
+		t.Error("note editing should end after save")
+	}
+	if got := m.filtered[0].NotesString(); got != "VG+ first press" {
+		t.Errorf("notes = %q, want trimmed input", got)
+	}
+}
+
+func TestDetailClearNotes(t *testing.T) {
+	records := testRecords()
+	records[0].Notes = new("VG")
+	m := newTestModel(records)
+	m.view = detailView
+
+	updated, _ := m.Update(keyMsg("n"))
+	m = updated.(Model)
+	for range 2 {
+		updated, _ = m.Update(keyMsg("backspace"))
+		m = updated.(Model)
+	}
+	updated, cmd := m.Update(keyMsg("enter"))
+	updated, _ = updated.(Model).Update(cmd())
+	if got := updated.(Model).filtered[0].Notes; got != nil {
+		t.Errorf("notes = %q, want nil after clearing", *got)
+	}
+}
+
+func TestDetailEditNotesError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.store = &mockStore{err: errors.New("boom")}
+	m.view = detailView
+
+	updated, _ := m.Update(keyMsg("n"))
+	updated, cmd := updated.(Model).Update(keyMsg("enter"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if !m.noteEditing || m.noteErr != "boom" {
+		t.Errorf("failed save should keep editing with the error, got editing=%v err=%q", m.noteEditing, m.noteErr)
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	if updated.(Model).noteEditing {
+		t.Error("esc should cancel note editing")
+	}
+}
+
+func TestDetailEditNotesReadOnly(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.readOnly = true
+	updated, _ := m.Update(keyMsg("n"))
+	if updated.(Model).noteEditing {
+		t.Error("n should be ignored in read-only mode")
+	}
+}
@@ -0,0 +1,29 @@
+package ui
+
+import "strings"
+
+// collectionEmpty reports whether the store has no records at all, as
+// opposed to a search or filter that matched nothing.
+func (m Model) collectionEmpty() bool {
+	return len(m.records) == 0 && !m.recordsSearched && m.genreFilter == "" && m.tagFilter == "" && m.labelFilter == nil && !m.yearRange.active()
+}
+
+// renderOnboarding replaces "No records found" for a brand-new collection,
+// confirming the database answered and pointing at the ways to fill it.
+func (m Model) renderOnboarding() string {
+	var b strings.Builder
+	b.WriteString("\n  " + m.styles.success.Render("✓ Connected to the database") + " — your collection is empty.\n\n")
+	if m.readOnly {
+		b.WriteString("  " + m.styles.help.Render("Read-only mode is on, so records can't be added from here.") + "\n")
+		return b.String()
+	}
+	hints := []struct{ key, desc string }{
+		{"a", "add a record from Discogs"},
+		{"m", "add a record by hand"},
+	}
+	for _, h := range hints {
+		b.WriteString("    " + m.styles.label.Width(4).Render(h.key) + m.styles.value.Render(h.desc) + "\n")
+	}
+	b.WriteString("\n  " + m.styles.help.Render("Or load a CSV from the shell: records-tui import records.csv") + "\n")
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestEmptyListStates(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*Model)
+		want  string
+		not   string
+	}{
+		{"empty collection", func(m *Model) {}, "your collection is empty", "No records found"},
+		{"search with no hits", func(m *Model) { m.recordsSearched = true }, "No records found", "collection is empty"},
+		{"genre filter", func(m *Model) { m.genreFilter = "Jazz" }, "No records found", "collection is empty"},
+		{"year range", func(m *Model) { m.yearRange = yearRange{1970, 1979} }, "No records found", "collection is empty"},
+		{"load failed", func(m *Model) { m.err = errors.New("connection refused") }, "connection refused", "Connected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(nil)
+			tt.setup(&m)
+			out := ansi.Strip(m.renderList())
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("list should contain %q:\n%s", tt.want, out)
+			}
+			if strings.Contains(out, tt.not) {
+				t.Errorf("list should not contain %q:\n%s", tt.not, out)
+			}
+		})
+	}
+}
+
+func TestOnboardingShowsAddHints(t *testing.T) {
+	out := ansi.Strip(newTestModel(nil).renderList())
+	for _, want := range []string{"Connected to the database", "add a record from Discogs", "add a record by hand", "records-tui import"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("onboarding missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package ui
+
+import tea "charm.land/bubbletea/v2"
+
+// startSearch runs the database search for query, showing it as the
+// pending load until the results arrive. The results carry searchSeq, so
+// a search cleared meanwhile can't replace the list.
+func (m Model) startSearch(query string) (Model, tea.Cmd) {
+	m.loading = true
+	m.loadingSearch = query
+	return m, searchRecords(m.ctx, m.store, query, m.searchSeq)
+}
+
+// cancelSearch makes any database search still in flight stale. Called
+// wherever the search is cleared or replaced.
+func (m *Model) cancelSearch() {
+	m.searchSeq++
+	if m.loadingSearch != "" {
+		m.loading = false
+		m.loadingSearch = ""
+	}
+}
+
+// staleSearch reports whether msg holds results for a search that was
+// cleared or replaced while it ran.
+func (m Model) staleSearch(msg recordsLoadedMsg) bool {
+	return msg.searched && msg.seq != m.searchSeq
+}
@@ -0,0 +1,33 @@
+package ui
+
+import "testing"
+
+func TestClearedSearchDropsResults(t *testing.T) {
+	clear := []struct {
+		name string
+		do   func(Model) Model
+	}{
+		{"clear filters", func(m Model) Model { m, _ = pressKeys(m, keyMsg("c")); return m }},
+		{"new search", func(m Model) Model { m, _ = pressKeys(m, keyMsg("/"), keyMsg("esc")); return m }},
+	}
+	for _, tt := range clear {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(testRecords())
+			m.searching = true
+			m.search = "miles"
+			updated, cmd := m.Update(keyMsg("enter"))
+			m = updated.(Model)
+			pending := cmd()
+
+			m = tt.do(m)
+			updated, _ = m.Update(pending)
+			m = updated.(Model)
+			if m.recordsSearched || len(m.records) != len(testRecords()) {
+				t.Errorf("results of a cleared search replaced the list: searched=%v, %d records", m.recordsSearched, len(m.records))
+			}
+			if m.loadingSearch != "" {
+				t.Error("clearing should end the pending search")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type playRecordedMsg struct {
+	id   string
+	play db.Play
+	err  error
+}
+
+func recordPlay(ctx context.Context, store db.Store, id string) tea.Cmd {
+	return func() tea.Msg {
+		p, err := store.RecordPlay(ctx, id)
+		return playRecordedMsg{id: id, play: p, err: err}
+	}
+}
+
+func (m Model) handlePlayRecorded(msg playRecordedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.detailErr = "play: " + msg.err.Error()
+		return m, nil
+	}
+	m.records = withRecordPlay(m.records, msg.id, msg.play)
+	m.filtered = withRecordPlay(m.filtered, msg.id, msg.play)
+	times := "times"
+	if msg.play.Count == 1 {
+		times = "time"
+	}
+	return m, m.setStatus(fmt.Sprintf("Logged a play — %d %s so far.", msg.play.Count, times))
+}
+
+func withRecordPlay(records []db.Record, id string, p db.Play) []db.Record {
+	out := make([]db.Record, len(records))
+	copy(out, records)
+	for i := range out {
+		if out[i].RecordID == id {
+			out[i].PlayCount = p.Count
+			out[i].LastPlayedAt = &p.At
+		}
+	}
+	return out
+}
+
+// lastPlayedString is when rec was last played, or "—" if never.
+func lastPlayedString(rec db.Record, now time.Time) string {
+	if rec.LastPlayedAt == nil {
+		return "—"
+	}
+	return timestampString(*rec.LastPlayedAt, now)
+}
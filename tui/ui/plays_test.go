@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"my-record-collection-tui/db"
+)
+
+func TestDetailLogPlay(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	if !strings.Contains(m.View().Content, "Last played") {
+		t.Fatal("detail should show the play history")
+	}
+
+	for range 2 {
+		updated, cmd := m.Update(keyMsg("p"))
+		if cmd == nil {
+			t.Fatal("p should log a play")
+		}
+		updated, _ = updated.(Model).Update(cmd())
+		m = updated.(Model)
+	}
+	rec := m.filtered[0]
+	if rec.PlayCount != 2 || rec.LastPlayedAt == nil {
+		t.Errorf("after two plays: count %d, last played %v", rec.PlayCount, rec.LastPlayedAt)
+	}
+	if !strings.Contains(m.status, "2 times") {
+		t.Errorf("status = %q, want the new play count", m.status)
+	}
+}
+
+func TestDetailLogPlayError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.store = &mockStore{err: errors.New("boom")}
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("p"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.filtered[0].PlayCount != 0 || !strings.Contains(m.detailErr, "boom") {
+		t.Errorf("failed play should leave the count and report, got err %q", m.detailErr)
+	}
+}
+
+func TestLastPlayedString(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	if got := lastPlayedString(db.Record{}, now); got != "—" {
+		t.Errorf("never played = %q, want —", got)
+	}
+	played := now.Add(-2 * time.Hour)
+	if got := lastPlayedString(db.Record{LastPlayedAt: &played}, now); !strings.Contains(got, "2 hours ago") {
+		t.Errorf("played two hours ago = %q", got)
+	}
+}
+
+func TestSortByPlays(t *testing.T) {
+	recs := []db.Record{
+		{RecordID: "1", ArtistName: "A", PlayCount: 3},
+		{RecordID: "2", ArtistName: "B"},
+		{RecordID: "3", ArtistName: "C", PlayCount: 12},
+	}
+	sortRecords(recs, sortPlays, true, false)
+	if got := recordIDs(recs); got != "3,1,2" {
+		t.Errorf("most played = %s, want 3,1,2", got)
+	}
+	sortRecords(recs, sortPlays, false, false)
+	if got := recordIDs(recs); got != "2,1,3" {
+		t.Errorf("least played = %s, want never-played 2 first", got)
+	}
+}
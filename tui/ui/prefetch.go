@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"context"
+	"image/color"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// prefetchRadius is how many records above and below the cursor get their
+// cover loaded while browsing the list.
+const prefetchRadius = 1
+
+// imagePrefetchedMsg only fills the cache; unlike imageLoadedMsg it never
+// replaces the art on screen.
+type imagePrefetchedMsg struct {
+	url      string
+	proto    imageProto
+	size     artSize
+	render   string
+	transmit string
+}
+
+// prefetchImage renders a cover for the cache. It runs alongside the other
+// prefetches and the selected cover's load, in no set order, so kitty
+// image IDs come from renderKitty's atomic counter.
+func prefetchImage(ctx context.Context, cache *diskCache, proto imageProto, url string, size artSize, bg color.Color) tea.Cmd {
+	return func() tea.Msg {
+		result, _ := fetchAndRender(ctx, cache, proto, url, size.w, size.h, bg)
+		return imagePrefetchedMsg{url: url, proto: proto, size: size, render: result.render, transmit: result.transmit}
+	}
+}
+
+// prefetchNeighbors loads covers for the selected record and its neighbors
+// that aren't cached or already on their way.
+func (m Model) prefetchNeighbors() tea.Cmd {
+	var cmds []tea.Cmd
+	size := m.artSize()
+	for i := m.cursor - prefetchRadius; i <= m.cursor+prefetchRadius; i++ {
+		if i < 0 || i >= len(m.filtered) {
+			continue
+		}
+		url := m.recordAt(i).ImageURL()
+		if url == "" || !m.imgCache.claim(url, m.imgProto, size) {
+			continue
+		}
+		cmds = append(cmds, prefetchImage(m.ctx, m.imgDiskCache, m.imgProto, url, size, m.artBackground()))
+	}
+	return tea.Batch(cmds...)
+}
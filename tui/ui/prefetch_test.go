@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+// runBatch executes cmd, expanding a tea.BatchMsg into its messages.
+func runBatch(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, runBatch(c)...)
+	}
+	return msgs
+}
+
+func TestPrefetchNeighborsOnCursorMove(t *testing.T) {
+	server := servePNG(t)
+	defer server.Close()
+
+	var recs []db.Record
+	for i := range 5 {
+		url := fmt.Sprintf("%s/%d.png", server.URL, i)
+		recs = append(recs, db.Record{RecordID: fmt.Sprint(i), ArtistName: fmt.Sprint(i), CoverImageURL: &url})
+	}
+	m := newTestModel(recs)
+	m.cursor = 1
+	m.artRender = "current art"
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m = updated.(Model)
+	msgs := runBatch(cmd)
+	if len(msgs) != 3 {
+		t.Fatalf("prefetched %d images, want 3 (cursor and one either side)", len(msgs))
+	}
+	for _, msg := range msgs {
+		if _, ok := msg.(imagePrefetchedMsg); !ok {
+			t.Fatalf("msg = %T, want imagePrefetchedMsg", msg)
+		}
+		updated, _ = m.Update(msg)
+		m = updated.(Model)
+	}
+	for i := 1; i <= 3; i++ {
+		if _, ok := m.imgCache.get(recs[i].ImageURL(), m.imgProto, m.artSize()); !ok {
+			t.Errorf("record %d should be cached", i)
+		}
+	}
+	if m.artRender != "current art" {
+		t.Error("prefetch should not replace the displayed art")
+	}
+
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	m = updated.(Model)
+	if msgs := runBatch(cmd); len(msgs) != 1 {
+		t.Errorf("moving up should only fetch the one uncached neighbor, got %d", len(msgs))
+	}
+}
+
+func TestPrefetchSkipsPendingAndMissing(t *testing.T) {
+	url := "http://example.invalid/a.png"
+	recs := testRecords()
+	recs[1].CoverImageURL = &url
+	m := newTestModel(recs)
+
+	if !m.imgCache.claim(url, m.imgProto, m.artSize()) {
+		t.Fatal("first claim should succeed")
+	}
+	m.cursor = 1
+	if cmd := m.prefetchNeighbors(); cmd != nil {
+		t.Error("in-flight and URL-less records should not be fetched")
+	}
+}
+
+func TestPrefetchKittyConcurrentIDs(t *testing.T) {
+	server := servePNG(t)
+	defer server.Close()
+
+	var recs []db.Record
+	for i := range 3 {
+		url := fmt.Sprintf("%s/%d.png", server.URL, i)
+		recs = append(recs, db.Record{RecordID: fmt.Sprint(i), ArtistName: fmt.Sprint(i), CoverImageURL: &url})
+	}
+	m := newTestModel(recs)
+	m.imgProto = protoKitty
+	m.cursor = 1
+
+	// Bubble Tea runs batched commands in their own goroutines, so run
+	// them that way here rather than one after another.
+	batch, ok := m.prefetchNeighbors()().(tea.BatchMsg)
+	if !ok || len(batch) != 3 {
+		t.Fatalf("prefetchNeighbors = %v, want a batch of 3", batch)
+	}
+	msgs := make([]imagePrefetchedMsg, len(batch))
+	var wg sync.WaitGroup
+	for i, cmd := range batch {
+		wg.Go(func() { msgs[i] = cmd().(imagePrefetchedMsg) })
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for _, msg := range msgs {
+		if msg.transmit == "" {
+			t.Fatalf("prefetch of %s rendered no kitty image", msg.url)
+		}
+		if seen[msg.render] {
+			t.Fatalf("two prefetched covers got the same image ID: %q", msg.render)
+		}
+		seen[msg.render] = true
+	}
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"my-record-collection-tui/db"
+)
+
+// WithQueryTimeout bounds each database call; a call that runs longer
+// fails with "query timed out". d <= 0 leaves calls unbounded.
+func (m Model) WithQueryTimeout(d time.Duration) Model {
+	m.queryTimeout = d
+	if _, wrapped := m.store.(*db.TimeoutStore); m.store != nil && !wrapped {
+		m.attachStore(m.store)
+	}
+	return m
+}
+
+// attachStore makes store the model's, cancelling any call still running
+// against the previous one.
+func (m *Model) attachStore(store db.Store) {
+	if m.cancelQueries != nil {
+		m.cancelQueries()
+	}
+	m.queries, m.cancelQueries = context.WithCancel(m.ctx)
+	m.store = store
+	if store != nil && m.queryTimeout > 0 {
+		m.store = db.NewTimeoutStore(m.queries, store, m.queryTimeout)
+	}
+}
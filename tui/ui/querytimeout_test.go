@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"my-record-collection-tui/db"
+)
+
+func TestQueryTimeoutWrapsConnectedStore(t *testing.T) {
+	store := &mockStore{records: testRecords()}
+	m := newDisconnectedModel(func(context.Context) (db.Store, error) { return store, nil }).
+		WithQueryTimeout(time.Second)
+
+	updated, _ := m.Update(m.Init()())
+	m = updated.(Model)
+	if _, ok := m.store.(*db.TimeoutStore); !ok {
+		t.Fatalf("store = %T, want it wrapped in a TimeoutStore", m.store)
+	}
+	first := m.queries
+
+	updated, _ = m.Update(storeConnectedMsg{store: store})
+	if first.Err() == nil {
+		t.Error("a new store should cancel calls still running on the old one")
+	}
+	if updated.(Model).queries.Err() != nil {
+		t.Error("the new store's calls should not start out cancelled")
+	}
+}
+
+func TestQueryTimeoutOff(t *testing.T) {
+	m := newTestModel(testRecords()).WithQueryTimeout(0)
+	if _, ok := m.store.(*mockStore); !ok {
+		t.Errorf("store = %T, want the bare store when timeouts are off", m.store)
+	}
+}
+
+func TestQueryTimeoutShownInList(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.records, m.filtered = nil, nil
+	err := fmt.Errorf("%w after 30s", db.ErrQueryTimeout)
+	updated, _ := m.Update(recordsLoadedMsg{err: err})
+	if view := updated.(Model).View().Content; !strings.Contains(view, "query timed out after 30s") {
+		t.Errorf("view should say the query timed out:\n%s", view)
+	}
+}
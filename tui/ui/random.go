@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"math/rand/v2"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func newRandIntN() func(int) int {
+	seed := uint64(time.Now().UnixNano())
+	return rand.New(rand.NewPCG(seed, seed>>32)).IntN
+}
+
+// randomRow picks a row in [0, n) other than current, so a pick always
+// moves when there's somewhere to move to. It returns -1 for an empty list.
+func randomRow(n, current int, intn func(int) int) int {
+	switch {
+	case n <= 0:
+		return -1
+	case n == 1:
+		return 0
+	}
+	row := intn(n - 1)
+	if row >= current {
+		row++
+	}
+	return row
+}
+
+// pickRandom moves the cursor to a random row of the filtered list,
+// centring it, and opens its detail view when open is set.
+func (m Model) pickRandom(open bool) (tea.Model, tea.Cmd) {
+	row := randomRow(len(m.filtered), m.cursor, m.randIntN)
+	if row < 0 {
+		return m, m.setStatus("Nothing to pick from.")
+	}
+	m.cursor = row
+	m.deleteConfirm = false
+	visible := m.listVisibleRows()
+	m.offset = max(0, min(row-visible/2, len(m.filtered)-visible))
+	if open {
+		return m.handleListKey("enter")
+	}
+	var thumbs tea.Cmd
+	m, thumbs = m.loadThumbnails()
+	return m, tea.Batch(m.prefetchNeighbors(), thumbs)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import "testing"
+
+func TestRandomRow(t *testing.T) {
+	first := func(int) int { return 0 }
+	last := func(n int) int { return n - 1 }
+	tests := []struct {
+		name       string
+		n, current int
+		intn       func(int) int
+		want       int
+	}{
+		{"empty", 0, 0, first, -1},
+		{"single", 1, 0, first, 0},
+		{"skips current", 3, 0, first, 1},
+		{"before current", 3, 2, first, 0},
+		{"last row", 3, 0, last, 2},
+		{"never current", 2, 1, last, 0},
+	}
+	for _, tt := range tests {
+		if got := randomRow(tt.n, tt.current, tt.intn); got != tt.want {
+			t.Errorf("%s: randomRow(%d, %d) = %d, want %d", tt.name, tt.n, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestRandomRowStaysInRange(t *testing.T) {
+	intn := newRandIntN()
+	for n := 1; n < 10; n++ {
+		for range 50 {
+			if got := randomRow(n, 0, intn); got < 0 || got >= n || (n > 1 && got == 0) {
+				t.Fatalf("randomRow(%d, 0) = %d", n, got)
+			}
+		}
+	}
+}
+
+func TestPickRandomKeys(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.randIntN = func(n int) int { return n - 1 }
+
+	updated, _ := m.Update(keyMsg("z"))
+	m = updated.(Model)
+	if m.cursor != 2 || m.view != listView {
+		t.Errorf("z: cursor %d view %d, want row 2 in the list", m.cursor, m.view)
+	}
+
+	m.cursor = 2
+	m.randIntN = func(int) int { return 0 }
+	updated, _ = m.Update(keyMsg("Z"))
+	m = updated.(Model)
+	if m.cursor != 0 || m.view != detailView {
+		t.Errorf("Z: cursor %d view %d, want row 0 opened in detail", m.cursor, m.view)
+	}
+}
+
+func TestPickRandomEmpty(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.filtered = nil
+	updated, _ := m.Update(keyMsg("Z"))
+	m = updated.(Model)
+	if m.view != listView || m.status != "Nothing to pick from." {
+		t.Error("a filter matching nothing should stay put and say so")
+	}
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"context"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type ratingSavedMsg struct {
+	id     string
+	rating *int
+	err    error
+}
+
+// saveRating stores rating for id, or clears it when rating is nil.
+func saveRating(ctx context.Context, store db.Store, id string, rating *int) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if rating == nil {
+			err = store.ClearRating(ctx, id)
+		} else {
+			err = store.SetRating(ctx, id, *rating)
+		}
+		return ratingSavedMsg{id: id, rating: rating, err: err}
+	}
+}
+
+// rateSelected handles the detail view's number keys: 1–5 set the rating
+// and 0 clears it. Other digits are ignored.
+func (m Model) rateSelected(key string) (Model, tea.Cmd) {
+	rec, ok := m.selectedRecord()
+	if !ok || len(key) != 1 {
+		return m, nil
+	}
+	n := int(key[0] - '0')
+	if n == 0 {
+		return m, saveRating(m.ctx, m.store, rec.RecordID, nil)
+	}
+	if !db.ValidRating(n) {
+		return m, nil
+	}
+	return m, saveRating(m.ctx, m.store, rec.RecordID, &n)
+}
+
+func (m Model) handleRatingSaved(msg ratingSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.detailErr = "rating: " + msg.err.Error()
+		return m, nil
+	}
+	m.records = withRecordRating(m.records, msg.id, msg.rating)
+	m.filtered = withRecordRating(m.filtered, msg.id, msg.rating)
+	if msg.rating == nil {
+		return m, m.setStatus("Rating cleared.")
+	}
+	return m, m.setStatus("Rated " + db.Record{Rating: msg.rating}.RatingString())
+}
+
+func withRecordRating(records []db.Record, id string, rating *int) []db.Record {
+	out := make([]db.Record, len(records))
+	copy(out, records)
+	for i := range out {
+		if out[i].RecordID == id {
+			out[i].Rating = rating
+		}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestSortByRating(t *testing.T) {
+	recs := []db.Record{
+		{RecordID: "1", ArtistName: "A", Rating: new(3)},
+		{RecordID: "2", ArtistName: "B"},
+		{RecordID: "3", ArtistName: "C", Rating: new(5)},
+		{RecordID: "4", ArtistName: "D", Rating: new(1)},
+	}
+	sortRecords(recs, sortRating, false, false)
+	if got := recordIDs(recs); got != "4,1,3,2" {
+		t.Errorf("rating ascending = %s, want 4,1,3,2", got)
+	}
+	sortRecords(recs, sortRating, true, false)
+	if got := recordIDs(recs); got != "3,1,4,2" {
+		t.Errorf("rating descending = %s, want 3,1,4,2 with unrated last", got)
+	}
+}
+
+func TestDetailRateWithNumberKeys(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("4"))
+	if cmd == nil {
+		t.Fatal("4 should save a rating")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if got := m.filtered[0].RatingString(); got != "★★★★☆" {
+		t.Errorf("rating = %q, want four stars", got)
+	}
+	if !strings.Contains(m.View().Content, "★★★★☆") {
+		t.Error("detail should show the new rating")
+	}
+
+	updated, cmd = m.Update(keyMsg("0"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.filtered[0].Rating != nil {
+		t.Errorf("rating = %d, want unrated after 0", *m.filtered[0].Rating)
+	}
+	if m.store.(*mockStore).records[0].Rating != nil {
+		t.Error("0 should clear the stored rating")
+	}
+}
+
+func TestDetailRateIgnoresOutOfRange(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	for _, k := range []string{"6", "9"} {
+		if _, cmd := m.Update(keyMsg(k)); cmd != nil {
+			t.Errorf("%s should not save a rating", k)
+		}
+	}
+}
+
+func TestDetailRateError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.store = &mockStore{err: errors.New("boom")}
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("3"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.filtered[0].Rating != nil || !strings.Contains(m.detailErr, "boom") {
+		t.Errorf("failed save should leave the rating and report, got err %q", m.detailErr)
+	}
+}
+
+func TestDetailRateReadOnly(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.readOnly = true
+	updated, _ := m.Update(keyMsg("5"))
+	if got := updated.(Model).status; got != readOnlyStatus {
+		t.Errorf("status = %q, want the read-only notice", got)
+	}
+}
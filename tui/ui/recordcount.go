@@ -0,0 +1,20 @@
+package ui
+
+import "fmt"
+
+// narrowed reports whether a search or filter is hiding part of the
+// collection.
+func (m Model) narrowed() bool {
+	return m.search != "" || m.genreFilter != "" || m.tagFilter != "" || m.labelFilter != nil || m.yearRange.active() || m.unsyncedOnly
+}
+
+// recordCount is the title's count: "340 records", or "12 of 340 records"
+// while the list is narrowed. The loaded records stand in for the total
+// until a load has reported one.
+func (m Model) recordCount() string {
+	if !m.narrowed() {
+		return fmt.Sprintf("%d records", len(m.filtered))
+	}
+	total := max(m.total, len(m.records))
+	return fmt.Sprintf("%d of %d records", len(m.filtered), total)
+}
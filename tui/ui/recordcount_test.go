@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestRecordCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*Model)
+		want  string
+	}{
+		{"everything", func(m *Model) {}, "3 records"},
+		{"live search", func(m *Model) {
+			m.searching = true
+			m.search = "kind"
+			m.applyFilters()
+		}, "1 of 3 records"},
+		{"unsynced only", func(m *Model) {
+			m.records[0].IsSyncedWithDiscogs = true
+			m.unsyncedOnly = true
+			m.applyFilters()
+		}, "2 of 3 records"},
+		{"server-side filter", func(m *Model) {
+			m.genreFilter = "Jazz"
+			m.records = []db.Record{{RecordID: "1", Genres: []string{"Jazz"}}}
+			m.total = 340
+			m.applyFilters()
+		}, "1 of 340 records"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(testRecords())
+			tt.setup(&m)
+			if got := m.recordCount(); got != tt.want {
+				t.Errorf("recordCount() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilteredLoadReportsTotal(t *testing.T) {
+	m := newTestModel(genreRecords())
+	m.genreFilter = "Jazz"
+	msg := m.fetchRecords(false)().(recordsLoadedMsg)
+	if msg.total != len(genreRecords()) || len(msg.records) >= msg.total {
+		t.Errorf("genre load = %d records of %d, want a subset of %d", len(msg.records), msg.total, len(genreRecords()))
+	}
+
+	msg = searchRecords(context.Background(), &mockStore{records: testRecords()}, "miles", 0)().(recordsLoadedMsg)
+	if msg.total != len(testRecords()) {
+		t.Errorf("search total = %d, want %d", msg.total, len(testRecords()))
+	}
+
+	msg = loadRecords(context.Background(), &mockStore{records: []db.Record{{RecordID: "1"}}})().(recordsLoadedMsg)
+	if msg.total != 1 {
+		t.Errorf("list total = %d, want 1", msg.total)
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type recordSyncedMsg struct {
+	record db.Record
+	err    error
+}
+
+// syncRecordFromDiscogs refreshes one record's release metadata from
+// Discogs and saves it as synced.
+func syncRecordFromDiscogs(ctx context.Context, store db.Store, dcfg discogsConfig, rec db.Record) tea.Cmd {
+	return func() tea.Msg {
+		id, err := strconv.Atoi(strings.TrimSpace(*rec.DiscogsID))
+		if err != nil {
+			return recordSyncedMsg{err: fmt.Errorf("invalid Discogs ID %q", *rec.DiscogsID)}
+		}
+		release, err := fetchDiscogsRelease(ctx, dcfg, id)
+		if err != nil {
+			return recordSyncedMsg{err: err}
+		}
+		rec = applyDiscogsRelease(rec, release)
+		if err := store.UpdateFromDiscogs(ctx, rec); err != nil {
+			return recordSyncedMsg{err: err}
+		}
+		return recordSyncedMsg{record: rec}
+	}
+}
+
+// applyDiscogsRelease copies the release's metadata onto rec. Values the
+// release doesn't have keep what the record already holds.
+func applyDiscogsRelease(rec db.Record, release discogsRelease) db.Record {
+	rec.YearReleased = cmp.Or(yearPointer(int(release.Year)), rec.YearReleased)
+	rec.LabelName = cmp.Or(firstLabel(release), rec.LabelName)
+	rec.CatalogNumber = cmp.Or(firstCatalogNumber(release), rec.CatalogNumber)
+	if len(release.Genres) > 0 {
+		rec.Genres = release.Genres
+	}
+	if len(release.Styles) > 0 {
+		rec.Styles = release.Styles
+	}
+	rec.DiscogsURI = cmp.Or(nonEmptyPointer(release.URI), rec.DiscogsURI)
+	rec.ThumbnailURL = cmp.Or(nonEmptyPointer(release.Thumb), rec.ThumbnailURL)
+	rec.CoverImageURL = cmp.Or(nonEmptyPointer(release.CoverImage), rec.CoverImageURL)
+	rec.IsSyncedWithDiscogs = true
+	return rec
+}
+
+// startRecordSync checks the selected record can be synced and starts the
+// request.
+func (m Model) startRecordSync() (tea.Model, tea.Cmd) {
+	rec, ok := m.selectedRecord()
+	if !ok || m.recordSyncing {
+		return m, nil
+	}
+	if rec.DiscogsID == nil || strings.TrimSpace(*rec.DiscogsID) == "" {
+		m.detailErr = "No Discogs ID for this record."
+		return m, nil
+	}
+	if strings.TrimSpace(m.discogsCfg.token) == "" {
+		m.detailErr = "Set discogs_token to sync with Discogs."
+		return m, nil
+	}
+	m.recordSyncing = true
+	return m, syncRecordFromDiscogs(m.ctx, m.store, m.discogsCfg, rec)
+}
+
+func (m Model) handleRecordSynced(msg recordSyncedMsg) (tea.Model, tea.Cmd) {
+	m.recordSyncing = false
+	if msg.err != nil {
+		m.detailErr = "Discogs sync failed: " + msg.err.Error()
+		return m, nil
+	}
+	m.records = withRecord(m.records, msg.record)
+	m.filtered = withRecord(m.filtered, msg.record)
+	status := m.setStatus("Synced " + msg.record.AlbumTitle + " from Discogs.")
+	if m.view != detailView {
+		return m, status
+	}
+	var art tea.Cmd
+	m, art = m.showArt()
+	return m, tea.Batch(status, art)
+}
+
+// withRecord returns a copy of records with the entry sharing rec's ID
+// replaced by rec.
+func withRecord(records []db.Record, rec db.Record) []db.Record {
+	out := make([]db.Record, len(records))
+	copy(out, records)
+	for i := range out {
+		if out[i].RecordID == rec.RecordID {
+			out[i] = rec
+		}
+	}
+	return out
+}
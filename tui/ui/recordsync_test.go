@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestApplyDiscogsRelease(t *testing.T) {
+	rec := db.Record{
+		RecordID:     "1",
+		ArtistName:   "Miles Davis",
+		AlbumTitle:   "Kind of Blue",
+		YearReleased: new(1959),
+		LabelName:    new("Columbia"),
+		Tags:         []string{"favourite"},
+	}
+	var release discogsRelease
+	release.Genres = []string{"Jazz"}
+	release.Styles = []string{"Modal"}
+	release.CoverImage = "https://img/cover.jpg"
+	release.URI = "https://www.discogs.com/release/1"
+
+	got := applyDiscogsRelease(rec, release)
+	if got.YearString() != "1959" || got.LabelString() != "Columbia" {
+		t.Errorf("year/label = %s/%s, want kept when the release has none", got.YearString(), got.LabelString())
+	}
+	if got.GenresString() != "Jazz" || got.StylesString() != "Modal" {
+		t.Errorf("genres/styles = %s/%s", got.GenresString(), got.StylesString())
+	}
+	if got.ImageURL() != "https://img/cover.jpg" || !got.IsSyncedWithDiscogs {
+		t.Errorf("cover = %q, synced = %v", got.ImageURL(), got.IsSyncedWithDiscogs)
+	}
+	if got.ArtistName != "Miles Davis" || got.TagsString() != "favourite" {
+		t.Error("user fields should be left alone")
+	}
+}
+
+func TestDetailSyncFromDiscogs(t *testing.T) {
+	fastStatus(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":42,"title":"Kind of Blue","year":1959,"genres":["Jazz"],"styles":["Modal"],
+			"labels":[{"name":"Columbia","catno":"CS 8163"}]}`))
+	}))
+	defer srv.Close()
+	t.Setenv("DISCOGS_BASE_URL", srv.URL)
+
+	recs := testRecords()
+	recs[0].DiscogsID = stringPointer("42")
+	m := newTestModel(recs)
+	store := m.store.(*mockStore)
+	m.discogsCfg.token = "t"
+	m.view = detailView
+
+	updated, cmd := m.Update(keyMsg("s"))
+	m = updated.(Model)
+	if !m.recordSyncing || cmd == nil {
+		t.Fatal("s should start syncing the record")
+	}
+	if !strings.Contains(m.renderDetail(), "Syncing with Discogs") {
+		t.Error("detail should show the sync in progress")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.recordSyncing || m.detailErr != "" {
+		t.Fatalf("syncing = %v, err = %q", m.recordSyncing, m.detailErr)
+	}
+	if len(store.updated) != 1 || !store.updated[0].IsSyncedWithDiscogs {
+		t.Fatalf("store updates = %+v, want one synced record", store.updated)
+	}
+	rec, _ := m.selectedRecord()
+	if rec.GenresString() != "Jazz" || rec.LabelString() != "Columbia" || !rec.IsSyncedWithDiscogs {
+		t.Errorf("record not refreshed: %+v", rec)
+	}
+	if !strings.Contains(m.status, "Synced Kind of Blue") {
+		t.Errorf("status = %q", m.status)
+	}
+}
+
+func TestDetailSyncFromDiscogsRequirements(t *testing.T) {
+	tests := []struct {
+		name      string
+		discogsID *string
+		token     string
+		want      string
+	}{
+		{"no discogs id", nil, "t", "No Discogs ID"},
+		{"no token", stringPointer("42"), "", "discogs_token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := testRecords()
+			recs[0].DiscogsID = tt.discogsID
+			m := newTestModel(recs)
+			m.discogsCfg.token = tt.token
+			m.view = detailView
+			updated, cmd := m.Update(keyMsg("s"))
+			m = updated.(Model)
+			if cmd != nil || !strings.Contains(m.detailErr, tt.want) {
+				t.Errorf("cmd = %v, detailErr = %q; want %q", cmd != nil, m.detailErr, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+
+	lipgloss "charm.land/lipgloss/v2"
+)
+
+// scrollThumb returns the first row and height of the scrollbar thumb on
+// a track of visible rows, or ok=false when all total rows fit.
+func scrollThumb(offset, visible, total int) (start, size int, ok bool) {
+	if visible <= 0 || total <= visible {
+		return 0, 0, false
+	}
+	size = max(1, visible*visible/total)
+	travel := visible - size
+	start = min(travel, (offset*travel+(total-visible)/2)/(total-visible))
+	return max(0, start), size, true
+}
+
+// withScrollbar pads each list row out to the last column and puts the
+// scrollbar cell there; rows are returned unchanged when nothing scrolls.
+func (m Model) withScrollbar(rows []string) []string {
+	start, size, ok := scrollThumb(m.offset, m.listVisibleRows(), len(m.filtered))
+	if !ok {
+		return rows
+	}
+	out := make([]string, len(rows))
+	for i, row := range rows {
+		cell := m.styles.scrollTrack.Render("░")
+		if i >= start && i < start+size {
+			cell = m.styles.scrollThumb.Render("█")
+		}
+		pad := max(0, m.width-1-lipgloss.Width(row))
+		out[i] = row + strings.Repeat(" ", pad) + cell
+	}
+	return out
+}
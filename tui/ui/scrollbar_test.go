@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+func TestScrollThumb(t *testing.T) {
+	tests := []struct {
+		offset, visible, total int
+		start, size            int
+		ok                     bool
+	}{
+		{0, 10, 10, 0, 0, false},
+		{0, 10, 5, 0, 0, false},
+		{0, 0, 5, 0, 0, false},
+		{0, 10, 20, 0, 5, true},
+		{10, 10, 20, 5, 5, true},
+		{5, 10, 20, 3, 5, true},
+		{0, 10, 1000, 0, 1, true},
+		{990, 10, 1000, 9, 1, true},
+		{500, 10, 1000, 5, 1, true},
+	}
+	for _, tt := range tests {
+		start, size, ok := scrollThumb(tt.offset, tt.visible, tt.total)
+		if start != tt.start || size != tt.size || ok != tt.ok {
+			t.Errorf("scrollThumb(%d, %d, %d) = %d, %d, %v, want %d, %d, %v",
+				tt.offset, tt.visible, tt.total, start, size, ok, tt.start, tt.size, tt.ok)
+		}
+	}
+}
+
+func manyRecords(n int) []db.Record {
+	recs := make([]db.Record, n)
+	for i := range recs {
+		recs[i] = db.Record{RecordID: fmt.Sprint(i), ArtistName: fmt.Sprintf("Artist %03d", i), AlbumTitle: "Album"}
+	}
+	return recs
+}
+
+func TestListScrollbar(t *testing.T) {
+	m := newTestModel(manyRecords(100))
+	lines := strings.Split(ansi.Strip(m.renderList()), "\n")
+	thumbs, tracks := 0, 0
+	for _, line := range lines {
+		if !strings.Contains(line, "Artist 0") {
+			continue
+		}
+		if w := ansi.StringWidth(line); w != m.width {
+			t.Errorf("row width = %d, want %d: %q", w, m.width, line)
+		}
+		switch {
+		case strings.HasSuffix(line, "█"):
+			thumbs++
+		case strings.HasSuffix(line, "░"):
+			tracks++
+		}
+	}
+	visible := m.listVisibleRows()
+	if thumbs+tracks != visible || thumbs != visible*visible/100 {
+		t.Errorf("scrollbar thumb=%d track=%d, want %d rows with a %d-row thumb", thumbs, tracks, visible, visible*visible/100)
+	}
+
+	m = newTestModel(testRecords())
+	if out := ansi.Strip(m.renderList()); strings.Contains(out, "█") || strings.Contains(out, "░") {
+		t.Error("no scrollbar when every record fits")
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const defaultSearchDebounce = 250 * time.Millisecond
+
+// searchDebounceMsg fires once a query has sat untouched for the debounce
+// interval. seq ties it to the edit that scheduled it.
+type searchDebounceMsg struct {
+	seq   int
+	query string
+}
+
+// WithSearchDebounce sets how long a database search waits after the last
+// keystroke; d <= 0 searches immediately.
+func (m Model) WithSearchDebounce(d time.Duration) Model {
+	m.searchDebounce = max(d, 0)
+	return m
+}
+
+// debounceSearch schedules a database search for the current query.
+// Clearing or replacing the search bumps searchSeq, which drops this one.
+func (m Model) debounceSearch() (Model, tea.Cmd) {
+	if m.searchDebounce <= 0 {
+		return m.startSearch(m.search)
+	}
+	seq, query := m.searchSeq, m.search
+	return m, tea.Tick(m.searchDebounce, func(time.Time) tea.Msg {
+		return searchDebounceMsg{seq: seq, query: query}
+	})
+}
+
+func (m Model) handleSearchDebounce(msg searchDebounceMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.searchSeq {
+		return m, nil
+	}
+	return m.startSearch(msg.query)
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchDebounce(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchDebounce(time.Millisecond)
+	m.searching = true
+	m.search = "miles"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	msg, ok := cmd().(searchDebounceMsg)
+	if !ok {
+		t.Fatalf("enter should wait out the debounce, got %T", cmd())
+	}
+	if msg.query != "miles" {
+		t.Errorf("debounced query = %q, want miles", msg.query)
+	}
+
+	_, cmd = m.Update(msg)
+	if cmd == nil {
+		t.Fatal("a current debounce should start the search")
+	}
+	if loaded, ok := cmd().(recordsLoadedMsg); !ok || !loaded.searched {
+		t.Errorf("debounce should run the database search, got %#v", loaded)
+	}
+}
+
+func TestSearchDebounceCanceledByTyping(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchDebounce(time.Millisecond)
+	m.searching = true
+	m.search = "miles"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	stale := cmd().(searchDebounceMsg)
+	updated, _ = updated.(Model).Update(keyMsg("/"))
+	updated, _ = updated.(Model).Update(keyMsg("x"))
+	m = updated.(Model)
+
+	if _, cmd := m.Update(stale); cmd != nil {
+		t.Error("a debounce overtaken by more typing should not search")
+	}
+}
+
+func TestSearchDebounceCanceledByClearing(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchDebounce(time.Millisecond)
+	m.searching = true
+	m.search = "miles"
+
+	m, cmd := pressKeys(m, keyMsg("enter"))
+	waiting := cmd().(searchDebounceMsg)
+	m, _ = pressKeys(m, keyMsg("c"))
+
+	if _, cmd := m.Update(waiting); cmd != nil {
+		t.Error("a debounce whose search was cleared should not search")
+	}
+}
+
+func TestSearchWithoutDebounce(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchDebounce(0)
+	m.searching = true
+	m.search = "miles"
+
+	_, cmd := m.Update(keyMsg("enter"))
+	if _, ok := cmd().(recordsLoadedMsg); !ok {
+		t.Error("a zero debounce should search straight away")
+	}
+}
+
+func TestSearchShowsSearchingStatus(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchDebounce(0)
+	m.searching = true
+	m.search = "miles"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if out := m.renderList(); !strings.Contains(out, "Searching for 'miles'...") || strings.Contains(out, "Loading records") {
+		t.Errorf("pending search should say what it's searching for:\n%s", out)
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.loading || m.loadingSearch != "" {
+		t.Error("results should end the pending search")
+	}
+
+	updated, _ = m.Update(keyMsg("r"))
+	if out := updated.(Model).renderList(); !strings.Contains(out, "Loading records...") {
+		t.Errorf("a reload should show the plain loading message:\n%s", out)
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxSearchHistory bounds how many past queries up/down can recall.
+const maxSearchHistory = 20
+
+// WithSearchHistory seeds the recall list with queries from an earlier
+// run, oldest first.
+func (m Model) WithSearchHistory(history []string) Model {
+	m.searchHistory = nil
+	for _, q := range history {
+		m.searchHistory = pushSearchHistory(m.searchHistory, q)
+	}
+	m.historyPos = len(m.searchHistory)
+	return m
+}
+
+// SearchHistory returns the recent queries, oldest first, for saving.
+func (m Model) SearchHistory() []string {
+	return slices.Clone(m.searchHistory)
+}
+
+// pushSearchHistory appends q, moving it to the end if it was already
+// there and dropping the oldest entries beyond maxSearchHistory.
+func pushSearchHistory(history []string, q string) []string {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return history
+	}
+	history = slices.DeleteFunc(history, func(h string) bool { return h == q })
+	history = append(history, q)
+	if n := len(history) - maxSearchHistory; n > 0 {
+		history = history[n:]
+	}
+	return history
+}
+
+// startSearchHistory is called on entering search mode; the next up
+// recalls the most recent query.
+func (m *Model) startSearchHistory() {
+	m.historyPos = len(m.searchHistory)
+	m.searchDraft = ""
+}
+
+// recallSearch steps through the history: dir < 0 is older, dir > 0
+// newer. Stepping past the newest entry brings back the query that was
+// being typed.
+func (m Model) recallSearch(dir int) Model {
+	pos := m.historyPos + dir
+	if pos < 0 || pos > len(m.searchHistory) {
+		return m
+	}
+	if !m.browsingHistory() {
+		m.searchDraft = m.search
+	}
+	m.historyPos = pos
+	if m.browsingHistory() {
+		m.search = m.searchHistory[pos]
+	} else {
+		m.search = m.searchDraft
+	}
+	return m.liveSearch()
+}
+
+func (m Model) browsingHistory() bool {
+	return m.historyPos < len(m.searchHistory)
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestPushSearchHistory(t *testing.T) {
+	var h []string
+	for _, q := range []string{"miles", "  ", "monk", "miles"} {
+		h = pushSearchHistory(h, q)
+	}
+	if want := []string{"monk", "miles"}; !slices.Equal(h, want) {
+		t.Errorf("history = %q, want %q", h, want)
+	}
+
+	h = nil
+	for i := range maxSearchHistory + 5 {
+		h = pushSearchHistory(h, fmt.Sprint(i))
+	}
+	if len(h) != maxSearchHistory || h[0] != "5" {
+		t.Errorf("history kept %d entries starting at %q, want %d starting at 5", len(h), h[0], maxSearchHistory)
+	}
+}
+
+func TestSearchHistoryRecall(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchHistory([]string{"miles", "monk"})
+	updated, _ := m.Update(keyMsg("/"))
+	updated, _ = updated.(Model).Update(keyMsg("c"))
+	m = updated.(Model)
+
+	steps := []struct {
+		key  tea.KeyPressMsg
+		want string
+	}{
+		{tea.KeyPressMsg{Code: tea.KeyUp}, "monk"},
+		{tea.KeyPressMsg{Code: tea.KeyUp}, "miles"},
+		{tea.KeyPressMsg{Code: tea.KeyUp}, "miles"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "monk"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "c"},
+		{tea.KeyPressMsg{Code: tea.KeyDown}, "c"},
+	}
+	for i, s := range steps {
+		updated, _ = m.Update(s.key)
+		m = updated.(Model)
+		if m.search != s.want {
+			t.Fatalf("step %d: search = %q, want %q", i, m.search, s.want)
+		}
+	}
+	if got := recordIDs(m.filtered); got != "2,3" {
+		t.Errorf("recalled query should filter live, got %s", got)
+	}
+}
+
+func TestSearchHistoryEscRestoresDraft(t *testing.T) {
+	m := newTestModel(testRecords()).WithSearchHistory([]string{"miles"})
+	updated, _ := m.Update(keyMsg("/"))
+	updated, _ = updated.(Model).Update(keyMsg("c"))
+	updated, _ = updated.(Model).Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	m = updated.(Model)
+	if m.search != "miles" {
+		t.Fatalf("search = %q, want recalled miles", m.search)
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if !m.searching || m.search != "c" {
+		t.Errorf("esc while recalling: searching=%v search=%q, want the draft c", m.searching, m.search)
+	}
+	updated, _ = m.Update(keyMsg("esc"))
+	if updated.(Model).searching {
+		t.Error("a second esc should leave search mode")
+	}
+}
+
+func TestSuccessfulSearchJoinsHistory(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.searching = true
+	m.search = "miles"
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if got := m.SearchHistory(); !slices.Equal(got, []string{"miles"}) {
+		t.Errorf("history = %q, want [miles]", got)
+	}
+
+	m.store.(*mockStore).err = errors.New("db down")
+	m.searching = true
+	m.search = "monk"
+	updated, cmd = m.Update(keyMsg("enter"))
+	updated, _ = updated.(Model).Update(cmd())
+	if got := updated.(Model).SearchHistory(); !slices.Equal(got, []string{"miles"}) {
+		t.Errorf("failed search should not be remembered, history = %q", got)
+	}
+}
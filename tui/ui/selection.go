@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type recordsDeletedMsg struct {
+	deleted []string
+	err     error
+}
+
+func deleteRecords(ctx context.Context, store db.Store, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		deleted, err := store.DeleteBatch(ctx, ids)
+		return recordsDeletedMsg{deleted: deleted, err: err}
+	}
+}
+
+// toggleSelected marks or unmarks the record under the cursor. The map is
+// copied so earlier Model values keep their own selection.
+func (m *Model) toggleSelected() {
+	rec, ok := m.selectedRecord()
+	if !ok {
+		return
+	}
+	selected := maps.Clone(m.selected)
+	if selected == nil {
+		selected = map[string]bool{}
+	}
+	if selected[rec.RecordID] {
+		delete(selected, rec.RecordID)
+	} else {
+		selected[rec.RecordID] = true
+	}
+	m.selected = selected
+}
+
+// selectedIDs returns the marked record IDs in a stable order.
+func (m Model) selectedIDs() []string {
+	return slices.Sorted(maps.Keys(m.selected))
+}
+
+// pruneSelection drops marks for records that are no longer loaded, so a
+// reload keeps the selection without counting deleted rows.
+func (m *Model) pruneSelection() {
+	if len(m.selected) == 0 {
+		return
+	}
+	kept := map[string]bool{}
+	for _, r := range m.records {
+		if m.selected[r.RecordID] {
+			kept[r.RecordID] = true
+		}
+	}
+	m.selected = kept
+}
+
+// selectionWidth is the gutter for the ✓ column, shown only while
+// something is marked.
+func (m Model) selectionWidth() int {
+	if len(m.selected) == 0 {
+		return 0
+	}
+	return 2
+}
+
+// selectionCell renders the mark for id, or the header gutter when id is "".
+func (m Model) selectionCell(id string) string {
+	if m.selectionWidth() == 0 {
+		return ""
+	}
+	if id != "" && m.selected[id] {
+		return "✓ "
+	}
+	return "  "
+}
+
+func (m Model) bulkDeletePrompt() string {
+	n := len(m.selected)
+	if n == 1 {
+		return "  Delete 1 selected record? y/n"
+	}
+	return fmt.Sprintf("  Delete %d selected records? y/n", n)
+}
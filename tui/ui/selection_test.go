@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSpaceMarksRows(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg(" "))
+	m = updated.(Model)
+	m.cursor = 2
+	updated, _ = m.Update(keyMsg(" "))
+	m = updated.(Model)
+
+	if got := strings.Join(m.selectedIDs(), ","); got != "1,3" {
+		t.Fatalf("selected = %s, want 1,3", got)
+	}
+	view := m.renderList()
+	if strings.Count(view, "✓") != 2 || !strings.Contains(view, "2 selected") {
+		t.Errorf("list should mark two rows and show the count:\n%s", view)
+	}
+
+	updated, _ = m.Update(keyMsg(" "))
+	if got := strings.Join(updated.(Model).selectedIDs(), ","); got != "1" {
+		t.Errorf("space again should unmark, selected = %s", got)
+	}
+}
+
+func TestEscClearsSelection(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg(" "))
+	updated, _ = updated.(Model).Update(keyMsg("d"))
+	m = updated.(Model)
+	if !m.deleteConfirm {
+		t.Fatal("d should ask for confirmation")
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.deleteConfirm || len(m.selected) != 1 {
+		t.Fatal("first esc should only cancel the confirmation")
+	}
+	updated, _ = m.Update(keyMsg("esc"))
+	if len(updated.(Model).selected) != 0 {
+		t.Error("second esc should clear the selection")
+	}
+}
+
+func TestBulkDelete(t *testing.T) {
+	fastStatus(t)
+	m := newTestModel(testRecords())
+	updated, _ := m.Update(keyMsg(" "))
+	m = updated.(Model)
+	m.cursor = 1
+	updated, _ = m.Update(keyMsg(" "))
+	updated, _ = updated.(Model).Update(keyMsg("d"))
+	m = updated.(Model)
+	if !strings.Contains(m.renderList(), "Delete 2 selected records? y/n") {
+		t.Fatal("confirmation should show the selected count")
+	}
+
+	updated, cmd := m.Update(keyMsg("y"))
+	m = updated.(Model)
+	if !m.deleting || cmd == nil {
+		t.Fatal("y should start the bulk delete")
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.selected) != 0 || m.status != "Deleted 2 records." {
+		t.Errorf("selected = %v, success = %q", m.selected, m.status)
+	}
+	for _, msg := range runBatch(cmd) {
+		updated, _ = m.Update(msg)
+		m = updated.(Model)
+	}
+	if got := recordIDs(m.records); got != "3" {
+		t.Errorf("records after delete = %s, want 3", got)
+	}
+}
+
+func TestBulkDeleteError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.selected = map[string]bool{"1": true}
+	m.deleting = true
+	updated, _ := m.Update(recordsDeletedMsg{err: errors.New("delete failed")})
+	m = updated.(Model)
+	if m.deleting || m.deleteErr != "delete failed" || len(m.selected) != 1 {
+		t.Error("a failed bulk delete should keep the selection and show the error")
+	}
+}
+
+func TestSelectionSurvivesReload(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.selected = map[string]bool{"2": true, "gone": true}
+	updated, cmd := m.Update(keyMsg("r"))
+	updated, _ = updated.(Model).Update(cmd())
+	if got := strings.Join(updated.(Model).selectedIDs(), ","); got != "2" {
+		t.Errorf("selected after reload = %s, want 2", got)
+	}
+}
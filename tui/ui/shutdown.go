@@ -0,0 +1,10 @@
+package ui
+
+import tea "charm.land/bubbletea/v2"
+
+// quit ends the program, cancelling the database calls and image
+// downloads still in flight so they don't outlive it.
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	m.cancel()
+	return m, tea.Quit
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"my-record-collection-tui/db"
+)
+
+// hangingStore blocks List until its context is cancelled.
+type hangingStore struct {
+	mockStore
+}
+
+func (s *hangingStore) List(ctx context.Context) ([]db.Record, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestQuitCancelsOutstandingQueries(t *testing.T) {
+	for _, key := range []string{"q", "ctrl+c"} {
+		m := newTestModel(testRecords()).WithQueryTimeout(time.Minute)
+		m.attachStore(&hangingStore{})
+		cmd := m.fetchRecords(false)
+
+		done := make(chan recordsLoadedMsg, 1)
+		go func() { done <- cmd().(recordsLoadedMsg) }()
+
+		m.Update(keyMsg(key))
+		select {
+		case msg := <-done:
+			if !errors.Is(msg.err, context.Canceled) {
+				t.Errorf("%s: load err = %v, want context.Canceled", key, msg.err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: quitting left the query running", key)
+		}
+	}
+}
+
+func TestQuitFromLoadingScreen(t *testing.T) {
+	m := newTestModel(nil)
+	m.loading = true
+	_, cmd := m.Update(keyMsg("ctrl+c"))
+	if cmd == nil {
+		t.Fatal("ctrl+c while loading should quit")
+	}
+	if m.ctx.Err() == nil {
+		t.Error("ctrl+c while loading should cancel the model's context")
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// skeletonRows is how many placeholder rows stand in for the list while
+// it loads, fewer when the terminal is shorter.
+const skeletonRows = 8
+
+// skeletonFill is how much of its cell each placeholder bar covers, in
+// percent, cycled by row and column so the bars don't form a grid.
+var skeletonFill = []int{85, 55, 70, 40, 90, 60}
+
+// skeletonBar is a dim bar for a w-wide cell, padded to the full width.
+func skeletonBar(row, col, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	n := max(1, w*skeletonFill[(row*2+col)%len(skeletonFill)]/100)
+	return strings.Repeat("▄", n) + strings.Repeat(" ", w-n)
+}
+
+// renderListHeader is the column header row; the header's padding makes
+// it a little wider than the rows, so it's clipped rather than left to
+// wrap on a narrow terminal.
+func (m Model) renderListHeader(colW []int) string {
+	header := m.thumbnailCell(nil) + m.styles.header.Render(
+		m.selectionCell("")+m.lineNumberCell(-1)+m.listHeader(colW))
+	return ansi.Truncate(header, m.width, "")
+}
+
+// renderSkeleton lays out the header and placeholder rows the loaded list
+// will fill, so the screen doesn't jump when the records arrive.
+func (m Model) renderSkeleton() string {
+	colW := m.columnWidths()
+	var b strings.Builder
+	b.WriteString(m.renderListHeader(colW))
+	b.WriteString("\n")
+	gutter := m.thumbnailCell(nil) + m.selectionCell("") + strings.Repeat(" ", m.lineNumberWidth())
+	for i := range min(skeletonRows, m.listVisibleRows()) {
+		cells := make([]string, len(colW))
+		for j, w := range colW {
+			cells[j] = skeletonBar(i, j, w)
+		}
+		b.WriteString(gutter + m.styles.skeleton.Render(strings.Join(cells, " ")))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
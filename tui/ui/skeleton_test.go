@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSkeletonBar(t *testing.T) {
+	for _, w := range []int{1, 5, 30} {
+		for row := range 3 {
+			bar := skeletonBar(row, 0, w)
+			if got := ansi.StringWidth(bar); got != w {
+				t.Errorf("skeletonBar(%d, 0, %d) is %d wide", row, w, got)
+			}
+			if !strings.Contains(bar, "▄") {
+				t.Errorf("skeletonBar(%d, 0, %d) = %q, want at least one block", row, w, bar)
+			}
+		}
+	}
+	if skeletonBar(0, 0, 0) != "" {
+		t.Error("a zero-width cell should get no bar")
+	}
+}
+
+func TestListLoadingSkeleton(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		rows   int
+	}{
+		{"tall", 40, skeletonRows},
+		{"short", 10, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(nil)
+			m.height = tt.height
+			m.loading = true
+			lines := strings.Split(ansi.Strip(m.renderList()), "\n")
+
+			var bars []string
+			header := false
+			for _, l := range lines {
+				switch {
+				case strings.Contains(l, "Artist") && strings.Contains(l, "Album"):
+					header = true
+				case strings.Contains(l, "▄"):
+					bars = append(bars, l)
+				}
+			}
+			if !header {
+				t.Error("the skeleton should keep the column header")
+			}
+			if len(bars) != tt.rows {
+				t.Errorf("placeholder rows = %d, want %d", len(bars), tt.rows)
+			}
+			want := ansi.StringWidth(m.listRow(testRecords()[0], m.columnWidths()))
+			for _, b := range bars {
+				if got := ansi.StringWidth(b); got != want {
+					t.Errorf("placeholder row is %d wide, want %d like a real row", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestListLoadingBeforeSize(t *testing.T) {
+	m := newTestModel(nil)
+	m.width, m.height = 0, 0
+	m.loading = true
+	if out := m.renderList(); strings.Contains(out, "▄") || !strings.Contains(out, "Loading records...") {
+		t.Errorf("without a size the list should fall back to the plain message:\n%s", out)
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"my-record-collection-tui/db"
+)
+
+type sortColumn int
+
+const (
+	sortArtist sortColumn = iota
+	sortAlbum
+	sortYear
+	sortLabel
+	sortRating
+	sortPlays
+	sortColumnCount
+)
+
+func (c sortColumn) String() string {
+	switch c {
+	case sortAlbum:
+		return "album"
+	case sortYear:
+		return "year"
+	case sortLabel:
+		return "label"
+	case sortRating:
+		return "rating"
+	case sortPlays:
+		return "plays"
+	default:
+		return "artist"
+	}
+}
+
+// parseSortColumn maps a config value like "year" to its column.
+func parseSortColumn(name string) (sortColumn, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for c := range sortColumnCount {
+		if c.String() == name {
+			return c, true
+		}
+	}
+	return sortArtist, false
+}
+
+// sortKey folds case and strips accents so "Björk" sorts next to "bjork".
+func sortKey(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// sortArticles are dropped from the front of artist names when sorting
+// with ignoreArticles.
+var sortArticles = []string{"the ", "a ", "an "}
+
+// artistSortKey is sortKey, optionally without a leading article so
+// "The Rolling Stones" files under R. Only the sort key changes; the name
+// is still shown and searched in full.
+func artistSortKey(name string, ignoreArticles bool) string {
+	k := sortKey(strings.TrimSpace(name))
+	if !ignoreArticles {
+		return k
+	}
+	for _, article := range sortArticles {
+		if rest, ok := strings.CutPrefix(k, article); ok && strings.TrimSpace(rest) != "" {
+			return strings.TrimSpace(rest)
+		}
+	}
+	return k
+}
+
+// sortRecords orders recs in place by col. Missing years, labels and
+// ratings go last in either direction; ties fall back to artist then album.
+func sortRecords(recs []db.Record, col sortColumn, desc, ignoreArticles bool) {
+	slices.SortStableFunc(recs, func(a, b db.Record) int {
+		if c := compareMissing(a, b, col); c != 0 {
+			return c
+		}
+		c := compareColumn(a, b, col, ignoreArticles)
+		if desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Or(
+			strings.Compare(artistSortKey(a.ArtistName, ignoreArticles), artistSortKey(b.ArtistName, ignoreArticles)),
+			strings.Compare(sortKey(a.AlbumTitle), sortKey(b.AlbumTitle)),
+		)
+	})
+}
+
+func compareMissing(a, b db.Record, col sortColumn) int {
+	var aMissing, bMissing bool
+	switch col {
+	case sortYear:
+		aMissing, bMissing = a.YearReleased == nil, b.YearReleased == nil
+	case sortLabel:
+		aMissing, bMissing = labelMissing(a), labelMissing(b)
+	case sortRating:
+		aMissing, bMissing = a.Rating == nil, b.Rating == nil
+	default:
+		return 0
+	}
+	switch {
+	case aMissing == bMissing:
+		return 0
+	case aMissing:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func labelMissing(r db.Record) bool {
+	return r.LabelName == nil || strings.TrimSpace(*r.LabelName) == ""
+}
+
+func compareColumn(a, b db.Record, col sortColumn, ignoreArticles bool) int {
+	switch col {
+	case sortAlbum:
+		return strings.Compare(sortKey(a.AlbumTitle), sortKey(b.AlbumTitle))
+	case sortYear:
+		if a.YearReleased == nil || b.YearReleased == nil {
+			return 0
+		}
+		return cmp.Compare(*a.YearReleased, *b.YearReleased)
+	case sortLabel:
+		if labelMissing(a) || labelMissing(b) {
+			return 0
+		}
+		return strings.Compare(sortKey(*a.LabelName), sortKey(*b.LabelName))
+	case sortRating:
+		if a.Rating == nil || b.Rating == nil {
+			return 0
+		}
+		return cmp.Compare(*a.Rating, *b.Rating)
+	case sortPlays:
+		return cmp.Compare(a.PlayCount, b.PlayCount)
+	default:
+		return strings.Compare(artistSortKey(a.ArtistName, ignoreArticles), artistSortKey(b.ArtistName, ignoreArticles))
+	}
+}
+
+// headerLabel adds the ▲/▼ indicator when col is the active sort column.
+func (m Model) headerLabel(label string, col sortColumn) string {
+	if col != m.sortCol {
+		return label
+	}
+	if m.sortDesc {
+		return label + " ▼"
+	}
+	return label + " ▲"
+}
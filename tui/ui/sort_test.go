@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func sortTestRecords() []db.Record {
+	y := func(v int) *int { return &v }
+	l := func(v string) *string { return &v }
+	return []db.Record{
+		{RecordID: "1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", YearReleased: y(1959), LabelName: l("Columbia")},
+		{RecordID: "2", ArtistName: "john Coltrane", AlbumTitle: "A Love Supreme", YearReleased: y(1965), LabelName: l("Impulse!")},
+		{RecordID: "3", ArtistName: "Bill Evans", AlbumTitle: "Undated"},
+		{RecordID: "4", ArtistName: "Art Blakey", AlbumTitle: "Moanin'", YearReleased: y(1959), LabelName: l("Blue Note")},
+	}
+}
+
+func recordIDs(recs []db.Record) string {
+	ids := make([]string, len(recs))
+	for i, r := range recs {
+		ids[i] = r.RecordID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSortRecords(t *testing.T) {
+	tests := []struct {
+		col  sortColumn
+		desc bool
+		want string
+	}{
+		{sortArtist, false, "4,3,2,1"},
+		{sortArtist, true, "1,2,3,4"},
+		{sortAlbum, false, "2,1,4,3"},
+		{sortAlbum, true, "3,4,1,2"},
+		{sortYear, false, "4,1,2,3"},
+		{sortYear, true, "2,4,1,3"},
+		{sortLabel, false, "4,1,2,3"},
+		{sortLabel, true, "2,1,4,3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.col.String(), func(t *testing.T) {
+			recs := sortTestRecords()
+			sortRecords(recs, tt.col, tt.desc, false)
+			if got := recordIDs(recs); got != tt.want {
+				t.Errorf("sort %v desc=%v = %s, want %s", tt.col, tt.desc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArtistSortKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		ignoreArticles bool
+		want           string
+	}{
+		{"The Beatles", false, "the beatles"},
+		{"The Beatles", true, "beatles"},
+		{"the beatles", true, "beatles"},
+		{"Björk", false, "bjork"},
+		{"Theo Parrish", true, "theo parrish"},
+		{"The", true, "the"},
+		{"  Étienne Daho", false, "etienne daho"},
+		{"The Rolling Stones", true, "rolling stones"},
+		{"A Tribe Called Quest", true, "tribe called quest"},
+		{"An Albatross", true, "albatross"},
+		{"A Tribe Called Quest", false, "a tribe called quest"},
+		{"Alabama Shakes", true, "alabama shakes"},
+		{"Anthrax", true, "anthrax"},
+		{"A", true, "a"},
+	}
+	for _, tt := range tests {
+		if got := artistSortKey(tt.name, tt.ignoreArticles); got != tt.want {
+			t.Errorf("artistSortKey(%q, %v) = %q, want %q", tt.name, tt.ignoreArticles, got, tt.want)
+		}
+	}
+}
+
+func TestSortRecordsFoldsCaseAndAccents(t *testing.T) {
+	recs := []db.Record{
+		{RecordID: "1", ArtistName: "The Beatles", AlbumTitle: "Abbey Road"},
+		{RecordID: "2", ArtistName: "Björk", AlbumTitle: "Post"},
+		{RecordID: "3", ArtistName: "bjork", AlbumTitle: "Debut"},
+		{RecordID: "4", ArtistName: "Can", AlbumTitle: "Tago Mago"},
+		{RecordID: "5", ArtistName: "the beatles", AlbumTitle: "Revolver"},
+	}
+	sortRecords(recs, sortArtist, false, false)
+	if got := recordIDs(recs); got != "3,2,4,1,5" {
+		t.Errorf("artist order = %s, want 3,2,4,1,5", got)
+	}
+	sortRecords(recs, sortArtist, false, true)
+	if got := recordIDs(recs); got != "1,5,3,2,4" {
+		t.Errorf("artist order ignoring The = %s, want 1,5,3,2,4", got)
+	}
+}
+
+func TestSortKeysCycleAndShowArrow(t *testing.T) {
+	m := newTestModel(sortTestRecords())
+	if !strings.Contains(m.renderList(), "Artist ▲") {
+		t.Error("default header should mark artist ascending")
+	}
+
+	updated, _ := m.Update(keyMsg("o"))
+	updated, _ = updated.(Model).Update(keyMsg("o"))
+	m = updated.(Model)
+	if m.sortCol != sortYear {
+		t.Fatalf("sortCol after two o = %v, want year", m.sortCol)
+	}
+	updated, _ = m.Update(keyMsg("O"))
+	m = updated.(Model)
+	if !strings.Contains(m.renderList(), "Year ▼") {
+		t.Error("header should mark year descending")
+	}
+	if got := recordIDs(m.filtered); got != "2,4,1,3" {
+		t.Errorf("filtered = %s, want 2,4,1,3", got)
+	}
+
+	for range 4 {
+		updated, _ = m.Update(keyMsg("o"))
+		m = updated.(Model)
+	}
+	if m.sortCol != sortArtist {
+		t.Errorf("sortCol should wrap back to artist, got %v", m.sortCol)
+	}
+}
+
+func TestSortPersistsAcrossReload(t *testing.T) {
+	m := newTestModel(sortTestRecords())
+	m.sortCol = sortYear
+	m.sortDesc = true
+
+	updated, cmd := m.Update(keyMsg("r"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if got := recordIDs(m.filtered); got != "2,4,1,3" {
+		t.Errorf("filtered after reload = %s, want year-descending 2,4,1,3", got)
+	}
+}
+
+func TestWithSortColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		want sortColumn
+	}{
+		{"year", sortYear},
+		{" Label ", sortLabel},
+		{"album", sortAlbum},
+		{"", sortArtist},
+		{"rating", sortRating},
+		{"stars", sortArtist},
+	}
+	for _, tt := range tests {
+		if got := newTestModel(nil).WithSortColumn(tt.name).sortCol; got != tt.want {
+			t.Errorf("WithSortColumn(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// spinnerTickMsg advances the art-loading spinner. id ties the tick to one
+// load so a stale chain from an earlier load dies out instead of doubling
+// the frame rate.
+type spinnerTickMsg struct {
+	id int
+}
+
+func spinnerTick(id int) tea.Cmd {
+	return tea.Tick(spinnerInterval, func(time.Time) tea.Msg {
+		return spinnerTickMsg{id: id}
+	})
+}
+
+// startSpinner begins a new tick chain for the current art load.
+func (m Model) startSpinner() (Model, tea.Cmd) {
+	m.spinnerID++
+	m.spinnerFrame = 0
+	return m, spinnerTick(m.spinnerID)
+}
+
+func (m Model) handleSpinnerTick(msg spinnerTickMsg) (tea.Model, tea.Cmd) {
+	if !m.artLoading || msg.id != m.spinnerID {
+		return m, nil
+	}
+	m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
+	return m, spinnerTick(m.spinnerID)
+}
+
+func (m Model) loadingPlaceholder(width, height int) string {
+	return renderPlaceholderLabel(width, height, spinnerFrames[m.spinnerFrame]+" Loading…")
+}
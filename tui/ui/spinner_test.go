@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpinnerAdvancesWhileLoading(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.artLoading = true
+	m, cmd := m.startSpinner()
+	if cmd == nil {
+		t.Fatal("startSpinner should schedule a tick")
+	}
+
+	updated, next := m.Update(spinnerTickMsg{id: m.spinnerID})
+	m = updated.(Model)
+	if m.spinnerFrame != 1 {
+		t.Errorf("spinnerFrame = %d, want 1", m.spinnerFrame)
+	}
+	if next == nil {
+		t.Error("tick while loading should schedule the next tick")
+	}
+	if !strings.Contains(m.renderDetail(), spinnerFrames[1]+" Loading…") {
+		t.Error("detail view should show the current spinner frame")
+	}
+}
+
+func TestSpinnerStopsWhenImageArrives(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.artLoading = true
+	m, _ = m.startSpinner()
+
+	updated, _ := m.Update(imageLoadedMsg{url: "http://example.com/a.jpg", render: "art"})
+	m = updated.(Model)
+	updated, cmd := m.Update(spinnerTickMsg{id: m.spinnerID})
+	if cmd != nil {
+		t.Error("tick after the image loaded should not reschedule")
+	}
+	if updated.(Model).spinnerFrame != 0 {
+		t.Error("frame should not advance once loading is done")
+	}
+}
+
+func TestSpinnerIgnoresStaleTicks(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.artLoading = true
+	m, _ = m.startSpinner()
+	stale := m.spinnerID
+	m, _ = m.startSpinner()
+
+	_, cmd := m.Update(spinnerTickMsg{id: stale})
+	if cmd != nil {
+		t.Error("tick from an earlier load should end its chain")
+	}
+}
+
+func TestEnterDetailStartsSpinner(t *testing.T) {
+	m := newTestModel(testRecords())
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if !m.artLoading || m.spinnerID == 0 || cmd == nil {
+		t.Error("opening an uncached record should start loading with a spinner")
+	}
+}
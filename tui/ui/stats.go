@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"my-record-collection-tui/db"
+)
+
+// statsBarWidth is the width of the longest bar in a stats section.
+const statsBarWidth = 30
+
+type statsLoadedMsg struct {
+	stats db.Stats
+	err   error
+}
+
+func loadStats(ctx context.Context, store db.Store) tea.Cmd {
+	return func() tea.Msg {
+		stats, err := store.Stats(ctx)
+		return statsLoadedMsg{stats: stats, err: err}
+	}
+}
+
+// statsBar scales n against largest to at most statsBarWidth cells, never
+// less than one cell for a non-zero count.
+func statsBar(n, largest int) string {
+	if n <= 0 || largest <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", max(1, n*statsBarWidth/largest))
+}
+
+func (m Model) statsSection(heading string, counts []db.Count) []string {
+	lines := []string{m.styles.label.Render("  " + heading)}
+	if len(counts) == 0 {
+		return append(lines, m.styles.normalRow.Render("    —"), "")
+	}
+	largest, nameWidth := 0, 0
+	for _, c := range counts {
+		largest = max(largest, c.N)
+		nameWidth = max(nameWidth, lipgloss.Width(c.Name))
+	}
+	nameWidth = min(nameWidth, 24)
+	for _, c := range counts {
+		lines = append(lines, fmt.Sprintf("    %s %s %s",
+			m.styles.value.Render(truncPad(c.Name, nameWidth)),
+			m.styles.synced.Render(statsBar(c.N, largest)),
+			m.styles.normalRow.Render(fmt.Sprint(c.N))))
+	}
+	return append(lines, "")
+}
+
+func (m Model) statsLines() []string {
+	if m.statsLoading {
+		return []string{"  Loading..."}
+	}
+	if m.statsErr != "" {
+		return []string{m.styles.error.Render("  " + m.statsErr)}
+	}
+	st := m.stats
+	lines := []string{
+		fmt.Sprintf("  %s %s", m.styles.label.Render("Total records:"), m.styles.value.Render(fmt.Sprint(st.Total))),
+		fmt.Sprintf("  %s %s %s", m.styles.label.Render("Synced:"),
+			m.styles.synced.Render(statsBar(st.Synced, st.Total)),
+			m.styles.value.Render(fmt.Sprintf("%.0f%% (%d)", st.SyncedPercent(), st.Synced))),
+		"",
+	}
+	lines = append(lines, m.statsSection("By decade", st.Decades)...)
+	lines = append(lines, m.statsSection(fmt.Sprintf("Top %d labels", db.StatsTopN), st.TopLabels)...)
+	lines = append(lines, m.statsSection(fmt.Sprintf("Top %d genres", db.StatsTopN), st.TopGenres)...)
+	return lines
+}
+
+func (m Model) handleStatsKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "q", "esc", "S":
+		m.view = listView
+		m.statsOffset = 0
+	case "up":
+		if m.statsOffset > 0 {
+			m.statsOffset--
+		}
+	case "down":
+		if m.statsOffset < len(m.statsLines())-m.listVisibleRows() {
+			m.statsOffset++
+		}
+	case "r":
+		m.statsLoading = true
+		return m, loadStats(m.ctx, m.store)
+	}
+	return m, nil
+}
+
+func (m Model) renderStats() string {
+	var b strings.Builder
+	title := m.styles.title.Render("♫ Stats")
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", m.styles.statusBar.Render("collection overview")))
+	b.WriteString("\n\n")
+
+	lines := m.statsLines()
+	visible := m.listVisibleRows()
+	start := min(m.statsOffset, max(0, len(lines)-1))
+	end := min(start+visible, len(lines))
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.helpLine(statsKeys))
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestStatsBar(t *testing.T) {
+	tests := []struct {
+		n, largest int
+		want       int
+	}{
+		{0, 10, 0},
+		{10, 10, statsBarWidth},
+		{5, 10, statsBarWidth / 2},
+		{1, 1000, 1},
+		{3, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := strings.Count(statsBar(tt.n, tt.largest), "█"); got != tt.want {
+			t.Errorf("statsBar(%d, %d) width = %d, want %d", tt.n, tt.largest, got, tt.want)
+		}
+	}
+}
+
+func TestStatsViewLoadsAndRenders(t *testing.T) {
+	recs := testRecords()
+	recs[0].IsSyncedWithDiscogs = true
+	m := newTestModel(recs)
+
+	updated, cmd := m.Update(keyMsg("S"))
+	m = updated.(Model)
+	if m.view != statsView || !m.statsLoading || cmd == nil {
+		t.Fatal("S should open the stats view and load stats")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	body := m.View().Content
+	for _, want := range []string{"Total records:", "3", "33% (1)"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("stats view should contain %q:\n%s", want, body)
+		}
+	}
+
+	updated, _ = m.Update(statsLoadedMsg{stats: db.Stats{
+		Total:     3,
+		Decades:   []db.Count{{Name: "1950s", N: 2}, {Name: "Unknown", N: 1}},
+		TopLabels: []db.Count{{Name: "Blue Note", N: 2}},
+	}})
+	body = updated.(Model).View().Content
+	for _, want := range []string{"By decade", "1950s", "Unknown", "Blue Note", "Top 5 genres"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("stats view should contain %q:\n%s", want, body)
+		}
+	}
+
+	updated, _ = updated.(Model).Update(keyMsg("esc"))
+	if updated.(Model).view != listView {
+		t.Error("esc should return to the list")
+	}
+}
+
+func TestStatsViewError(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = statsView
+	updated, _ := m.Update(statsLoadedMsg{err: errors.New("query failed")})
+	if !strings.Contains(updated.(Model).View().Content, "query failed") {
+		t.Error("stats view should show the load error")
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// statusDuration is how long a status message stays up before clearing.
+var statusDuration = 4 * time.Second
+
+// statusMsg sets the status line from a command, e.g. when a background
+// job finishes.
+type statusMsg struct {
+	text string
+}
+
+// statusClearMsg clears the status line if it still shows the message set
+// at the given time, so an older tick can't clear a newer message.
+type statusClearMsg struct {
+	at time.Time
+}
+
+// setStatus shows text in the status line and returns the tick that
+// clears it.
+func (m *Model) setStatus(text string) tea.Cmd {
+	m.status = text
+	m.statusAt = time.Now()
+	at := m.statusAt
+	return tea.Tick(statusDuration, func(time.Time) tea.Msg {
+		return statusClearMsg{at: at}
+	})
+}
+
+func (m Model) handleStatusClear(msg statusClearMsg) (tea.Model, tea.Cmd) {
+	if msg.at.Equal(m.statusAt) {
+		m.status = ""
+	}
+	return m, nil
+}
+
+func (m Model) renderStatus() string {
+	if m.status == "" {
+		return ""
+	}
+	return m.styles.success.Render("  "+m.status) + "\n"
+}
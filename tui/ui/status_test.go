@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// fastStatus shortens how long status messages stay up for the rest of
+// the test.
+func fastStatus(t *testing.T) {
+	t.Helper()
+	saved := statusDuration
+	statusDuration = time.Millisecond
+	t.Cleanup(func() { statusDuration = saved })
+}
+
+func TestStatusMsgAutoClears(t *testing.T) {
+	fastStatus(t)
+	m := newTestModel(testRecords())
+
+	updated, cmd := m.Update(statusMsg{text: "Exported to foo.csv"})
+	m = updated.(Model)
+	if !strings.Contains(m.renderList(), "Exported to foo.csv") {
+		t.Fatal("status should be rendered in the list")
+	}
+	if cmd == nil {
+		t.Fatal("status should schedule its own clear")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.status != "" {
+		t.Errorf("status = %q after its tick, want cleared", m.status)
+	}
+}
+
+func TestStatusClearIgnoresStaleTick(t *testing.T) {
+	fastStatus(t)
+	m := newTestModel(testRecords())
+
+	updated, first := m.Update(statusMsg{text: "first"})
+	m = updated.(Model)
+	stale := first()
+	// Make sure the second message gets a different timestamp.
+	time.Sleep(time.Millisecond)
+	updated, _ = m.Update(statusMsg{text: "second"})
+	m = updated.(Model)
+
+	updated, _ = m.Update(stale)
+	m = updated.(Model)
+	if m.status != "second" {
+		t.Errorf("status = %q, want the newer message kept", m.status)
+	}
+}
+
+func TestStatusClearedByKeypress(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.status = "Record updated."
+
+	updated, _ := m.Update(keyMsg("down"))
+	if got := updated.(Model).status; got != "" {
+		t.Errorf("status = %q after a key, want cleared", got)
+	}
+}
@@ -1,86 +1,393 @@
 package ui
 
 import (
+	"image/color"
+	"os"
+	"strings"
+
 	lipgloss "charm.land/lipgloss/v2"
 )
 
-// Catppuccin Mocha palette
-// https://github.com/catppuccin/catppuccin
+// palette slots are named after Catppuccin's roles; other themes fill them
+// with their closest equivalents.
+type palette struct {
+	base, surface0, surface1, overlay0, subtext0, text color.Color
+	lavender, mauve, red, green                        color.Color
+}
+
+// theme is a named set of palettes, one per terminal color depth.
+type theme struct {
+	name      string
+	trueColor palette
+	ansi256   palette
+	ansi16    palette
+}
+
+func (t theme) palette(d colorDepth) palette {
+	switch d {
+	case depth256:
+		return t.ansi256
+	case depth16:
+		return t.ansi16
+	default:
+		return t.trueColor
+	}
+}
+
+// Basic ANSI colors; the terminal's own scheme decides the exact shades.
 var (
-	base     = lipgloss.Color("#1e1e2e")
-	surface1 = lipgloss.Color("#45475a")
-	overlay0 = lipgloss.Color("#6c7086")
-	subtext0 = lipgloss.Color("#a6adc8")
-	text     = lipgloss.Color("#cdd6f4")
-	lavender = lipgloss.Color("#b4befe")
-	mauve    = lipgloss.Color("#cba6f7")
-	red      = lipgloss.Color("#f38ba8")
-	green    = lipgloss.Color("#a6e3a1")
-
-	titleStyle = lipgloss.NewStyle().
+	darkANSI16 = palette{
+		base:     lipgloss.Color("0"),
+		surface0: lipgloss.Color("0"),
+		surface1: lipgloss.Color("8"),
+		overlay0: lipgloss.Color("7"),
+		subtext0: lipgloss.Color("7"),
+		text:     lipgloss.Color("15"),
+		lavender: lipgloss.Color("12"),
+		mauve:    lipgloss.Color("13"),
+		red:      lipgloss.Color("9"),
+		green:    lipgloss.Color("10"),
+	}
+	lightANSI16 = palette{
+		base:     lipgloss.Color("15"),
+		surface0: lipgloss.Color("15"),
+		surface1: lipgloss.Color("7"),
+		overlay0: lipgloss.Color("8"),
+		subtext0: lipgloss.Color("8"),
+		text:     lipgloss.Color("0"),
+		lavender: lipgloss.Color("4"),
+		mauve:    lipgloss.Color("5"),
+		red:      lipgloss.Color("1"),
+		green:    lipgloss.Color("2"),
+	}
+)
+
+// Catppuccin Mocha and Latte
+// https://github.com/catppuccin/catppuccin
+var mochaTheme = theme{
+	name: "mocha",
+	trueColor: palette{
+		base:     lipgloss.Color("#1e1e2e"),
+		surface0: lipgloss.Color("#313244"),
+		surface1: lipgloss.Color("#45475a"),
+		overlay0: lipgloss.Color("#6c7086"),
+		subtext0: lipgloss.Color("#a6adc8"),
+		text:     lipgloss.Color("#cdd6f4"),
+		lavender: lipgloss.Color("#b4befe"),
+		mauve:    lipgloss.Color("#cba6f7"),
+		red:      lipgloss.Color("#f38ba8"),
+		green:    lipgloss.Color("#a6e3a1"),
+	},
+	// Nearest xterm-256 entries, nudged where the automatic match lost contrast.
+	ansi256: palette{
+		base:     lipgloss.Color("234"),
+		surface0: lipgloss.Color("236"),
+		surface1: lipgloss.Color("239"),
+		overlay0: lipgloss.Color("243"),
+		subtext0: lipgloss.Color("146"),
+		text:     lipgloss.Color("189"),
+		lavender: lipgloss.Color("147"),
+		mauve:    lipgloss.Color("183"),
+		red:      lipgloss.Color("211"),
+		green:    lipgloss.Color("151"),
+	},
+	ansi16: darkANSI16,
+}
+
+var latteTheme = theme{
+	name: "latte",
+	trueColor: palette{
+		base:     lipgloss.Color("#eff1f5"),
+		surface0: lipgloss.Color("#ccd0da"),
+		surface1: lipgloss.Color("#bcc0cc"),
+		overlay0: lipgloss.Color("#9ca0b0"),
+		subtext0: lipgloss.Color("#6c6f85"),
+		text:     lipgloss.Color("#4c4f69"),
+		lavender: lipgloss.Color("#7287fd"),
+		mauve:    lipgloss.Color("#8839ef"),
+		red:      lipgloss.Color("#d20f39"),
+		green:    lipgloss.Color("#40a02b"),
+	},
+	ansi256: palette{
+		base:     lipgloss.Color("255"),
+		surface0: lipgloss.Color("253"),
+		surface1: lipgloss.Color("250"),
+		overlay0: lipgloss.Color("246"),
+		subtext0: lipgloss.Color("60"),
+		text:     lipgloss.Color("238"),
+		lavender: lipgloss.Color("69"),
+		mauve:    lipgloss.Color("93"),
+		red:      lipgloss.Color("161"),
+		green:    lipgloss.Color("70"),
+	},
+	ansi16: lightANSI16,
+}
+
+// Gruvbox dark
+// https://github.com/morhetz/gruvbox
+var gruvboxTheme = theme{
+	name: "gruvbox",
+	trueColor: palette{
+		base:     lipgloss.Color("#282828"),
+		surface0: lipgloss.Color("#3c3836"),
+		surface1: lipgloss.Color("#504945"),
+		overlay0: lipgloss.Color("#928374"),
+		subtext0: lipgloss.Color("#bdae93"),
+		text:     lipgloss.Color("#ebdbb2"),
+		lavender: lipgloss.Color("#83a598"),
+		mauve:    lipgloss.Color("#d3869b"),
+		red:      lipgloss.Color("#fb4934"),
+		green:    lipgloss.Color("#b8bb26"),
+	},
+	ansi256: palette{
+		base:     lipgloss.Color("235"),
+		surface0: lipgloss.Color("237"),
+		surface1: lipgloss.Color("239"),
+		overlay0: lipgloss.Color("245"),
+		subtext0: lipgloss.Color("144"),
+		text:     lipgloss.Color("223"),
+		lavender: lipgloss.Color("109"),
+		mauve:    lipgloss.Color("175"),
+		red:      lipgloss.Color("167"),
+		green:    lipgloss.Color("142"),
+	},
+	ansi16: darkANSI16,
+}
+
+// Nord
+// https://www.nordtheme.com
+var nordTheme = theme{
+	name: "nord",
+	trueColor: palette{
+		base:     lipgloss.Color("#2e3440"),
+		surface0: lipgloss.Color("#3b4252"),
+		surface1: lipgloss.Color("#434c5e"),
+		overlay0: lipgloss.Color("#616e88"),
+		subtext0: lipgloss.Color("#d8dee9"),
+		text:     lipgloss.Color("#eceff4"),
+		lavender: lipgloss.Color("#81a1c1"),
+		mauve:    lipgloss.Color("#b48ead"),
+		red:      lipgloss.Color("#bf616a"),
+		green:    lipgloss.Color("#a3be8c"),
+	},
+	ansi256: palette{
+		base:     lipgloss.Color("236"),
+		surface0: lipgloss.Color("237"),
+		surface1: lipgloss.Color("238"),
+		overlay0: lipgloss.Color("60"),
+		subtext0: lipgloss.Color("253"),
+		text:     lipgloss.Color("255"),
+		lavender: lipgloss.Color("110"),
+		mauve:    lipgloss.Color("139"),
+		red:      lipgloss.Color("131"),
+		green:    lipgloss.Color("144"),
+	},
+	ansi16: darkANSI16,
+}
+
+// themes is the cycle order for the theme key; the first entry is the
+// default.
+var themes = []theme{mochaTheme, latteTheme, gruvboxTheme, nordTheme}
+
+// themeIndex returns the position of name in themes, falling back to
+// Mocha for unknown names.
+func themeIndex(name string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, t := range themes {
+		if t.name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+type colorDepth int
+
+const (
+	depthTrueColor colorDepth = iota
+	depth256
+	depth16
+)
+
+func (d colorDepth) String() string {
+	switch d {
+	case depth256:
+		return "256"
+	case depth16:
+		return "16"
+	default:
+		return "truecolor"
+	}
+}
+
+// detectColorDepth reads COLORTERM and TERM. Anything that doesn't
+// advertise 24-bit or 256 colors gets the 16-color palette.
+func detectColorDepth() colorDepth {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return depthTrueColor
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.HasSuffix(term, "-direct"), strings.Contains(term, "kitty"), strings.Contains(term, "ghostty"):
+		return depthTrueColor
+	case strings.Contains(term, "256color"):
+		return depth256
+	}
+	return depth16
+}
+
+type styles struct {
+	title       lipgloss.Style
+	statusBar   lipgloss.Style
+	header      lipgloss.Style
+	selectedRow lipgloss.Style
+	normalRow   lipgloss.Style
+	stripedRow  lipgloss.Style
+	detailBox   lipgloss.Style
+	label       lipgloss.Style
+	focusLabel  lipgloss.Style
+	value       lipgloss.Style
+	synced      lipgloss.Style
+	notSynced   lipgloss.Style
+	search      lipgloss.Style
+	help        lipgloss.Style
+	helpKey     lipgloss.Style
+	helpDesc    lipgloss.Style
+	helpSep     lipgloss.Style
+	error       lipgloss.Style
+	success     lipgloss.Style
+	match       lipgloss.Style
+	scrollThumb lipgloss.Style
+	scrollTrack lipgloss.Style
+	skeleton    lipgloss.Style
+
+	indexCurrent   lipgloss.Style
+	indexAvailable lipgloss.Style
+	indexEmpty     lipgloss.Style
+}
+
+func newStyles(p palette) styles {
+	return styles{
+		title: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(mauve).
-			Padding(0, 1)
+			Foreground(p.mauve).
+			Padding(0, 1),
 
-	statusBarStyle = lipgloss.NewStyle().
-			Foreground(overlay0).
-			Padding(0, 1)
+		statusBar: lipgloss.NewStyle().
+			Foreground(p.overlay0).
+			Padding(0, 1),
+
+		header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(p.base).
+			Background(p.mauve).
+			Padding(0, 1),
 
-	headerStyle = lipgloss.NewStyle().
+		selectedRow: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(base).
-			Background(mauve).
-			Padding(0, 1)
+			Foreground(p.text).
+			Background(p.surface1),
 
-	selectedRowStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(text).
-				Background(surface1)
+		normalRow: lipgloss.NewStyle().
+			Foreground(p.subtext0),
 
-	normalRowStyle = lipgloss.NewStyle().
-			Foreground(subtext0)
+		stripedRow: stripedRowStyle(p),
 
-	detailBoxStyle = lipgloss.NewStyle().
+		detailBox: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lavender).
-			Padding(1, 2)
+			BorderForeground(p.lavender).
+			Padding(1, 2),
 
-	labelStyle = lipgloss.NewStyle().
+		label: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lavender).
-			Width(16)
+			Foreground(p.lavender).
+			Width(16),
 
-	valueStyle = lipgloss.NewStyle().
-			Foreground(text)
+		focusLabel: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(p.text).
+			Background(p.surface1).
+			Width(16),
+
+		value: lipgloss.NewStyle().
+			Foreground(p.text),
 
-	syncedStyle = lipgloss.NewStyle().
-			Foreground(green)
+		synced: lipgloss.NewStyle().
+			Foreground(p.green),
 
-	notSyncedStyle = lipgloss.NewStyle().
-			Foreground(red)
+		notSynced: lipgloss.NewStyle().
+			Foreground(p.red),
 
-	searchStyle = lipgloss.NewStyle().
+		search: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(mauve).
-			Padding(0, 1)
+			BorderForeground(p.mauve).
+			Padding(0, 1),
 
-	helpStyle = lipgloss.NewStyle().
-			Foreground(overlay0)
+		help: lipgloss.NewStyle().
+			Foreground(p.overlay0),
 
-	helpKeyStyle = lipgloss.NewStyle().
-			Foreground(overlay0)
+		helpKey: lipgloss.NewStyle().
+			Foreground(p.overlay0),
 
-	helpDescStyle = lipgloss.NewStyle().
-			Foreground(surface1)
+		helpDesc: lipgloss.NewStyle().
+			Foreground(p.surface1),
 
-	helpSepStyle = lipgloss.NewStyle().
-			Foreground(surface1)
+		helpSep: lipgloss.NewStyle().
+			Foreground(p.surface1),
 
-	errorStyle = lipgloss.NewStyle().
-			Foreground(red).
-			Bold(true)
+		error: lipgloss.NewStyle().
+			Foreground(p.red).
+			Bold(true),
 
-	successStyle = lipgloss.NewStyle().
-			Foreground(green).
-			Bold(true)
-)
+		success: lipgloss.NewStyle().
+			Foreground(p.green).
+			Bold(true),
+
+		match: lipgloss.NewStyle().
+			Foreground(p.mauve).
+			Underline(true),
+
+		scrollThumb: lipgloss.NewStyle().
+			Foreground(p.lavender),
+
+		scrollTrack: lipgloss.NewStyle().
+			Foreground(p.surface1),
+
+		skeleton: lipgloss.NewStyle().
+			Foreground(p.surface1),
+
+		indexCurrent: lipgloss.NewStyle().
+			Foreground(p.mauve).
+			Bold(true),
+
+		indexAvailable: lipgloss.NewStyle().
+			Foreground(p.subtext0),
+
+		indexEmpty: lipgloss.NewStyle().
+			Foreground(p.surface1),
+	}
+}
+
+// stripedRowStyle shades a row a step off base, short of the selection
+// color so the cursor still stands out. Palettes with no such step (the
+// 16-color ones) leave stripes unshaded.
+func stripedRowStyle(p palette) lipgloss.Style {
+	s := lipgloss.NewStyle().Foreground(p.subtext0)
+	if p.surface0 != p.base {
+		s = s.Background(p.surface0)
+	}
+	return s
+}
+
+// artBackground is the color transparent covers are flattened onto: the
+// current theme's base.
+func (m Model) artBackground() color.Color {
+	return themes[m.themeIdx].palette(m.colorDepth).base
+}
+
+// setTheme switches to themes[i] at the model's color depth.
+func (m *Model) setTheme(i int) {
+	m.themeIdx = i
+	m.styles = newStyles(themes[i].palette(m.colorDepth))
+}
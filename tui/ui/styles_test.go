@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"testing"
+
+	lipgloss "charm.land/lipgloss/v2"
+)
+
+func TestDetectColorDepth(t *testing.T) {
+	tests := []struct {
+		name      string
+		colorTerm string
+		term      string
+		want      colorDepth
+	}{
+		{"colorterm truecolor", "truecolor", "xterm-256color", depthTrueColor},
+		{"colorterm 24bit", "24bit", "", depthTrueColor},
+		{"direct term", "", "xterm-direct", depthTrueColor},
+		{"kitty term", "", "xterm-kitty", depthTrueColor},
+		{"256 color term", "", "xterm-256color", depth256},
+		{"screen 256", "", "screen-256color", depth256},
+		{"plain xterm", "", "xterm", depth16},
+		{"linux console", "", "linux", depth16},
+		{"unset", "", "", depth16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLORTERM", tt.colorTerm)
+			t.Setenv("TERM", tt.term)
+			if got := detectColorDepth(); got != tt.want {
+				t.Errorf("detectColorDepth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThemePaletteSixteenColorTerm(t *testing.T) {
+	t.Setenv("COLORTERM", "")
+	t.Setenv("TERM", "xterm")
+
+	p := mochaTheme.palette(detectColorDepth())
+	if p != mochaTheme.ansi16 {
+		t.Fatalf("palette for TERM=xterm = %+v, want 16-color palette", p)
+	}
+	if p == mochaTheme.trueColor {
+		t.Error("16-color terminal should not get hex colors")
+	}
+}
+
+func TestThemePaletteForDepth(t *testing.T) {
+	for _, th := range themes {
+		if th.palette(depthTrueColor) != th.trueColor {
+			t.Errorf("%s: truecolor should use the hex palette", th.name)
+		}
+		if th.palette(depth256) != th.ansi256 {
+			t.Errorf("%s: 256 should use the 256-color palette", th.name)
+		}
+	}
+}
+
+func TestThemeIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"mocha", "mocha"},
+		{"latte", "latte"},
+		{" Gruvbox ", "gruvbox"},
+		{"NORD", "nord"},
+		{"solarized", "mocha"},
+		{"", "mocha"},
+	}
+	for _, tt := range tests {
+		if got := themes[themeIndex(tt.name)].name; got != tt.want {
+			t.Errorf("themeIndex(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThemeKeyCyclesAndRestyles(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.colorDepth = depthTrueColor
+	m.setTheme(0)
+	before := m.styles.title.GetForeground()
+
+	updated, _ := m.Update(keyMsg("T"))
+	model := updated.(Model)
+	if got := themes[model.themeIdx].name; got != "latte" {
+		t.Fatalf("theme after T = %q, want latte", got)
+	}
+	if model.styles.title.GetForeground() == before {
+		t.Error("styles should be rebuilt from the new palette")
+	}
+
+	for range len(themes) - 1 {
+		updated, _ = model.Update(keyMsg("T"))
+		model = updated.(Model)
+	}
+	if model.themeIdx != 0 {
+		t.Errorf("theme should wrap back to mocha, got %q", themes[model.themeIdx].name)
+	}
+}
+
+func TestWithThemeUnknownFallsBack(t *testing.T) {
+	m := newTestModel(testRecords()).WithTheme("nope")
+	if m.themeIdx != 0 {
+		t.Errorf("themeIdx = %d, want 0 (mocha)", m.themeIdx)
+	}
+}
+
+func TestStripedRowStyleSkipsSixteenColors(t *testing.T) {
+	for _, th := range themes {
+		if _, ok := stripedRowStyle(th.ansi16).GetBackground().(lipgloss.NoColor); !ok {
+			t.Errorf("%s: 16-color stripes should have no background", th.name)
+		}
+		if _, ok := stripedRowStyle(th.trueColor).GetBackground().(lipgloss.NoColor); ok {
+			t.Errorf("%s: truecolor stripes should be shaded", th.name)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"my-record-collection-tui/db"
+)
+
+type syncOutcome string
+
+const (
+	syncOutcomePulled   syncOutcome = "pulled"
+	syncOutcomePushed   syncOutcome = "pushed"
+	syncOutcomeNotFound syncOutcome = "not found"
+	syncOutcomeError    syncOutcome = "error"
+)
+
+// syncReportOrder lists outcomes as they appear in the report, problems
+// first so they are on screen without scrolling.
+var syncReportOrder = []syncOutcome{syncOutcomeError, syncOutcomeNotFound, syncOutcomePulled, syncOutcomePushed}
+
+// syncResult is the outcome of one record in a sync run. Records that were
+// already in both places are only counted in syncProgress.Skipped.
+type syncResult struct {
+	Outcome   syncOutcome
+	DiscogsID string
+	Artist    string
+	Album     string
+	Detail    string
+}
+
+func newSyncResult(rec db.Record, outcome syncOutcome, detail string) syncResult {
+	r := syncResult{Outcome: outcome, Artist: rec.ArtistName, Album: rec.AlbumTitle, Detail: detail}
+	if rec.DiscogsID != nil {
+		r.DiscogsID = *rec.DiscogsID
+	}
+	return r
+}
+
+func (r syncResult) label() string {
+	switch {
+	case r.Artist == "" && r.Album == "":
+		return r.DiscogsID
+	case r.DiscogsID == "":
+		return r.Artist + " — " + r.Album
+	default:
+		return fmt.Sprintf("%s — %s (%s)", r.Artist, r.Album, r.DiscogsID)
+	}
+}
+
+func (r syncResult) failed() bool {
+	return r.Outcome == syncOutcomeError || r.Outcome == syncOutcomeNotFound
+}
+
+// add records r and keeps the running counters and error lines in step.
+func (p *syncProgress) add(r syncResult) {
+	switch r.Outcome {
+	case syncOutcomePulled:
+		p.Pulled++
+	case syncOutcomePushed:
+		p.Pushed++
+	}
+	if r.failed() {
+		line := r.Detail
+		if l := r.label(); l != "" {
+			line = l + ": " + r.Detail
+		}
+		p.Errors = append(p.Errors, line)
+	}
+	p.Results = append(p.Results, r)
+}
+
+// groupSyncResults buckets results by outcome, preserving run order within
+// each bucket.
+func groupSyncResults(results []syncResult) map[syncOutcome][]syncResult {
+	groups := make(map[syncOutcome][]syncResult, len(syncReportOrder))
+	for _, r := range results {
+		groups[r.Outcome] = append(groups[r.Outcome], r)
+	}
+	return groups
+}
+
+// syncReportRow is one report line; result is the index into
+// orderedSyncResults, or -1 for headings and spacers.
+type syncReportRow struct {
+	text   string
+	result int
+}
+
+func orderedSyncResults(results []syncResult) []syncResult {
+	groups := groupSyncResults(results)
+	ordered := make([]syncResult, 0, len(results))
+	for _, o := range syncReportOrder {
+		ordered = append(ordered, groups[o]...)
+	}
+	return ordered
+}
+
+func syncReportRows(results []syncResult) []syncReportRow {
+	groups := groupSyncResults(results)
+	var rows []syncReportRow
+	idx := 0
+	for _, o := range syncReportOrder {
+		recs := groups[o]
+		if len(recs) == 0 {
+			continue
+		}
+		rows = append(rows, syncReportRow{text: fmt.Sprintf("%s (%d)", o, len(recs)), result: -1})
+		for _, r := range recs {
+			text := r.label()
+			if r.Detail != "" {
+				text += " — " + r.Detail
+			}
+			rows = append(rows, syncReportRow{text: text, result: idx})
+			idx++
+		}
+		rows = append(rows, syncReportRow{result: -1})
+	}
+	return rows
+}
+
+func (m Model) handleSyncReportKey(key string) (tea.Model, tea.Cmd) {
+	ordered := orderedSyncResults(m.syncResults)
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "q", "esc", "v":
+		m.view = listView
+	case "up":
+		if m.syncReportCursor > 0 {
+			m.syncReportCursor--
+		}
+	case "down":
+		if m.syncReportCursor < len(ordered)-1 {
+			m.syncReportCursor++
+		}
+	case "enter":
+		if m.syncReportCursor >= len(ordered) || ordered[m.syncReportCursor].DiscogsID == "" {
+			return m, nil
+		}
+		return m.openByDiscogsID(ordered[m.syncReportCursor].DiscogsID)
+	}
+	m.syncReportOffset = m.syncReportScroll()
+	return m, nil
+}
+
+// openByDiscogsID selects the record with the given Discogs ID in the list
+// and opens its detail view, clearing the search if it hides the record.
+func (m Model) openByDiscogsID(discogsID string) (tea.Model, tea.Cmd) {
+	return m.openMatching(func(r db.Record) bool {
+		return r.DiscogsID != nil && *r.DiscogsID == discogsID
+	})
+}
+
+// openMatching opens the detail view of the first listed record match
+// accepts, clearing the search if it hides every match.
+func (m Model) openMatching(match func(db.Record) bool) (tea.Model, tea.Cmd) {
+	find := func() int {
+		for row := range m.filtered {
+			if match(m.recordAt(row)) {
+				return row
+			}
+		}
+		return -1
+	}
+	row := find()
+	if row < 0 && m.search != "" {
+		m.search = ""
+		m.cancelSearch()
+		m.applyFilters()
+		row = find()
+	}
+	if row < 0 {
+		return m, nil
+	}
+	m.view = listView
+	m.cursor = row
+	visible := m.listVisibleRows()
+	m.offset = max(0, min(row-visible/2, len(m.filtered)-visible))
+	return m.handleListKey("enter")
+}
+
+// syncReportScroll returns an offset that keeps the cursor's row visible.
+func (m Model) syncReportScroll() int {
+	rows := syncReportRows(m.syncResults)
+	line := 0
+	for i, r := range rows {
+		if r.result == m.syncReportCursor {
+			line = i
+			break
+		}
+	}
+	visible := m.listVisibleRows()
+	offset := m.syncReportOffset
+	if line < offset+1 {
+		offset = max(0, line-1)
+	} else if line >= offset+visible {
+		offset = line - visible + 1
+	}
+	return offset
+}
+
+func (m Model) renderSyncReport() string {
+	var b strings.Builder
+	title := m.styles.title.Render("♫ Sync Report")
+	counts := fmt.Sprintf("pulled:%d pushed:%d skipped:%d failed:%d",
+		m.syncPulled, m.syncPushed, m.syncSkipped, len(m.syncErrors))
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", m.styles.statusBar.Render(counts)))
+	b.WriteString("\n\n")
+
+	rows := syncReportRows(m.syncResults)
+	if len(rows) == 0 {
+		b.WriteString("  Nothing was pulled, pushed, or failed in the last sync.\n")
+	}
+	visible := m.listVisibleRows()
+	start := min(m.syncReportOffset, max(0, len(rows)-1))
+	end := min(start+visible, len(rows))
+	for _, row := range rows[start:end] {
+		switch {
+		case row.result < 0 && row.text != "":
+			b.WriteString(m.styles.label.Width(0).Render("  " + row.text))
+		case row.result == m.syncReportCursor:
+			b.WriteString(m.styles.selectedRow.Render("    " + truncPad(row.text, max(m.width-6, 20))))
+		case row.result >= 0:
+			b.WriteString(m.styles.normalRow.Render("    " + row.text))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.helpLine(syncReportKeys))
+	return b.String()
+}
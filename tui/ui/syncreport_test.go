@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestSyncProgressAdd(t *testing.T) {
+	var p syncProgress
+	p.add(syncResult{Outcome: syncOutcomePulled, DiscogsID: "1", Artist: "A", Album: "One"})
+	p.add(syncResult{Outcome: syncOutcomePushed, DiscogsID: "2", Artist: "B", Album: "Two"})
+	p.add(syncResult{Outcome: syncOutcomeNotFound, DiscogsID: "3", Artist: "C", Album: "Three", Detail: "release not on Discogs"})
+	p.add(syncResult{Outcome: syncOutcomeError, Detail: "mark synced: boom"})
+
+	if p.Pulled != 1 || p.Pushed != 1 {
+		t.Errorf("pulled/pushed = %d/%d, want 1/1", p.Pulled, p.Pushed)
+	}
+	if len(p.Results) != 4 {
+		t.Fatalf("results = %d, want 4", len(p.Results))
+	}
+	want := []string{"C — Three (3): release not on Discogs", "mark synced: boom"}
+	if len(p.Errors) != len(want) {
+		t.Fatalf("errors = %q, want %q", p.Errors, want)
+	}
+	for i := range want {
+		if p.Errors[i] != want[i] {
+			t.Errorf("errors[%d] = %q, want %q", i, p.Errors[i], want[i])
+		}
+	}
+}
+
+func TestOrderedSyncResults(t *testing.T) {
+	results := []syncResult{
+		{Outcome: syncOutcomePulled, DiscogsID: "1"},
+		{Outcome: syncOutcomeError, DiscogsID: "2"},
+		{Outcome: syncOutcomePushed, DiscogsID: "3"},
+		{Outcome: syncOutcomeNotFound, DiscogsID: "4"},
+		{Outcome: syncOutcomeError, DiscogsID: "5"},
+	}
+	var got []string
+	for _, r := range orderedSyncResults(results) {
+		got = append(got, r.DiscogsID)
+	}
+	if strings.Join(got, ",") != "2,5,4,1,3" {
+		t.Errorf("order = %v, want errors, not found, pulled, pushed", got)
+	}
+
+	groups := groupSyncResults(results)
+	if len(groups[syncOutcomeError]) != 2 || len(groups[syncOutcomeNotFound]) != 1 {
+		t.Errorf("groups = %v", groups)
+	}
+}
+
+func TestSyncReportRows(t *testing.T) {
+	rows := syncReportRows([]syncResult{
+		{Outcome: syncOutcomePulled, DiscogsID: "1", Artist: "A", Album: "One"},
+		{Outcome: syncOutcomeError, DiscogsID: "2", Artist: "B", Album: "Two", Detail: "push: 500"},
+	})
+	want := []syncReportRow{
+		{text: "error (1)", result: -1},
+		{text: "B — Two (2) — push: 500", result: 0},
+		{result: -1},
+		{text: "pulled (1)", result: -1},
+		{text: "A — One (1)", result: 1},
+		{result: -1},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("rows[%d] = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestExecuteSyncCollectsResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"pagination":{"page":1,"pages":1,"items":1},"releases":[
+				{"basic_information":{"id":100,"title":"New","artists":[{"name":"Pulled"}]}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/200"):
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasSuffix(r.URL.Path, "/300"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("DISCOGS_BASE_URL", srv.URL)
+
+	store := &mockStore{records: []db.Record{
+		{RecordID: "a", ArtistName: "Pushed", AlbumTitle: "Ok", DiscogsID: stringPointer("200")},
+		{RecordID: "b", ArtistName: "Missing", AlbumTitle: "Gone", DiscogsID: stringPointer("300")},
+		{RecordID: "c", ArtistName: "Broken", AlbumTitle: "Err", DiscogsID: stringPointer("400")},
+	}}
+	var last syncProgress
+	err := executeSync(context.Background(), store, "me", discogsConfig{token: "t"}, func(p syncProgress) { last = p })
+	if err != nil {
+		t.Fatalf("executeSync: %v", err)
+	}
+
+	got := make(map[string]syncOutcome)
+	for _, r := range last.Results {
+		got[r.DiscogsID] = r.Outcome
+	}
+	want := map[string]syncOutcome{
+		"100": syncOutcomePulled,
+		"200": syncOutcomePushed,
+		"300": syncOutcomeNotFound,
+		"400": syncOutcomeError,
+	}
+	for id, outcome := range want {
+		if got[id] != outcome {
+			t.Errorf("outcome for %s = %q, want %q", id, got[id], outcome)
+		}
+	}
+	if last.Pulled != 1 || last.Pushed != 1 || len(last.Errors) != 2 {
+		t.Errorf("pulled=%d pushed=%d errors=%d, want 1/1/2", last.Pulled, last.Pushed, len(last.Errors))
+	}
+}
+
+func TestSyncReportEnterOpensRecord(t *testing.T) {
+	recs := []db.Record{
+		{RecordID: "a", ArtistName: "Fine", AlbumTitle: "Ok", DiscogsID: stringPointer("1")},
+		{RecordID: "b", ArtistName: "Missing", AlbumTitle: "Gone", DiscogsID: stringPointer("2")},
+	}
+	m := newTestModel(recs)
+	m.syncResults = []syncResult{
+		{Outcome: syncOutcomePushed, DiscogsID: "1"},
+		newSyncResult(recs[1], syncOutcomeNotFound, "release not on Discogs"),
+	}
+
+	updated, _ := m.Update(keyMsg("v"))
+	model := updated.(Model)
+	if model.view != syncReportView {
+		t.Fatalf("view = %d, want syncReportView", model.view)
+	}
+	if !strings.Contains(model.View().Content, "Missing — Gone (2)") {
+		t.Error("report should list the not-found record")
+	}
+
+	updated, _ = model.Update(keyMsg("enter"))
+	model = updated.(Model)
+	if model.view != detailView {
+		t.Fatalf("view after enter = %d, want detailView", model.view)
+	}
+	if rec, _ := model.selectedRecord(); rec.RecordID != "b" {
+		t.Errorf("selected = %q, want %q", rec.RecordID, "b")
+	}
+}
+
+func TestListCapsSyncErrors(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.syncErrors = []string{"e1", "e2", "e3", "e4", "e5"}
+	v := m.renderList()
+	if strings.Contains(v, "e4") {
+		t.Error("list should not show errors past the cap")
+	}
+	if !strings.Contains(v, "and 2 more") {
+		t.Error("list should point at the sync report for the rest")
+	}
+}
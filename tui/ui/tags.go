@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+type tagToggledMsg struct {
+	id    string
+	tag   string
+	added bool
+	tags  []string
+	err   error
+}
+
+func toggleTag(ctx context.Context, store db.Store, id, tag string, add bool) tea.Cmd {
+	return func() tea.Msg {
+		var tags []string
+		var err error
+		if add {
+			tags, err = store.AddTag(ctx, id, tag)
+		} else {
+			tags, err = store.RemoveTag(ctx, id, tag)
+		}
+		return tagToggledMsg{id: id, tag: tag, added: add, tags: tags, err: err}
+	}
+}
+
+// tagCommand handles "tag <name>": it puts the tag on the selected record,
+// or takes it off if the record already has it. The name runs to the end
+// of the line so shelves like "For Sale" work unquoted.
+func (m Model) tagCommand(args string) (tea.Model, tea.Cmd) {
+	if m.readOnly {
+		return m, m.setStatus(readOnlyStatus)
+	}
+	if args == "" {
+		m.commandErr = "usage: tag <name>"
+		return m, nil
+	}
+	rec, ok := m.selectedRecord()
+	if !ok {
+		m.commandErr = "tag: no record selected"
+		return m, nil
+	}
+	return m, toggleTag(m.ctx, m.store, rec.RecordID, args, !rec.HasTag(args))
+}
+
+func (m Model) handleTagToggled(msg tagToggledMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.commandErr = "tag: " + msg.err.Error()
+		return m, nil
+	}
+	m.records = withRecordTags(m.records, msg.id, msg.tags)
+	// Re-filter so a record untagged while its tag is the filter drops out.
+	m.applyFilters()
+	m.cursor = max(0, min(m.cursor, len(m.filtered)-1))
+	m.keepCursorVisible()
+	verb := "Removed tag"
+	if msg.added {
+		verb = "Tagged"
+	}
+	return m, m.setStatus(fmt.Sprintf("%s %q.", verb, strings.TrimSpace(msg.tag)))
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func shelfRecords() []db.Record {
+	return []db.Record{
+		{RecordID: "1", ArtistName: "Miles Davis", AlbumTitle: "Kind of Blue", Tags: []string{"Favorites"}},
+		{RecordID: "2", ArtistName: "Nina Simone", AlbumTitle: "Pastel Blues", Tags: []string{"Wishlist", "favorites"}},
+		{RecordID: "3", ArtistName: "Sun Ra", AlbumTitle: "Lanquidity"},
+	}
+}
+
+func TestCommandFilterTag(t *testing.T) {
+	m := newTestModel(shelfRecords())
+	m, cmd := typeCommand(t, m, "filter tag=favorites")
+	if m.tagFilter != "favorites" || cmd == nil {
+		t.Fatalf("tagFilter = %q, want favorites and a refetch", m.tagFilter)
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if got := recordIDs(m.filtered); got != "1,2" {
+		t.Errorf("tagged records = %s, want 1,2 regardless of case", got)
+	}
+	if !strings.Contains(m.renderList(), `tagged "favorites"`) {
+		t.Error("list title should name the tag filter")
+	}
+
+	updated, cmd = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.tagFilter != "" || cmd == nil {
+		t.Errorf("esc should clear the tag filter and reload, got %q", m.tagFilter)
+	}
+}
+
+func TestCommandToggleTag(t *testing.T) {
+	m := newTestModel(shelfRecords())
+	m.cursor = 2
+
+	m, cmd := typeCommand(t, m, "tag For Sale")
+	if cmd == nil {
+		t.Fatal("tag should save")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	rec, _ := m.selectedRecord()
+	if !rec.HasTag("For Sale") || !strings.Contains(m.status, `Tagged "For Sale"`) {
+		t.Fatalf("tags = %v status %q, want For Sale added", rec.Tags, m.status)
+	}
+
+	m, cmd = typeCommand(t, m, "tag for sale")
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	rec, _ = m.selectedRecord()
+	if rec.HasTag("For Sale") || !strings.Contains(m.status, "Removed tag") {
+		t.Errorf("tags = %v status %q, want the tag toggled off", rec.Tags, m.status)
+	}
+}
+
+func TestUntagDropsOutOfTagFilter(t *testing.T) {
+	m := newTestModel(shelfRecords())
+	m.tagFilter = "wishlist"
+	m.applyFilters()
+	if len(m.filtered) != 1 {
+		t.Fatalf("filtered = %d, want the one wishlist record", len(m.filtered))
+	}
+
+	m, cmd := typeCommand(t, m, "tag Wishlist")
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if len(m.filtered) != 0 || m.cursor != 0 {
+		t.Errorf("filtered = %d cursor = %d, want the untagged record gone", len(m.filtered), m.cursor)
+	}
+}
+
+func TestCommandTagErrors(t *testing.T) {
+	m := newTestModel(shelfRecords())
+	m, _ = typeCommand(t, m, "tag")
+	if !strings.Contains(m.commandErr, "usage: tag") {
+		t.Errorf("commandErr = %q, want usage", m.commandErr)
+	}
+
+	m.readOnly = true
+	m, _ = typeCommand(t, m, "tag Wishlist")
+	if m.status != readOnlyStatus {
+		t.Errorf("status = %q, want the read-only notice", m.status)
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// kittyDeleteAll removes every image placement and frees its data.
+var kittyDeleteAll = ansi.KittyGraphics(nil, "a=d", "d=A")
+
+// clearScreen wipes cells holding inline iTerm2 or sixel images, which stay
+// in the text buffer and have no delete command of their own. Scrollback is
+// left alone: it's the user's shell history, not ours.
+const clearScreen = ansi.EraseEntireScreen + ansi.CursorHomePosition
+
+// markDrawn records that art was shown with m.imgProto.
+func (m *Model) markDrawn() {
+	m.protosDrawn |= 1 << m.imgProto
+}
+
+// ClearGraphics returns the escape sequences that remove images left on
+// screen by the graphics protocols used this session, for writing to the
+// terminal after the program exits. Text-based protocols need nothing.
+func (m Model) ClearGraphics() string {
+	drawn := func(p imageProto) bool { return m.protosDrawn&(1<<p) != 0 }
+	var b strings.Builder
+	if drawn(protoKitty) {
+		b.WriteString(kittyDeleteAll)
+	}
+	if drawn(protoITerm2) || drawn(protoSixel) {
+		b.WriteString(clearScreen)
+	}
+	return b.String()
+}
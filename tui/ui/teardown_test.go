@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClearGraphics(t *testing.T) {
+	tests := []struct {
+		name  string
+		drawn []imageProto
+		want  string
+	}{
+		{"nothing drawn", nil, ""},
+		{"text protocols", []imageProto{protoMosaic, protoHalfBlock}, ""},
+		{"kitty", []imageProto{protoKitty}, kittyDeleteAll},
+		{"iterm2", []imageProto{protoITerm2}, clearScreen},
+		{"kitty and sixel", []imageProto{protoSixel, protoKitty}, kittyDeleteAll + clearScreen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(nil)
+			for _, p := range tt.drawn {
+				m.imgProto = p
+				m.markDrawn()
+			}
+			if got := m.ClearGraphics(); got != tt.want {
+				t.Errorf("ClearGraphics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClearGraphicsKeepsScrollback(t *testing.T) {
+	m := newTestModel(nil)
+	m.imgProto = protoSixel
+	m.markDrawn()
+	got := m.ClearGraphics()
+	if got != "\x1b[2J\x1b[H" {
+		t.Errorf("ClearGraphics() = %q, want erase screen and cursor home", got)
+	}
+	if strings.Contains(got, "\x1b[3J") {
+		t.Errorf("ClearGraphics() = %q erases the scrollback", got)
+	}
+}
+
+func TestShownArtMarksProtocolDrawn(t *testing.T) {
+	m := newTestModel(testRecords())
+	m.view = detailView
+	m.imgProto = protoKitty
+	updated, _ := m.Update(imageLoadedMsg{proto: protoKitty, render: "placeholder", transmit: "\x1b_Gdata\x1b\\"})
+	if got := updated.(Model).ClearGraphics(); got != kittyDeleteAll {
+		t.Errorf("ClearGraphics() after a kitty image = %q, want kitty delete", got)
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"image/color"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"my-record-collection-tui/db"
+)
+
+// thumbSize is a list thumbnail: one row tall and two columns wide, which
+// is roughly square in most fonts.
+var thumbSize = artSize{w: 2, h: 1}
+
+const (
+	// thumbPixels bounds the image sent for a thumbnail; the terminal
+	// scales it into thumbSize, so full-size covers would only waste
+	// bandwidth.
+	thumbPixels = 64
+
+	// maxThumbLoads is how many thumbnails load at once.
+	maxThumbLoads = 4
+)
+
+type thumbLoadedMsg struct {
+	url      string
+	render   string
+	transmit string
+}
+
+// WithThumbnails shows a cover thumbnail at the start of each list row
+// when the terminal supports it.
+func (m Model) WithThumbnails(on bool) Model {
+	m.thumbnails = on
+	return m
+}
+
+// thumbnailsShown reports whether the thumbnail column is drawn. Only
+// kitty can place an image inside a row of text (its placeholders are
+// ordinary cells), so other protocols leave the list as it is.
+func (m Model) thumbnailsShown() bool {
+	return m.thumbnails && m.imgProto == protoKitty
+}
+
+// thumbnailWidth is the gutter taken by the thumbnail column.
+func (m Model) thumbnailWidth() int {
+	if !m.thumbnailsShown() {
+		return 0
+	}
+	return thumbSize.w + 1
+}
+
+// thumbnailCell is rec's thumbnail once loaded, blank until then; with a
+// nil rec it's the header gutter.
+func (m Model) thumbnailCell(rec *db.Record) string {
+	w := m.thumbnailWidth()
+	if w == 0 {
+		return ""
+	}
+	if rec != nil {
+		if cached, ok := m.imgCache.get(rec.ListImageURL(), protoKitty, thumbSize); ok && cached.render != "" {
+			return cached.render + " "
+		}
+	}
+	return strings.Repeat(" ", w)
+}
+
+func loadThumbnail(ctx context.Context, cache *diskCache, url string, bg color.Color) tea.Cmd {
+	return func() tea.Msg {
+		img, _, err := fetchImage(ctx, cache, url)
+		if err != nil {
+			return thumbLoadedMsg{url: url}
+		}
+		img = fitImage(flattenImage(img, bg), thumbPixels, thumbPixels)
+		kr := renderKitty(img, thumbSize.w, thumbSize.h)
+		return thumbLoadedMsg{url: url, render: kr.placeholder, transmit: kr.transmit}
+	}
+}
+
+// loadThumbnails starts loads for visible rows without a thumbnail, at
+// most maxThumbLoads at a time. Each finished load calls it again, so the
+// rest follow as slots free up.
+func (m Model) loadThumbnails() (Model, tea.Cmd) {
+	if !m.thumbnailsShown() {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	end := min(m.offset+m.listVisibleRows(), len(m.filtered))
+	for i := m.offset; i < end && m.thumbInFlight < maxThumbLoads; i++ {
+		url := m.recordAt(i).ListImageURL()
+		if url == "" || !m.imgCache.claim(url, protoKitty, thumbSize) {
+			continue
+		}
+		m.thumbInFlight++
+		cmds = append(cmds, loadThumbnail(m.ctx, m.imgDiskCache, url, m.artBackground()))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) handleThumbLoaded(msg thumbLoadedMsg) (tea.Model, tea.Cmd) {
+	m.imgCache.set(msg.url, protoKitty, thumbSize, cachedImage{render: msg.render, transmit: msg.transmit})
+	m.thumbInFlight = max(m.thumbInFlight-1, 0)
+	var cmds []tea.Cmd
+	if msg.transmit != "" {
+		m.protosDrawn |= 1 << protoKitty
+		cmds = append(cmds, tea.Raw(msg.transmit))
+	}
+	var more tea.Cmd
+	m, more = m.loadThumbnails()
+	return m, tea.Batch(append(cmds, more)...)
+}
+
+func (m Model) toggleThumbnails() (Model, tea.Cmd) {
+	m.thumbnails = !m.thumbnails
+	if m.thumbnails && !m.thumbnailsShown() {
+		return m, m.setStatus("Thumbnails need a terminal with the kitty graphics protocol.")
+	}
+	return m.loadThumbnails()
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"my-record-collection-tui/db"
+)
+
+func TestThumbnailsShown(t *testing.T) {
+	tests := []struct {
+		proto imageProto
+		on    bool
+		want  bool
+	}{
+		{protoKitty, true, true},
+		{protoKitty, false, false},
+		{protoMosaic, true, false},
+		{protoHalfBlock, true, false},
+		{protoSixel, true, false},
+		{protoITerm2, true, false},
+	}
+	for _, tt := range tests {
+		m := newTestModel(testRecords()).WithThumbnails(tt.on)
+		m.imgProto = tt.proto
+		if got := m.thumbnailsShown(); got != tt.want {
+			t.Errorf("thumbnailsShown(%v, %v) = %v, want %v", tt.proto, tt.on, got, tt.want)
+		}
+		if got := m.thumbnailWidth() > 0; got != tt.want {
+			t.Errorf("thumbnailWidth(%v, %v) = %d, want gutter %v", tt.proto, tt.on, m.thumbnailWidth(), tt.want)
+		}
+	}
+}
+
+func thumbnailModel(t *testing.T, n int) Model {
+	t.Helper()
+	server := servePNG(t)
+	t.Cleanup(server.Close)
+
+	var recs []db.Record
+	for i := range n {
+		url := fmt.Sprintf("%s/%d.png", server.URL, i)
+		recs = append(recs, db.Record{RecordID: fmt.Sprint(i), ArtistName: fmt.Sprintf("Artist %02d", i), ThumbnailURL: &url})
+	}
+	m := newTestModel(recs).WithThumbnails(true)
+	m.imgProto = protoKitty
+	m.imgDiskCache = nil
+	return m
+}
+
+func TestLoadThumbnailsThrottled(t *testing.T) {
+	m := thumbnailModel(t, 10)
+
+	m, cmd := m.loadThumbnails()
+	pending := runBatch(cmd)
+	if len(pending) != maxThumbLoads || m.thumbInFlight != maxThumbLoads {
+		t.Fatalf("started %d loads (%d in flight), want %d", len(pending), m.thumbInFlight, maxThumbLoads)
+	}
+	for len(pending) > 0 {
+		msg := pending[0]
+		pending = pending[1:]
+		if _, ok := msg.(thumbLoadedMsg); !ok {
+			// The transmit escape for a loaded thumbnail.
+			continue
+		}
+		updated, next := m.Update(msg)
+		m = updated.(Model)
+		if m.thumbInFlight > maxThumbLoads {
+			t.Fatalf("%d loads in flight, want at most %d", m.thumbInFlight, maxThumbLoads)
+		}
+		pending = append(pending, runBatch(next)...)
+	}
+	if m.thumbInFlight != 0 {
+		t.Errorf("thumbInFlight = %d after every load finished, want 0", m.thumbInFlight)
+	}
+	for i := range 10 {
+		rec := m.recordAt(i)
+		if cached, ok := m.imgCache.get(rec.ListImageURL(), protoKitty, thumbSize); !ok || cached.render == "" {
+			t.Errorf("record %d has no thumbnail", i)
+		}
+	}
+	if m.protosDrawn&(1<<protoKitty) == 0 {
+		t.Error("transmitted thumbnails should be cleared on exit")
+	}
+}
+
+func TestThumbnailColumnAligned(t *testing.T) {
+	m := thumbnailModel(t, 3)
+	rec := m.recordAt(0)
+	kr := renderKitty(testImage(), thumbSize.w, thumbSize.h)
+	m.imgCache.set(rec.ListImageURL(), protoKitty, thumbSize, cachedImage{render: kr.placeholder})
+
+	rows := listLines(m)
+	if len(rows) != 4 {
+		t.Fatalf("found %d header and record rows, want 4", len(rows))
+	}
+	if !strings.HasPrefix(rows[1], kr.placeholder) {
+		t.Error("loaded thumbnail should lead its row")
+	}
+	plain := listLines(m.WithThumbnails(false))
+	for i, row := range rows {
+		if got, want := ansi.StringWidth(row), ansi.StringWidth(plain[i]); got != want {
+			t.Errorf("row %d is %d cells wide, want %d as without thumbnails", i, got, want)
+		}
+	}
+}
+
+// listLines is the list's header and record rows.
+func listLines(m Model) []string {
+	var rows []string
+	for _, line := range strings.Split(m.renderList(), "\n") {
+		if strings.Contains(line, "Artist") {
+			rows = append(rows, line)
+		}
+	}
+	return rows
+}
+
+func TestToggleThumbnails(t *testing.T) {
+	m := thumbnailModel(t, 3)
+	m.thumbnails = false
+
+	updated, cmd := m.Update(keyMsg("I"))
+	m = updated.(Model)
+	if !m.thumbnails || len(runBatch(cmd)) == 0 {
+		t.Fatal("I should turn thumbnails on and start loading them")
+	}
+
+	m.thumbnails = false
+	m.imgProto = protoMosaic
+	updated, _ = m.Update(keyMsg("I"))
+	m = updated.(Model)
+	if !strings.Contains(m.status, "kitty") {
+		t.Errorf("status = %q, want a note that thumbnails need kitty", m.status)
+	}
+	if strings.Contains(m.renderList(), "\U0010EEEE") {
+		t.Error("mosaic terminals should not get thumbnail placeholders")
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// relativeTime describes how long before now t was, in the largest whole
+// unit: "just now", "5 minutes ago", "3 months ago".
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < time.Minute {
+		return "just now"
+	}
+	unit := func(n int, name string) string {
+		if n == 1 {
+			return "1 " + name + " ago"
+		}
+		return fmt.Sprintf("%d %ss ago", n, name)
+	}
+	switch days := int(d.Hours() / 24); {
+	case d < time.Hour:
+		return unit(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return unit(int(d.Hours()), "hour")
+	case days < 30:
+		return unit(days, "day")
+	case days < 365:
+		return unit(days/30, "month")
+	default:
+		return unit(days/365, "year")
+	}
+}
+
+// timestampString shows t as a local date with its relative age, or "—"
+// for a zero time.
+func timestampString(t, now time.Time) string {
+	if t.IsZero() {
+		return "—"
+	}
+	return t.Local().Format("2006-01-02 15:04") + " (" + relativeTime(t, now) + ")"
+}
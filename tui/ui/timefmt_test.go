@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{45 * time.Minute, "45 minutes ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{95 * 24 * time.Hour, "3 months ago"},
+		{800 * 24 * time.Hour, "2 years ago"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("relativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestTimestampString(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.Local)
+	if got := timestampString(time.Time{}, now); got != "—" {
+		t.Errorf("zero time = %q, want —", got)
+	}
+	added := time.Date(2026, 6, 12, 9, 30, 0, 0, time.Local)
+	if got := timestampString(added, now); got != "2026-06-12 09:30 (3 days ago)" {
+		t.Errorf("timestampString = %q", got)
+	}
+}
+
+func TestDetailShowsTimestamps(t *testing.T) {
+	recs := testRecords()
+	recs[0].CreatedAt = time.Now().Add(-48 * time.Hour)
+	m := newTestModel(recs)
+	m.view = detailView
+	view := m.renderDetail()
+	if !strings.Contains(view, "Added") || !strings.Contains(view, "2 days ago") {
+		t.Errorf("detail should show when the record was added:\n%s", view)
+	}
+	if !strings.Contains(view, "Updated") || !strings.Contains(view, "—") {
+		t.Errorf("detail should show a dash for a missing update time:\n%s", view)
+	}
+}
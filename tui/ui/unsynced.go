@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"slices"
+
+	"my-record-collection-tui/db"
+)
+
+// toggleUnsynced shows only records not yet synced with Discogs, or shows
+// everything again. The selected record stays selected when it is still
+// in the list.
+func (m Model) toggleUnsynced() Model {
+	var id string
+	if rec, ok := m.selectedRecord(); ok {
+		id = rec.RecordID
+	}
+	m.unsyncedOnly = !m.unsyncedOnly
+	m.applyFilters()
+	m.cursor, m.offset = 0, 0
+	i := slices.IndexFunc(m.filtered, func(r db.Record) bool { return r.RecordID == id })
+	if i < 0 {
+		if m.reverseList {
+			m.cursor = max(0, len(m.filtered)-1)
+			m.offset = max(0, len(m.filtered)-m.listVisibleRows())
+		}
+		return m
+	}
+	m.cursor = i
+	if m.reverseList {
+		m.cursor = len(m.filtered) - 1 - i
+	}
+	m.keepCursorVisible()
+	return m
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToggleUnsynced(t *testing.T) {
+	recs := testRecords()
+	recs[0].IsSyncedWithDiscogs = true
+	recs[2].IsSyncedWithDiscogs = true
+	m := newTestModel(recs)
+	m.cursor = 1
+
+	updated, _ := m.Update(keyMsg("u"))
+	m = updated.(Model)
+	if got := recordIDs(m.filtered); got != "2" {
+		t.Fatalf("filtered = %s, want 2", got)
+	}
+	if rec, _ := m.selectedRecord(); rec.RecordID != "2" {
+		t.Errorf("selected = %s, want 2 kept", rec.RecordID)
+	}
+	if !strings.Contains(m.renderList(), "unsynced only") {
+		t.Error("title should show the unsynced filter")
+	}
+
+	updated, _ = m.Update(keyMsg("u"))
+	m = updated.(Model)
+	if got := recordIDs(m.filtered); got != "2,1,3" {
+		t.Errorf("filtered = %s after toggling off, want 2,1,3", got)
+	}
+	if rec, _ := m.selectedRecord(); rec.RecordID != "2" {
+		t.Errorf("selected = %s, want 2 kept", rec.RecordID)
+	}
+	if strings.Contains(m.renderList(), "unsynced only") {
+		t.Error("title should drop the indicator")
+	}
+}
+
+func TestUnsyncedWithSearch(t *testing.T) {
+	recs := testRecords()
+	recs[1].IsSyncedWithDiscogs = true
+	m := newTestModel(recs)
+	m.search = "m"
+	m.applyFilters()
+	before := recordIDs(m.filtered)
+
+	updated, _ := m.Update(keyMsg("u"))
+	m = updated.(Model)
+	for _, r := range m.filtered {
+		if r.IsSyncedWithDiscogs {
+			t.Errorf("synced record %s shown", r.RecordID)
+		}
+		if !strings.Contains(strings.ToLower(r.ArtistName+r.AlbumTitle), "m") {
+			t.Errorf("record %s does not match the search", r.RecordID)
+		}
+	}
+
+	updated, _ = m.Update(keyMsg("u"))
+	if got := recordIDs(updated.(Model).filtered); got != before {
+		t.Errorf("filtered = %s after toggling off, want %s", got, before)
+	}
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// maxYearInputRunes fits "1970 - 1979" with room for stray spaces.
+const maxYearInputRunes = 16
+
+// yearRange limits the list to records released from..to inclusive; the
+// zero value means no limit.
+type yearRange struct {
+	from, to int
+}
+
+func (r yearRange) active() bool {
+	return r != yearRange{}
+}
+
+func (r yearRange) String() string {
+	if r.from == r.to {
+		return strconv.Itoa(r.from)
+	}
+	return fmt.Sprintf("%d–%d", r.from, r.to)
+}
+
+// parseYearRange accepts "1970-1979" or a single year like "1975".
+func parseYearRange(s string) (yearRange, error) {
+	from, to, found := strings.Cut(s, "-")
+	if !found {
+		to = from
+	}
+	a, errA := strconv.Atoi(strings.TrimSpace(from))
+	b, errB := strconv.Atoi(strings.TrimSpace(to))
+	if errA != nil || errB != nil || a <= 0 || b <= 0 {
+		return yearRange{}, errors.New("enter a year or a range like 1970-1979")
+	}
+	if a > b {
+		return yearRange{}, fmt.Errorf("%d is after %d", a, b)
+	}
+	return yearRange{from: a, to: b}, nil
+}
+
+func (m Model) handleYearPromptKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.yearPrompt = false
+		m.yearErr = ""
+		return m, nil
+	case "enter":
+		var r yearRange
+		if input := strings.TrimSpace(m.yearInput); input != "" {
+			var err error
+			if r, err = parseYearRange(input); err != nil {
+				m.yearErr = err.Error()
+				return m, nil
+			}
+		}
+		m.yearPrompt = false
+		m.yearErr = ""
+		if r == m.yearRange {
+			return m, nil
+		}
+		m.yearRange = r
+		m.loading = true
+		return m, m.fetchRecords(false)
+	case "backspace":
+		runes := []rune(m.yearInput)
+		if len(runes) > 0 {
+			m.yearInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	default:
+		r, ok := inputKeyRune(key)
+		if ok && utf8.RuneCountInString(m.yearInput) < maxYearInputRunes {
+			m.yearInput += string(r)
+		}
+		return m, nil
+	}
+}
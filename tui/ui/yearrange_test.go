@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"my-record-collection-tui/db"
+)
+
+func TestParseYearRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    yearRange
+		wantErr bool
+	}{
+		{"1970-1979", yearRange{1970, 1979}, false},
+		{" 1970 - 1979 ", yearRange{1970, 1979}, false},
+		{"1975", yearRange{1975, 1975}, false},
+		{"1979-1970", yearRange{}, true},
+		{"seventies", yearRange{}, true},
+		{"1970-", yearRange{}, true},
+		{"0-1970", yearRange{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseYearRange(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseYearRange(%q) = %v, %v; want %v, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func yearTestRecords() []db.Record {
+	recs := testRecords()
+	recs[0].YearReleased = new(1959)
+	recs[1].YearReleased = new(1965)
+	recs[2].YearReleased = nil
+	return recs
+}
+
+func typeYears(m Model, input string) Model {
+	updated, _ := m.Update(keyMsg("Y"))
+	m = updated.(Model)
+	for _, r := range input {
+		updated, _ = m.Update(keyMsg(string(r)))
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestYearRangeFilter(t *testing.T) {
+	recs := yearTestRecords()
+	m := newTestModel(recs)
+	m.store = &mockStore{records: recs}
+
+	m = typeYears(m, "1960-1969")
+	if !m.yearPrompt || !strings.Contains(m.renderList(), "Years") {
+		t.Fatal("Y should open the year prompt")
+	}
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if m.yearPrompt || cmd == nil {
+		t.Fatal("enter should close the prompt and reload")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if got := recordIDs(m.filtered); got != "2" {
+		t.Errorf("filtered = %s, want 2", got)
+	}
+	if !strings.Contains(m.renderList(), "from 1960–1969") {
+		t.Error("title should show the active range")
+	}
+
+	updated, cmd = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.yearRange.active() || cmd == nil {
+		t.Fatal("esc should clear the range and reload")
+	}
+	updated, _ = m.Update(cmd())
+	if got := recordIDs(updated.(Model).filtered); got != "2,1,3" {
+		t.Errorf("filtered = %s after clearing, want 2,1,3", got)
+	}
+}
+
+func TestYearRangeExcludesMissingYears(t *testing.T) {
+	recs := yearTestRecords()
+	m := newTestModel(recs)
+	m.yearRange = yearRange{1900, 2100}
+	m.applyFilters()
+	if got := recordIDs(m.filtered); got != "2,1" {
+		t.Errorf("filtered = %s, want records without a year left out", got)
+	}
+}
+
+func TestYearPromptInvalid(t *testing.T) {
+	m := typeYears(newTestModel(yearTestRecords()), "1979-1970")
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(Model)
+	if !m.yearPrompt || cmd != nil {
+		t.Fatal("an invalid range should keep the prompt open")
+	}
+	if !strings.Contains(m.renderList(), "1979 is after 1970") {
+		t.Error("prompt should show the parse error")
+	}
+
+	updated, _ = m.Update(keyMsg("esc"))
+	m = updated.(Model)
+	if m.yearPrompt || m.yearRange.active() {
+		t.Error("esc should cancel without applying")
+	}
+}